@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+// ackCmd represents the ack command
+var ackCmd = &cobra.Command{
+	Use:   "ack [date] [code...]",
+	Short: "Acknowledges the validation findings of a day",
+	Long: `Days that break the schedule or a labor rule get findings when they are
+finished, backfilled or edited. The ack command dismisses the findings you
+have reviewed, so they no longer show up in status and reports.
+
+Without arguments it lists the findings that still need a review. With a date
+in the YYYY-MM-DD format it acknowledges the findings of that day, or only
+those with the given codes. A finding that is found again when the day is
+edited stays acknowledged.
+
+Examples:
+  workday ack
+  workday ack 2026-04-07
+  workday ack 2026-04-07 lunch_break
+  workday ack --all`,
+	RunE: acknowledgeFindings,
+}
+
+// acknowledgeDay acknowledges the findings of the entry with the given ID, or
+// only those with the given codes, and returns how many it acknowledged.
+func acknowledgeDay(store journal.Store, id string, codes []string) (int, error) {
+	entry, err := store.Get(id)
+	if err != nil {
+		return 0, err
+	}
+	count := entry.Acknowledge(codes...)
+	if count == 0 {
+		return 0, nil
+	}
+	return count, store.Upsert(*entry)
+}
+
+// acknowledgeAll acknowledges every finding in the journal, saving the
+// entries it changed in a single write, and returns how many it acknowledged.
+func acknowledgeAll(store journal.Store) (int, error) {
+	entries, err := store.All()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	var changes []journal.Change
+	for _, entry := range entries {
+		count := entry.Acknowledge()
+		if count == 0 {
+			continue
+		}
+		changes = append(changes, journal.Change{Entry: entry})
+		total += count
+	}
+	if err := store.Write(changes); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// listFindings prints the findings that have not been acknowledged, by day.
+func listFindings() error {
+	entries, err := loadEntries()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, entry := range entries {
+		findings := entry.ActiveFindings()
+		if len(findings) == 0 {
+			continue
+		}
+		found = true
+		fmt.Println(styles.SectionStyle.Render("📅 " + entry.StartTime.Format("Mon, Jan 2 2006")))
+		fmt.Print(renderFindings(findings))
+	}
+
+	if !found {
+		fmt.Println(styles.SuccessStyle.Render("✅ No findings to review"))
+		return nil
+	}
+	fmt.Println()
+	fmt.Println(styles.HelpStyle.Render("Acknowledge them with 'workday ack <date> [code...]' or 'workday ack --all'"))
+	return nil
+}
+
+func acknowledgeFindings(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	if all && len(args) > 0 {
+		return fmt.Errorf("--all cannot be combined with a date")
+	}
+	if !all && len(args) == 0 {
+		return listFindings()
+	}
+
+	var count int
+	err := withStore(func(store journal.Store) error {
+		var err error
+		if all {
+			count, err = acknowledgeAll(store)
+			return err
+		}
+
+		date, err := time.ParseInLocation("2006-01-02", args[0], currentTime().Location())
+		if err != nil {
+			return fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+		}
+		count, err = acknowledgeDay(store, date.Format("20060102"), args[1:])
+		if errors.Is(err, journal.ErrEntryNotFound) {
+			return fmt.Errorf("no entry found for %s", args[0])
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		fmt.Println(styles.InfoStyle.Render("No matching findings to acknowledge"))
+		return nil
+	}
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ Acknowledged %d findings", count)))
+	return nil
+}
+
+func init() {
+	ackCmd.Flags().Bool("all", false, "Acknowledge every finding in the journal")
+	rootCmd.AddCommand(ackCmd)
+}
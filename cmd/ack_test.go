@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+func TestAcknowledgeFindings(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 4, day, hour, 0, 0, 0, time.UTC)
+	}
+	entry := func(day int, codes ...string) journal.JournalEntry {
+		e := journal.JournalEntry{
+			ID:        at(day, 0).Format("20060102"),
+			StartTime: at(day, 9),
+			EndTime:   at(day, 15),
+			Sessions:  []journal.Session{{StartTime: at(day, 9), EndTime: at(day, 15)}},
+		}
+		for _, code := range codes {
+			e.Findings = append(e.Findings, journal.Finding{Code: code, Severity: journal.SeverityWarning, Message: code, CreatedAt: at(day, 15)})
+		}
+		return e
+	}
+	path := writeTempJournal(t, []journal.JournalEntry{
+		entry(6, "min_work_time", "lunch_break"),
+		entry(7, "min_work_time"),
+		entry(8),
+	})
+	store := journal.NewJSONStore(path, journal.BackupPolicy{})
+
+	count, err := acknowledgeDay(store, "20260406", []string{"lunch_break"})
+	if err != nil || count != 1 {
+		t.Fatalf("acknowledgeDay() = %d, %v, want 1", count, err)
+	}
+	saved, err := store.Get("20260406")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active := saved.ActiveFindings(); len(active) != 1 || active[0].Code != "min_work_time" {
+		t.Errorf("expected only min_work_time to remain, got %+v", active)
+	}
+
+	if _, err := acknowledgeDay(store, "20260409", nil); err == nil {
+		t.Error("expected an error for a day without an entry")
+	}
+
+	count, err = acknowledgeAll(store)
+	if err != nil || count != 2 {
+		t.Fatalf("acknowledgeAll() = %d, %v, want 2", count, err)
+	}
+	entries, err := store.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if len(e.ActiveFindings()) != 0 {
+			t.Errorf("expected every finding of %s to be acknowledged, got %+v", e.ID, e.Findings)
+		}
+	}
+}
+
+func TestValidateEntrySettlesFindings(t *testing.T) {
+	path := writeTempJournal(t, []journal.JournalEntry{})
+	setBackfillViper(t, path, "6h", "30m", "10h")
+	store := journal.NewJSONStore(path, journal.BackupPolicy{})
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 4, 7, hour, minute, 0, 0, time.UTC)
+	}
+	day := &journal.JournalEntry{
+		ID:        "20260407",
+		StartTime: at(9, 0),
+		EndTime:   at(16, 0),
+		Sessions:  []journal.Session{{StartTime: at(9, 0), EndTime: at(16, 0)}},
+	}
+
+	if err := validateEntry(store, day, at(16, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if len(day.Findings) != 1 || day.Findings[0].Code != "lunch_break" {
+		t.Fatalf("expected a lunch_break finding, got %+v", day.Findings)
+	}
+
+	// Adding the lunch break afterwards settles the finding
+	day.Breaks = []journal.Break{{StartTime: at(12, 0), EndTime: at(12, 30), Reason: "lunch"}}
+	if err := validateEntry(store, day, at(18, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if len(day.Findings) != 0 {
+		t.Errorf("expected no findings after the lunch break was added, got %+v", day.Findings)
+	}
+}
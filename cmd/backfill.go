@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -8,7 +9,6 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // breakSpec is the parsed, still-string form of a single break: argument.
@@ -127,120 +127,122 @@ var backfillCmd = &cobra.Command{
 start, end, breaks and notes, all from CLI arguments. It refuses to touch a day
 that already has an entry (use 'workday edit' or 'workday break add --date' for that).
 
-The date is YYYYMMDD. Times are HH:MM. Order of arguments after the date is irrelevant.
+The date is YYYYMMDD. Times are HH:MM; add +1 to a time that falls on the next
+day, e.g. for a night shift. Order of arguments after the date is irrelevant.
 
 Examples:
   workday backfill 20240527 start:09:00 end:17:30
   workday backfill 20240527 start:09:00 end:17:30 break:12:00-13:00:lunch
   workday backfill 20240527 start:09:00 end:17:30 \
     break:12:00-13:00:lunch break:15:00-15:15:coffee \
-    note:"Reviewed PRs" note:"Wrapped up release notes"`,
+    note:"Reviewed PRs" note:"Wrapped up release notes"
+  workday backfill 20240527 start:22:00 end:06:00+1 break:02:00+1-02:30+1:meal`,
 	Args: cobra.MinimumNArgs(3),
 	RunE: runBackfill,
 }
 
 // backfillAndSave performs all of the backfill work that does NOT involve the
-// terminal UI: it loads the journal, refuses on a date collision, parses the
-// remaining arguments, builds the entry via journal.NewBackfilledEntry, saves
-// it, then runs policy validation (validateEntry from end.go). On a policy
-// failure it appends a "Validation Error: ..." note and saves again.
+// terminal UI: it opens the configured store, refuses on a date collision,
+// parses the remaining arguments, builds the entry via
+// journal.NewBackfilledEntry and validates it against the schedule and the
+// labor rules (validateEntry from end.go), which records any problems as
+// findings on the entry before it is saved.
 //
-// It returns the constructed entry, the policy validation error (nil if the day
-// passed policy), and a hard error. A non-nil hard error means nothing was
-// written (collision, parse failure, structural validation failure, or I/O
-// failure). A non-nil validation error with a nil hard error means the entry WAS
-// saved with a warning note.
-func backfillAndSave(args []string) (*journal.JournalEntry, error, error) {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load journal: %v", err)
-	}
-
-	// Parse in the local zone so anchored times-of-day match the timezone that
-	// real-time commands store. Plain time.Parse defaults to UTC, which would
-	// shift the entry and its breaks by the local UTC offset.
-	dateAnchor, err := time.ParseInLocation("20060102", args[0], time.Local)
-	if err != nil {
-		return nil, nil, fmt.Errorf("invalid date '%s'. Use YYYYMMDD (e.g., 20240527)", args[0])
-	}
-	entryID := dateAnchor.Format("20060102")
-
-	if _, idx := journal.FetchEntryByID(entryID, entries); idx != -1 {
-		return nil, nil, fmt.Errorf("entry for %s already exists. Use 'workday edit %s' or 'workday break add --date %s' to modify it.", entryID, entryID, dateAnchor.Format("2006-01-02"))
-	}
+// It returns the saved entry and a hard error. A non-nil hard error means
+// nothing was written (collision, parse failure, structural validation failure,
+// or I/O failure). A day that breaks the schedule or a rule is still saved,
+// with its findings. The journal lock is held for the whole cycle.
+func backfillAndSave(args []string) (*journal.JournalEntry, error) {
+	var saved journal.JournalEntry
+	err := withStore(func(store journal.Store) error {
+		// Parse in the local zone so anchored times-of-day match the timezone that
+		// real-time commands store. Plain time.Parse defaults to UTC, which would
+		// shift the entry and its breaks by the local UTC offset.
+		dateAnchor, err := time.ParseInLocation("20060102", args[0], time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid date '%s'. Use YYYYMMDD (e.g., 20240527)", args[0])
+		}
+		entryID := dateAnchor.Format("20060102")
 
-	parsed, err := parseBackfillArgs(args[1:])
-	if err != nil {
-		return nil, nil, err
-	}
+		_, err = store.Get(entryID)
+		if err != nil && !errors.Is(err, journal.ErrEntryNotFound) {
+			return fmt.Errorf("failed to load journal: %v", err)
+		}
+		if err == nil {
+			return fmt.Errorf("entry for %s already exists. Use 'workday edit %s' or 'workday break add --date %s' to modify it.", entryID, entryID, dateAnchor.Format("2006-01-02"))
+		}
 
-	start, err := anchorTime(dateAnchor, parsed.startStr)
-	if err != nil {
-		return nil, nil, fmt.Errorf("invalid start time '%s'. Use HH:MM", parsed.startStr)
-	}
-	end, err := anchorTime(dateAnchor, parsed.endStr)
-	if err != nil {
-		return nil, nil, fmt.Errorf("invalid end time '%s'. Use HH:MM", parsed.endStr)
-	}
+		parsed, err := parseBackfillArgs(args[1:])
+		if err != nil {
+			return err
+		}
 
-	breaks := make([]journal.Break, 0, len(parsed.breakSpecs))
-	for _, bs := range parsed.breakSpecs {
-		bStart, err := anchorTime(dateAnchor, bs.startStr)
+		start, err := anchorTime(dateAnchor, parsed.startStr)
 		if err != nil {
-			return nil, nil, fmt.Errorf("invalid break time '%s'. Use HH:MM", bs.startStr)
+			return fmt.Errorf("invalid start time '%s'. Use HH:MM or HH:MM+1", parsed.startStr)
 		}
-		bEnd, err := anchorTime(dateAnchor, bs.endStr)
+		end, err := anchorTime(dateAnchor, parsed.endStr)
 		if err != nil {
-			return nil, nil, fmt.Errorf("invalid break time '%s'. Use HH:MM", bs.endStr)
+			return fmt.Errorf("invalid end time '%s'. Use HH:MM or HH:MM+1", parsed.endStr)
 		}
-		breaks = append(breaks, journal.Break{StartTime: bStart, EndTime: bEnd, Reason: bs.reason})
-	}
 
-	notes := make([]journal.Note, 0, len(parsed.noteSpecs))
-	for _, text := range parsed.noteSpecs {
-		notes = append(notes, journal.Note{Contents: text})
-	}
+		breaks := make([]journal.Break, 0, len(parsed.breakSpecs))
+		for _, bs := range parsed.breakSpecs {
+			bStart, err := anchorTime(dateAnchor, bs.startStr)
+			if err != nil {
+				return fmt.Errorf("invalid break time '%s'. Use HH:MM or HH:MM+1", bs.startStr)
+			}
+			bEnd, err := anchorTime(dateAnchor, bs.endStr)
+			if err != nil {
+				return fmt.Errorf("invalid break time '%s'. Use HH:MM or HH:MM+1", bs.endStr)
+			}
+			breaks = append(breaks, journal.Break{StartTime: bStart, EndTime: bEnd, Reason: bs.reason})
+		}
 
-	entry, err := journal.NewBackfilledEntry(dateAnchor, start, end, breaks, notes)
-	if err != nil {
-		return nil, nil, err
-	}
+		notes := make([]journal.Note, 0, len(parsed.noteSpecs))
+		for _, text := range parsed.noteSpecs {
+			notes = append(notes, journal.Note{Contents: text})
+		}
 
-	entries = append(entries, *entry)
-	if err := journal.SaveEntries(entries, journalPath); err != nil {
-		return nil, nil, fmt.Errorf("failed to save journal entries: %v", err)
-	}
+		entry, err := journal.NewBackfilledEntry(dateAnchor, start, end, breaks, notes)
+		if err != nil {
+			return err
+		}
 
-	// Policy validation mirrors end.go: on failure, save anyway with a warning note.
-	savedIdx := len(entries) - 1
-	validationErr := validateEntry(&entries[savedIdx])
-	if validationErr != nil {
-		validationNote := journal.Note{Contents: fmt.Sprintf("Validation Error: %s", validationErr)}
-		entries[savedIdx].AddNote(validationNote)
-		if err := journal.SaveEntries(entries, journalPath); err != nil {
-			return nil, nil, fmt.Errorf("failed to save journal entries: %v", err)
+		// Policy validation mirrors end.go: on failure, save anyway with findings.
+		if err := validateEntry(store, entry, currentTime()); err != nil {
+			return err
 		}
-	}
 
-	return &entries[savedIdx], validationErr, nil
+		if err := store.Upsert(*entry); err != nil {
+			return fmt.Errorf("failed to save journal entries: %v", err)
+		}
+
+		saved = *entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
 }
 
 // anchorTime parses an HH:MM string and stamps it onto the given date, in the
-// date's location. Mirrors the time-anchoring pattern in cmd/break.go:addBreak.
+// date's location. A +N suffix (e.g. 06:00+1) moves the time N days past the
+// date, for shifts that end after midnight. Mirrors the time-anchoring pattern
+// in cmd/break.go:addBreak.
 func anchorTime(date time.Time, hhmm string) (time.Time, error) {
-	parsed, err := time.Parse("15:04", hhmm)
+	parsed, err := journal.ParseClockTime(hhmm)
 	if err != nil {
 		return time.Time{}, err
 	}
-	return time.Date(date.Year(), date.Month(), date.Day(),
-		parsed.Hour(), parsed.Minute(), 0, 0, date.Location()), nil
+	return parsed.On(date), nil
 }
 
 // runBackfill is the cobra RunE handler. It performs the persistence work via
 // backfillAndSave, then launches the endModel confirmation TUI.
 func runBackfill(cmd *cobra.Command, args []string) error {
-	entry, validationErr, err := backfillAndSave(args)
+	entry, err := backfillAndSave(args)
 	if err != nil {
 		return err
 	}
@@ -249,7 +251,6 @@ func runBackfill(cmd *cobra.Command, args []string) error {
 		entry:         entry,
 		date:          entry.StartTime,
 		totalWorkTime: entry.TotalWorkTime(),
-		validationErr: validationErr,
 	}
 
 	p := tea.NewProgram(&model)
@@ -178,12 +178,12 @@ func TestBackfillAndSaveNewDay(t *testing.T) {
 	setBackfillViper(t, path, "8h", "1h", "10h")
 
 	args := []string{"20240527", "start:09:00", "end:18:30", "break:12:00-13:00:lunch", "note:Reviewed PRs #done"}
-	entry, validationErr, err := backfillAndSave(args)
+	entry, err := backfillAndSave(args)
 	if err != nil {
 		t.Fatalf("backfillAndSave returned error: %v", err)
 	}
-	if validationErr != nil {
-		t.Fatalf("expected no policy validation error, got: %v", validationErr)
+	if findings := entry.ActiveFindings(); len(findings) != 0 {
+		t.Fatalf("expected no policy findings, got: %v", findings)
 	}
 	if entry == nil {
 		t.Fatal("expected non-nil entry")
@@ -241,7 +241,7 @@ func TestBackfillAndSaveExistingDayRefused(t *testing.T) {
 	}
 
 	args := []string{"20240527", "start:08:00", "end:18:00"}
-	_, _, err = backfillAndSave(args)
+	_, err = backfillAndSave(args)
 	if err == nil {
 		t.Fatal("expected refusal error for existing day, got nil")
 	}
@@ -261,17 +261,14 @@ func TestBackfillAndSaveExistingDayRefused(t *testing.T) {
 func TestBackfillAndSavePolicyViolation(t *testing.T) {
 	path := writeTempJournal(t, []journal.JournalEntry{})
 	// minWorkTime 8h, but the backfilled day is only ~2h with no lunch break:
-	// policy validation must fail and a warning note must be appended.
+	// policy validation must fail and findings must be recorded.
 	setBackfillViper(t, path, "8h", "1h", "10h")
 
 	args := []string{"20240527", "start:09:00", "end:11:00"}
-	entry, validationErr, err := backfillAndSave(args)
+	entry, err := backfillAndSave(args)
 	if err != nil {
 		t.Fatalf("backfillAndSave returned hard error: %v", err)
 	}
-	if validationErr == nil {
-		t.Fatal("expected a policy validation error, got nil")
-	}
 	if entry == nil {
 		t.Fatal("expected entry to be saved despite policy violation")
 	}
@@ -283,14 +280,15 @@ func TestBackfillAndSavePolicyViolation(t *testing.T) {
 	if len(entries) != 1 {
 		t.Fatalf("expected 1 entry persisted, got %d", len(entries))
 	}
-	foundWarning := false
-	for _, n := range entries[0].Notes {
-		if strings.HasPrefix(n.Contents, "Validation Error:") {
-			foundWarning = true
-		}
+	codes := map[string]bool{}
+	for _, finding := range entries[0].Findings {
+		codes[finding.Code] = true
 	}
-	if !foundWarning {
-		t.Errorf("expected a 'Validation Error:' note appended, notes = %+v", entries[0].Notes)
+	if !codes["min_work_time"] || !codes["lunch_break"] {
+		t.Errorf("expected min_work_time and lunch_break findings, got %+v", entries[0].Findings)
+	}
+	if len(entries[0].Notes) != 0 {
+		t.Errorf("expected no notes to be added, notes = %+v", entries[0].Notes)
 	}
 }
 
@@ -308,7 +306,7 @@ func TestBackfillAndSaveAnchorsLocalZone(t *testing.T) {
 	setBackfillViper(t, path, "8h", "1h", "10h")
 
 	args := []string{"20240527", "start:09:00", "end:18:30", "break:12:00-13:00:lunch"}
-	if _, _, err := backfillAndSave(args); err != nil {
+	if _, err := backfillAndSave(args); err != nil {
 		t.Fatalf("backfillAndSave returned error: %v", err)
 	}
 
@@ -337,3 +335,29 @@ func TestBackfillAndSaveAnchorsLocalZone(t *testing.T) {
 		t.Errorf("break times = %v-%v, want 12:00-13:00 local", got.Breaks[0].StartTime, got.Breaks[0].EndTime)
 	}
 }
+
+func TestBackfillAndSaveOvernightShift(t *testing.T) {
+	path := writeTempJournal(t, []journal.JournalEntry{})
+	setBackfillViper(t, path, "7h", "30m", "10h")
+
+	args := []string{"20240527", "start:22:00", "end:06:00+1", "break:02:00+1-02:30+1:meal"}
+	entry, err := backfillAndSave(args)
+	if err != nil {
+		t.Fatalf("backfillAndSave returned error: %v", err)
+	}
+	if findings := entry.ActiveFindings(); len(findings) != 0 {
+		t.Fatalf("expected no policy findings, got: %v", findings)
+	}
+	if entry.ID != "20240527" {
+		t.Errorf("entry ID = %q, want %q", entry.ID, "20240527")
+	}
+	if entry.EndTime.Format("2006-01-02 15:04") != "2024-05-28 06:00" {
+		t.Errorf("EndTime = %v, want 2024-05-28 06:00", entry.EndTime)
+	}
+	if entry.Breaks[0].StartTime.Format("2006-01-02 15:04") != "2024-05-28 02:00" {
+		t.Errorf("break start = %v, want 2024-05-28 02:00", entry.Breaks[0].StartTime)
+	}
+	if got := entry.TotalWorkTime(); got != 7*time.Hour+30*time.Minute {
+		t.Errorf("TotalWorkTime() = %v, want 7h30m", got)
+	}
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Manage the automatic backups of the journal",
+	Long: `Every time a command saves the journal, the previous version is kept as a
+timestamped backup. By default the last 10 backups are kept next to the journal.
+
+Use the backup.count and backup.dir config keys to change how many backups are
+kept and where; a backup.count of 0 disables backups. Backups are only kept
+for the json storage backend.
+
+Examples:
+  workday backup list
+  workday backup restore 20260417-153012.123`,
+}
+
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available journal backups, newest first",
+	Args:  cobra.NoArgs,
+	RunE:  listBackups,
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore <timestamp>",
+	Short: "Replace the journal with one of its backups",
+	Long: `The restore command replaces the journal with the backup identified by the
+timestamp shown in 'workday backup list'. Any unique prefix of the timestamp is
+accepted.
+
+The journal being replaced is backed up first, so a restore can be undone.`,
+	Args: cobra.ExactArgs(1),
+	RunE: restoreBackup,
+}
+
+// backupPolicy builds the backup policy from the backup.count and backup.dir
+// config values.
+func backupPolicy() journal.BackupPolicy {
+	return journal.BackupPolicy{
+		Dir:   viper.GetString("backup.dir"),
+		Count: viper.GetInt("backup.count"),
+	}
+}
+
+// saveJournal saves entries to journalPath, keeping a backup of the journal it
+// replaces according to the configured backup policy. Commands that write the
+// JSON journal file directly, rather than through a journal.Store, use it
+// instead of journal.SaveEntries, inside withJournalLock.
+func saveJournal(entries []journal.JournalEntry, journalPath string) error {
+	return journal.SaveEntriesWithBackup(entries, journalPath, backupPolicy())
+}
+
+type backupListModel struct {
+	journalPath string
+	backups     []journal.Backup
+	width       int
+	height      int
+	quitting    bool
+}
+
+func (m backupListModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m backupListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m backupListModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	return m.render(renderOptions{Interactive: true})
+}
+
+// render renders the view, see renderOptions.
+func (m backupListModel) render(opts renderOptions) string {
+	var content strings.Builder
+
+	// Title
+	content.WriteString(styles.TitleStyle.Render("🗄️  Journal Backups"))
+	content.WriteString("\n\n")
+
+	content.WriteString(styles.LabelStyle.Render("Journal:") + " " + styles.ValueStyle.Render(m.journalPath))
+	content.WriteString("\n\n")
+
+	if len(m.backups) == 0 {
+		content.WriteString(styles.InfoStyle.Render("No backups found"))
+		content.WriteString("\n")
+	} else {
+		var rows [][]string
+		for _, backup := range m.backups {
+			rows = append(rows, []string{
+				backup.ID(),
+				backup.Timestamp.Format("Mon, Jan 2 2006 15:04:05"),
+				formatSize(backup.Size),
+			})
+		}
+		content.WriteString(renderTable([]string{"Timestamp", "Created", "Size"}, rows))
+
+		content.WriteString(styles.InfoStyle.Render("💡 Restore one with: workday backup restore <timestamp>"))
+		content.WriteString("\n")
+	}
+
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
+
+	return content.String()
+}
+
+// formatSize renders a byte count with a binary unit, e.g. "1.5 KiB".
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func listBackups(cmd *cobra.Command, args []string) error {
+	journalPath := viper.GetString("journalPath")
+	backups, err := journal.ListBackups(journalPath, backupPolicy())
+	if err != nil {
+		return err
+	}
+
+	data := backupListJSON{Journal: journalPath, Backups: []backupJSON{}}
+	for _, backup := range backups {
+		data.Backups = append(data.Backups, backupJSON{
+			ID:        backup.ID(),
+			Created:   formatJSONTime(backup.Timestamp),
+			SizeBytes: backup.Size,
+		})
+	}
+
+	model := backupListModel{
+		journalPath: journalPath,
+		backups:     backups,
+	}
+	return showReport(&model, data)
+}
+
+// restoreJournalBackup restores the backup identified by timestamp over the
+// journal at journalPath while holding the journal lock.
+func restoreJournalBackup(journalPath string, timestamp string, now time.Time) (journal.Backup, error) {
+	var restored journal.Backup
+	err := withJournalLock(journalPath, func() error {
+		var err error
+		restored, err = journal.RestoreBackup(journalPath, backupPolicy(), timestamp, now)
+		if err != nil {
+			return err
+		}
+		// The restored notes are not in the search index
+		return journal.RemoveSearchIndex(journalPath)
+	})
+	return restored, err
+}
+
+func restoreBackup(cmd *cobra.Command, args []string) error {
+	journalPath := viper.GetString("journalPath")
+	restored, err := restoreJournalBackup(journalPath, args[0], time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ Restored %s from backup %s", journalPath, restored.ID())))
+	return nil
+}
+
+func init() {
+	backupCmd.AddCommand(backupListCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+	rootCmd.AddCommand(backupCmd)
+}
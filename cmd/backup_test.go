@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/viper"
+)
+
+func setBackupViper(t *testing.T, count int, dir string) {
+	t.Helper()
+	original := viper.AllSettings()
+	t.Cleanup(func() {
+		viper.Reset()
+		for k, v := range original {
+			viper.Set(k, v)
+		}
+	})
+	viper.Set("backup.count", count)
+	viper.Set("backup.dir", dir)
+}
+
+func TestSaveJournalRotatesBackups(t *testing.T) {
+	dir := t.TempDir()
+	journalPath := filepath.Join(dir, "journal.json")
+	backupDir := filepath.Join(dir, "backups")
+	setBackupViper(t, 2, backupDir)
+
+	// Four saves: the first has nothing to back up, the other three each
+	// back up the previous journal and only the newest two are kept.
+	for i := 0; i < 4; i++ {
+		entries := []journal.JournalEntry{{ID: "20260417", Notes: []journal.Note{{Contents: string(rune('a' + i))}}}}
+		if err := saveJournal(entries, journalPath); err != nil {
+			t.Fatalf("saveJournal() error = %v", err)
+		}
+		// Backups are named to the millisecond
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	backups, err := journal.ListBackups(journalPath, backupPolicy())
+	if err != nil {
+		t.Fatalf("ListBackups() error = %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %d", len(backups))
+	}
+
+	restored, err := restoreJournalBackup(journalPath, backups[1].ID(), time.Now())
+	if err != nil {
+		t.Fatalf("restoreJournalBackup() error = %v", err)
+	}
+	if restored.ID() != backups[1].ID() {
+		t.Errorf("restored %s, want %s", restored.ID(), backups[1].ID())
+	}
+
+	entries, err := journal.LoadEntries(journalPath)
+	if err != nil {
+		t.Fatalf("LoadEntries() error = %v", err)
+	}
+	if got := entries[0].Notes[0].Contents; got != "b" {
+		t.Errorf("restored journal has note %q, want %q", got, "b")
+	}
+}
+
+func TestListBackupsOutput(t *testing.T) {
+	dir := t.TempDir()
+	journalPath := filepath.Join(dir, "journal.json")
+	setBackupViper(t, 5, "")
+	viper.Set("journalPath", journalPath)
+	for i := 0; i < 2; i++ {
+		if err := saveJournal([]journal.JournalEntry{{ID: "20260417"}}, journalPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("text", func(t *testing.T) {
+		setOutputFormat(t, outputText)
+		out := captureStdout(t, func() error { return listBackups(nil, nil) })
+		if !strings.Contains(out, "Journal Backups") || strings.Contains(out, "Press 'q'") {
+			t.Errorf("unexpected text output %q", out)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		setOutputFormat(t, outputJSON)
+		out := captureStdout(t, func() error { return listBackups(nil, nil) })
+		var got backupListJSON
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatalf("invalid JSON %q: %v", out, err)
+		}
+		if got.Journal != journalPath || len(got.Backups) != 1 || got.Backups[0].SizeBytes == 0 {
+			t.Errorf("unexpected backups %+v", got)
+		}
+	})
+}
+
+func TestMultiDayCommandKeepsPreCommandBackup(t *testing.T) {
+	dir := t.TempDir()
+	journalPath := filepath.Join(dir, "journal.json")
+	setStorageViper(t, journalPath, "json", "")
+	setBackupViper(t, 2, filepath.Join(dir, "backups"))
+
+	// More days than backups are kept, so a backup per day would push the
+	// journal from before the command out
+	var entries []journal.JournalEntry
+	for day := 1; day <= 5; day++ {
+		entries = append(entries, journal.JournalEntry{
+			ID:    fmt.Sprintf("202604%02d", day),
+			Notes: []journal.Note{{Contents: "Planning", Tags: []string{"team"}}},
+		})
+	}
+	if err := journal.SaveEntries(entries, journalPath); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := openStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if _, err := renameTag(store, "team", "crew", false); err != nil {
+		t.Fatalf("renameTag() error = %v", err)
+	}
+
+	backups, err := journal.ListBackups(journalPath, backupPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("expected a single backup for the command, got %d", len(backups))
+	}
+	saved, err := journal.LoadEntries(backups[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(entries, saved); diff != "" {
+		t.Errorf("backup is not the journal from before the command (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{size: 0, want: "0 B"},
+		{size: 1023, want: "1023 B"},
+		{size: 1536, want: "1.5 KiB"},
+		{size: 5 * 1024 * 1024, want: "5.0 MiB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatSize(tt.size); got != tt.want {
+			t.Errorf("formatSize(%d) = %q, want %q", tt.size, got, tt.want)
+		}
+	}
+}
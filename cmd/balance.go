@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// balanceCmd represents the balance command
+var balanceCmd = &cobra.Command{
+	Use:   "balance",
+	Short: "Shows the running overtime balance",
+	Long: `The balance command keeps a flex time account: every day adds the time
+worked and subtracts the target of the schedule, and manual adjustments are
+added on top. It shows the balance of every week and the running total.
+
+The account starts on the first day of the journal, or on balance.start from
+the config. Today only counts once it has ended.
+
+Examples:
+  workday balance
+  workday balance --from 2026-01-01`,
+	RunE: showBalance,
+}
+
+var balanceAdjustCmd = &cobra.Command{
+	Use:   "adjust <amount> <reason>",
+	Short: "Records a manual change to the overtime balance",
+	Long: `The adjust command adds a duration to the overtime balance, e.g. hours
+carried over from before the journal. Negative amounts take time off the
+balance, such as overtime that was paid out; put them after "--" so they are
+not read as flags.
+
+Examples:
+  workday balance adjust 12h "Carried over from 2025"
+  workday balance adjust --date 2026-03-31 -- -5h "Paid out overtime"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: adjustBalance,
+}
+
+// balanceWeek is one week of the overtime balance.
+type balanceWeek struct {
+	First, Last time.Time     // days of the week within the balance period
+	Worked      time.Duration // time worked, lunch deducted as in the reports
+	Target      time.Duration // target of the schedule
+	Adjusted    time.Duration // sum of the manual adjustments
+	Running     time.Duration // balance at the end of the week
+}
+
+// Balance returns the change to the balance during the week.
+func (w balanceWeek) Balance() time.Duration {
+	return w.Worked - w.Target + w.Adjusted
+}
+
+// computeBalance splits the days from first to last, inclusive, into weeks
+// starting on Monday and returns the balance of each of them.
+func computeBalance(entries []journal.JournalEntry, first, last time.Time, schedule workSchedule) []balanceWeek {
+	var weeks []balanceWeek
+	var running time.Duration
+	for from := first; !from.After(last); {
+		_, to := journal.WeekBounds(from)
+		if to.After(last) {
+			to = last
+		}
+
+		week := balanceWeek{
+			First:  from,
+			Last:   to,
+			Worked: periodWorkTime(entries, from, to, schedule),
+			Target: schedule.Target(from, to, entries),
+		}
+		// An error only means the week has no entries
+		weekEntries, _ := journal.FetchEntriesByRange(entries, from, to)
+		week.Adjusted = adjustmentTotal(weekEntries)
+		running += week.Balance()
+		week.Running = running
+
+		weeks = append(weeks, week)
+		from = to.AddDate(0, 0, 1)
+	}
+	return weeks
+}
+
+// balancePeriod returns the days the balance covers. It starts on fromStr,
+// else on balance.start from the config, else on the day of the first entry,
+// and ends today once today's entry has ended, yesterday otherwise. ok is
+// false when there is nothing to count.
+func balancePeriod(entries []journal.JournalEntry, fromStr string, now time.Time) (first, last time.Time, ok bool, err error) {
+	if fromStr == "" {
+		fromStr = viper.GetString("balance.start")
+	}
+
+	if fromStr != "" {
+		first, err = time.ParseInLocation("2006-01-02", fromStr, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, false, fmt.Errorf("invalid balance start '%s'. Use YYYY-MM-DD", fromStr)
+		}
+	} else {
+		for _, entry := range entries {
+			if first.IsZero() || entry.StartTime.Before(first) {
+				first = entry.StartTime
+			}
+		}
+		if first.IsZero() {
+			return time.Time{}, time.Time{}, false, nil
+		}
+		first = first.In(now.Location())
+		first = time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, now.Location())
+	}
+
+	last = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	today, _ := journal.FetchEntryForTime(now, entries)
+	if today == nil || today.EndTime.IsZero() {
+		last = last.AddDate(0, 0, -1)
+	}
+	return first, last, !last.Before(first), nil
+}
+
+// adjustmentTotal returns the sum of the balance adjustments of entries.
+func adjustmentTotal(entries []journal.JournalEntry) time.Duration {
+	var total time.Duration
+	for _, entry := range entries {
+		total += entry.AdjustmentTotal()
+	}
+	return total
+}
+
+// formatSignedDuration renders a duration that may be negative, e.g. "-5h 0m"
+// or "+1h 30m".
+func formatSignedDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + formatDuration(-d)
+	}
+	return "+" + formatDuration(d)
+}
+
+type balanceModel struct {
+	first, last time.Time
+	weeks       []balanceWeek
+	adjustments []journal.JournalEntry // days with adjustments in the period
+	quitting    bool
+}
+
+func (m balanceModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m balanceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m balanceModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	return m.render(renderOptions{Interactive: true})
+}
+
+// render renders the view, see renderOptions.
+func (m balanceModel) render(opts renderOptions) string {
+	var content strings.Builder
+
+	// Title
+	content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("⚖️  Overtime Balance - since %s", m.first.Format("Jan 2, 2006"))))
+	content.WriteString("\n\n")
+
+	// By Week
+	content.WriteString(styles.SectionStyle.Render("📅 By Week"))
+	content.WriteString("\n")
+	var rows [][]string
+	for _, week := range m.weeks {
+		adjusted := "--"
+		if week.Adjusted != 0 {
+			adjusted = formatSignedDuration(week.Adjusted)
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%s - %s", week.First.Format("Jan 2"), week.Last.Format("Jan 2, 2006")),
+			formatDuration(week.Worked),
+			formatDuration(week.Target),
+			adjusted,
+			formatBalance(week.Balance()),
+			formatBalance(week.Running),
+		})
+	}
+	content.WriteString(renderTable([]string{"Week", "Worked", "Target", "Adjusted", "Balance", "Running"}, rows))
+
+	// Adjustments
+	if len(m.adjustments) > 0 {
+		content.WriteString(styles.SectionStyle.Render("✏️  Adjustments"))
+		content.WriteString("\n")
+		rows = nil
+		for _, entry := range m.adjustments {
+			for _, adjustment := range entry.Adjustments {
+				rows = append(rows, []string{entry.StartTime.Format("Mon, Jan 2 2006"), formatSignedDuration(adjustment.Amount), adjustment.Reason})
+			}
+		}
+		content.WriteString(renderTable([]string{"Date", "Amount", "Reason"}, rows))
+	}
+
+	// Summary Section
+	var running time.Duration
+	if len(m.weeks) > 0 {
+		running = m.weeks[len(m.weeks)-1].Running
+	}
+	summary := fmt.Sprintf("📊 Balance on %s: %s", m.last.Format("Jan 2, 2006"), formatBalance(running))
+	if running < 0 {
+		content.WriteString(styles.ErrorStyle.Render(summary))
+	} else {
+		content.WriteString(styles.SuccessStyle.Render(summary))
+	}
+	content.WriteString("\n")
+
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
+
+	return content.String()
+}
+
+func showBalance(cmd *cobra.Command, args []string) error {
+	fromStr, _ := cmd.Flags().GetString("from")
+
+	entries, err := loadEntries()
+	if err != nil {
+		return err
+	}
+	schedule, err := loadSchedule()
+	if err != nil {
+		return err
+	}
+
+	first, last, ok, err := balancePeriod(entries, fromStr, currentTime())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println(styles.InfoStyle.Render("No finished days to balance yet"))
+		return nil
+	}
+
+	model := balanceModel{
+		first: first,
+		last:  last,
+		weeks: computeBalance(entries, first, last, schedule),
+	}
+	periodEntries, _ := journal.FetchEntriesByRange(entries, first, last)
+	for _, entry := range periodEntries {
+		if len(entry.Adjustments) > 0 {
+			model.adjustments = append(model.adjustments, entry)
+		}
+	}
+
+	data := balanceJSON{
+		From:  first.Format("2006-01-02"),
+		To:    last.Format("2006-01-02"),
+		Weeks: []balanceWeekJSON{},
+	}
+	for _, week := range model.weeks {
+		data.Weeks = append(data.Weeks, balanceWeekJSON{
+			From:            week.First.Format("2006-01-02"),
+			To:              week.Last.Format("2006-01-02"),
+			WorkedMinutes:   minutes(week.Worked),
+			TargetMinutes:   minutes(week.Target),
+			AdjustedMinutes: minutes(week.Adjusted),
+			BalanceMinutes:  minutes(week.Balance()),
+			RunningMinutes:  minutes(week.Running),
+		})
+		data.BalanceMinutes = minutes(week.Running)
+	}
+	return showReport(&model, data)
+}
+
+func adjustBalance(cmd *cobra.Command, args []string) error {
+	amount, err := time.ParseDuration(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid amount '%s'. Use a duration such as 5h, 1h30m or -45m", args[0])
+	}
+	reason := strings.Join(args[1:], " ")
+
+	date := currentTime()
+	if dateStr, _ := cmd.Flags().GetString("date"); dateStr != "" {
+		date, err = time.ParseInLocation("2006-01-02", dateStr, date.Location())
+		if err != nil {
+			return fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+		}
+	}
+
+	err = withStore(func(store journal.Store) error {
+		entry, err := store.Get(journal.DayID(date, date.Location()))
+		if errors.Is(err, journal.ErrEntryNotFound) {
+			entry, err = journal.NewEmptyEntry(date), nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := entry.AddAdjustment(amount, reason); err != nil {
+			return err
+		}
+		return store.Upsert(*entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ Adjusted the balance by %s on %s: %s",
+		formatSignedDuration(amount), date.Format("Mon, Jan 2 2006"), strings.TrimSpace(reason))))
+	return nil
+}
+
+func init() {
+	balanceCmd.Flags().String("from", "", "Start the balance on this day, in YYYY-MM-DD format")
+	balanceAdjustCmd.Flags().String("date", "", "Day to record the adjustment on, in YYYY-MM-DD format (default today)")
+	balanceCmd.AddCommand(balanceAdjustCmd)
+	rootCmd.AddCommand(balanceCmd)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+func TestComputeBalance(t *testing.T) {
+	var schedule workSchedule
+	for day := time.Monday; day <= time.Friday; day++ {
+		schedule[day].MinWorkTime = 8 * time.Hour
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 4, day, hour, 0, 0, 0, time.UTC)
+	}
+	worked := func(day, from, to int) journal.JournalEntry {
+		return journal.JournalEntry{
+			ID:        at(day, 0).Format("20060102"),
+			StartTime: at(day, from),
+			EndTime:   at(day, to),
+			Sessions:  []journal.Session{{StartTime: at(day, from), EndTime: at(day, to)}},
+		}
+	}
+
+	holiday, err := journal.NewDayOffEntry(at(3, 0), journal.DayHoliday, "Good Friday")
+	if err != nil {
+		t.Fatal(err)
+	}
+	paidOut := journal.NewEmptyEntry(at(7, 0))
+	if err := paidOut.AddAdjustment(-2*time.Hour, "Paid out"); err != nil {
+		t.Fatal(err)
+	}
+	entries := []journal.JournalEntry{worked(2, 8, 17), *holiday, worked(6, 9, 16), *paidOut}
+
+	// Thursday to the Tuesday after, across a week boundary
+	weeks := computeBalance(entries, at(2, 0), at(7, 0), schedule)
+	if len(weeks) != 2 {
+		t.Fatalf("got %d weeks, want 2", len(weeks))
+	}
+
+	tests := []struct {
+		week                                   balanceWeek
+		first, last                            string
+		worked, target, adjusted, balance, sum time.Duration
+	}{
+		{weeks[0], "20260402", "20260405", 9 * time.Hour, 8 * time.Hour, 0, time.Hour, time.Hour},
+		{weeks[1], "20260406", "20260407", 7 * time.Hour, 16 * time.Hour, -2 * time.Hour, -11 * time.Hour, -10 * time.Hour},
+	}
+	for i, tt := range tests {
+		w := tt.week
+		if w.First.Format("20060102") != tt.first || w.Last.Format("20060102") != tt.last {
+			t.Errorf("week %d spans %s-%s, want %s-%s", i, w.First.Format("20060102"), w.Last.Format("20060102"), tt.first, tt.last)
+		}
+		if w.Worked != tt.worked || w.Target != tt.target || w.Adjusted != tt.adjusted {
+			t.Errorf("week %d: worked %v, target %v, adjusted %v; want %v, %v, %v", i, w.Worked, w.Target, w.Adjusted, tt.worked, tt.target, tt.adjusted)
+		}
+		if w.Balance() != tt.balance || w.Running != tt.sum {
+			t.Errorf("week %d: balance %v, running %v; want %v, %v", i, w.Balance(), w.Running, tt.balance, tt.sum)
+		}
+	}
+}
+
+func TestBalancePeriod(t *testing.T) {
+	now := time.Date(2026, 4, 8, 18, 0, 0, 0, time.UTC)
+	first := journal.JournalEntry{ID: "20260401", StartTime: time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 4, 1, 17, 0, 0, 0, time.UTC)}
+	today := journal.JournalEntry{ID: "20260408", StartTime: time.Date(2026, 4, 8, 9, 0, 0, 0, time.UTC)}
+	ended := today
+	ended.EndTime = now
+
+	tests := []struct {
+		name      string
+		entries   []journal.JournalEntry
+		from      string
+		wantFirst string
+		wantLast  string
+		wantOK    bool
+		wantErr   bool
+	}{
+		{name: "today is still ongoing", entries: []journal.JournalEntry{first, today}, wantFirst: "20260401", wantLast: "20260407", wantOK: true},
+		{name: "today has ended", entries: []journal.JournalEntry{first, ended}, wantFirst: "20260401", wantLast: "20260408", wantOK: true},
+		{name: "explicit start", entries: []journal.JournalEntry{first}, from: "2026-03-01", wantFirst: "20260301", wantLast: "20260407", wantOK: true},
+		{name: "only today, still ongoing", entries: []journal.JournalEntry{today}, wantOK: false},
+		{name: "empty journal", wantOK: false},
+		{name: "invalid start", from: "03/01/2026", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotFirst, gotLast, ok, err := balancePeriod(tt.entries, tt.from, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("balancePeriod() error = %v", err)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if gotFirst.Format("20060102") != tt.wantFirst || gotLast.Format("20060102") != tt.wantLast {
+				t.Errorf("period = %s-%s, want %s-%s", gotFirst.Format("20060102"), gotLast.Format("20060102"), tt.wantFirst, tt.wantLast)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -10,7 +11,6 @@ import (
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var breakReason string
@@ -30,32 +30,29 @@ var breakStartCmd = &cobra.Command{
 }
 
 func startBreak(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
-	if err != nil {
-		return err
-	}
-
-	now := time.Now()
-	currentDayId := now.Format("20060102")
-	entry, idx := journal.FetchEntryByID(currentDayId, entries)
-	if idx == -1 {
-		return journal.EntryNotFoundError(currentDayId)
-	}
+	now := currentTime()
 
 	if len(args) > 0 {
 		breakReason = args[0]
 	}
 
-	newBreak := journal.Break{
-		StartTime: now,
-		Reason:    breakReason,
-	}
+	var entry journal.JournalEntry
+	err := withStore(func(store journal.Store) error {
+		current, err := journal.CurrentEntry(store, now)
+		if err != nil {
+			return err
+		}
+
+		newBreak := journal.Break{
+			StartTime: now,
+			Reason:    breakReason,
+		}
 
-	entry.Breaks = append(entry.Breaks, newBreak)
-	entries[idx] = *entry // Update the entry in the slice
+		current.Breaks = append(current.Breaks, newBreak)
+		entry = *current
 
-	err = journal.SaveEntries(entries, journalPath)
+		return store.Upsert(*current)
+	})
 	if err != nil {
 		return err
 	}
@@ -201,32 +198,34 @@ func (m breakModel) View() string {
 }
 
 func stopBreak(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
-	if err != nil {
-		return err
-	}
-
-	now := time.Now()
-	currentDayId := now.Format("20060102")
-	entry, idx := journal.FetchEntryByID(currentDayId, entries)
-	if idx == -1 {
-		return fmt.Errorf("No entry found for the current day.")
-	}
+	now := currentTime()
+
+	var entry journal.JournalEntry
+	var lastBreak journal.Break
+	err := withStore(func(store journal.Store) error {
+		current, err := journal.CurrentEntry(store, now)
+		if errors.Is(err, journal.ErrEntryNotFound) {
+			return fmt.Errorf("No entry found for the current day.")
+		}
+		if err != nil {
+			return err
+		}
 
-	if len(entry.Breaks) == 0 {
-		return fmt.Errorf("No break started for the current day.")
-	}
+		if len(current.Breaks) == 0 {
+			return fmt.Errorf("No break started for the current day.")
+		}
 
-	lastBreak := &entry.Breaks[len(entry.Breaks)-1] // Get the last break
+		last := &current.Breaks[len(current.Breaks)-1] // Get the last break
 
-	if !lastBreak.EndTime.IsZero() {
-		return fmt.Errorf("Last break was already stopped.")
-	}
-	lastBreak.EndTime = now
-	entries[idx] = *entry
+		if !last.EndTime.IsZero() {
+			return fmt.Errorf("Last break was already stopped.")
+		}
+		last.EndTime = now
+		entry = *current
+		lastBreak = *last
 
-	err = journal.SaveEntries(entries, journalPath)
+		return store.Upsert(*current)
+	})
 	if err != nil {
 		return err
 	}
@@ -266,11 +265,11 @@ var breakListCmd = &cobra.Command{
 }
 
 func listBreaks(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
+	store, err := openStore()
 	if err != nil {
 		return err
 	}
+	defer store.Close()
 
 	// Determine target date
 	var targetDate time.Time
@@ -283,18 +282,26 @@ func listBreaks(cmd *cobra.Command, args []string) error {
 		}
 		entryId = targetDate.Format("20060102")
 	} else {
-		targetDate = time.Now()
+		targetDate = currentTime()
 		entryId = targetDate.Format("20060102")
 	}
 
-	entry, idx := journal.FetchEntryByID(entryId, entries)
-	if idx == -1 {
-		return journal.EntryNotFoundError(entryId)
+	entry, err := store.Get(entryId)
+	if err != nil {
+		return err
+	}
+
+	data := breakListJSON{Date: targetDate.Format("2006-01-02"), Breaks: []breakJSON{}}
+	for _, br := range entry.Breaks {
+		data.Breaks = append(data.Breaks, newBreakJSON(br))
+		data.TotalMinutes += minutes(br.Duration())
 	}
 
 	if len(entry.Breaks) == 0 {
-		fmt.Printf("No breaks found for %s\n", targetDate.Format("2006-01-02"))
-		return nil
+		if format, _ := resolveOutput(); format == outputInteractive || format == outputText {
+			fmt.Printf("No breaks found for %s\n", targetDate.Format("2006-01-02"))
+			return nil
+		}
 	}
 
 	// Create TUI model for break list
@@ -304,9 +311,7 @@ func listBreaks(cmd *cobra.Command, args []string) error {
 		breaks:     entry.Breaks,
 	}
 
-	p := tea.NewProgram(&model)
-	_, err = p.Run()
-	return err
+	return showReport(&model, data)
 }
 
 // Break list TUI model
@@ -342,7 +347,11 @@ func (m breakListModel) View() string {
 	if m.quitting {
 		return ""
 	}
+	return m.render(renderOptions{Interactive: true})
+}
 
+// render renders the view, see renderOptions.
+func (m breakListModel) render(opts renderOptions) string {
 	var content strings.Builder
 
 	// Title
@@ -367,12 +376,12 @@ func (m breakListModel) View() string {
 		var totalDuration time.Duration
 		for i, br := range m.breaks {
 			id := fmt.Sprintf("%d", i+1)
-			startTime := br.StartTime.Format("15:04")
+			startTime := journal.FormatClockTime(br.StartTime, m.entry.StartTime)
 			endTime := "ongoing"
 			duration := "N/A"
 
 			if !br.EndTime.IsZero() {
-				endTime = br.EndTime.Format("15:04")
+				endTime = journal.FormatClockTime(br.EndTime, m.entry.StartTime)
 				dur := br.Duration()
 				totalDuration += dur
 				if dur.Hours() >= 1 {
@@ -413,7 +422,9 @@ func (m breakListModel) View() string {
 	content.WriteString("\n")
 	content.WriteString(styles.InfoStyle.Render("💡 Use 'workday break modify <id> ...' to edit breaks"))
 	content.WriteString("\n")
-	content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
 
 	return content.String()
 }
@@ -446,289 +457,296 @@ var breakAddCmd = &cobra.Command{
 	Short: "Add a completed break with explicit start and end times",
 	Long: `Add a completed break entry with explicit start and end times.
 Useful for retroactively logging breaks you forgot to track in real-time.
+Add +1 to a time after midnight of a night shift.
 
 Examples:
   workday break add start:12:00 end:13:00 reason:lunch
   workday break add start:15:00 end:15:15 reason:break
   workday break add start:09:30 end:10:00 reason:drive
-  workday break add --date 2024-05-27 start:12:00 end:13:00 reason:lunch`,
+  workday break add --date 2024-05-27 start:12:00 end:13:00 reason:lunch
+  workday break add --date 2024-05-27 start:02:00+1 end:02:30+1 reason:meal`,
 	Args: cobra.MinimumNArgs(2),
 	RunE: addBreak,
 }
 
 func modifyBreak(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
-	if err != nil {
-		return err
-	}
+	return withStore(func(store journal.Store) error {
+		// Parse break ID
+		breakID := args[0]
+		breakIndex, err := parseBreakID(breakID)
+		if err != nil {
+			return err
+		}
 
-	// Parse break ID
-	breakID := args[0]
-	breakIndex, err := parseBreakID(breakID)
-	if err != nil {
-		return err
-	}
+		// Get target date
+		dateFlag, _ := cmd.Flags().GetString("date")
+		var targetDate time.Time
+		var entryId string
 
-	// Get target date
-	dateFlag, _ := cmd.Flags().GetString("date")
-	var targetDate time.Time
-	var entryId string
+		if dateFlag != "" {
+			targetDate, err = time.Parse("2006-01-02", dateFlag)
+			if err != nil {
+				return fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+			}
+			entryId = targetDate.Format("20060102")
+		} else {
+			targetDate = currentTime()
+			entryId = targetDate.Format("20060102")
+		}
 
-	if dateFlag != "" {
-		targetDate, err = time.Parse("2006-01-02", dateFlag)
+		entry, err := store.Get(entryId)
 		if err != nil {
-			return fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+			return err
 		}
-		entryId = targetDate.Format("20060102")
-	} else {
-		targetDate = time.Now()
-		entryId = targetDate.Format("20060102")
-	}
-
-	entry, idx := journal.FetchEntryByID(entryId, entries)
-	if idx == -1 {
-		return journal.EntryNotFoundError(entryId)
-	}
 
-	if breakIndex >= len(entry.Breaks) {
-		return fmt.Errorf("break ID %s not found. Use 'workday break list' to see available breaks", breakID)
-	}
+		if breakIndex >= len(entry.Breaks) {
+			return fmt.Errorf("break ID %s not found. Use 'workday break list' to see available breaks", breakID)
+		}
 
-	// Parse modifications
-	modifications := args[1:]
-	originalBreak := entry.Breaks[breakIndex]
+		// Parse modifications
+		modifications := args[1:]
+		originalBreak := entry.Breaks[breakIndex]
 
-	for _, mod := range modifications {
-		err = applyBreakModification(&entry.Breaks[breakIndex], mod)
-		if err != nil {
-			return fmt.Errorf("error applying modification '%s': %v", mod, err)
+		for _, mod := range modifications {
+			err = applyBreakModification(&entry.Breaks[breakIndex], entry.StartTime, mod)
+			if err != nil {
+				return fmt.Errorf("error applying modification '%s': %v", mod, err)
+			}
 		}
-	}
-
-	// Validate the modified break
-	if result := journal.ValidateBreak(entry.Breaks[breakIndex]); !result.IsValid {
-		return fmt.Errorf("invalid break modification: %v", result.Error)
-	}
 
-	// Save changes
-	entries[idx] = *entry
-	err = journal.SaveEntries(entries, journalPath)
-	if err != nil {
-		return err
-	}
+		// Validate the modified break
+		if result := journal.ValidateBreak(entry.Breaks[breakIndex]); !result.IsValid {
+			return fmt.Errorf("invalid break modification: %v", result.Error)
+		}
+		if err := validateEntry(store, entry, currentTime()); err != nil {
+			return err
+		}
 
-	// Show confirmation
-	fmt.Printf("✅ Break %s modified successfully\n", breakID)
-	fmt.Printf("Original: %s %s-%s (%s)\n",
-		originalBreak.StartTime.Format("15:04"),
-		originalBreak.EndTime.Format("15:04"),
-		originalBreak.Reason,
-		originalBreak.Duration())
+		// Save changes
+		err = store.Upsert(*entry)
+		if err != nil {
+			return err
+		}
 
-	newBreak := entry.Breaks[breakIndex]
-	endTime := "ongoing"
-	duration := "N/A"
-	if !newBreak.EndTime.IsZero() {
-		endTime = newBreak.EndTime.Format("15:04")
-		duration = newBreak.Duration().String()
-	}
+		// Show confirmation
+		fmt.Printf("✅ Break %s modified successfully\n", breakID)
+		fmt.Printf("Original: %s %s-%s (%s)\n",
+			originalBreak.StartTime.Format("15:04"),
+			originalBreak.EndTime.Format("15:04"),
+			originalBreak.Reason,
+			originalBreak.Duration())
+
+		newBreak := entry.Breaks[breakIndex]
+		endTime := "ongoing"
+		duration := "N/A"
+		if !newBreak.EndTime.IsZero() {
+			endTime = newBreak.EndTime.Format("15:04")
+			duration = newBreak.Duration().String()
+		}
 
-	fmt.Printf("Updated:  %s %s-%s (%s)\n",
-		newBreak.StartTime.Format("15:04"),
-		endTime,
-		newBreak.Reason,
-		duration)
+		fmt.Printf("Updated:  %s %s-%s (%s)\n",
+			newBreak.StartTime.Format("15:04"),
+			endTime,
+			newBreak.Reason,
+			duration)
 
-	return nil
+		return nil
+	})
 }
 
 func deleteBreak(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
-	if err != nil {
-		return err
-	}
+	return withStore(func(store journal.Store) error {
+		// Parse break ID
+		breakID := args[0]
+		breakIndex, err := parseBreakID(breakID)
+		if err != nil {
+			return err
+		}
 
-	// Parse break ID
-	breakID := args[0]
-	breakIndex, err := parseBreakID(breakID)
-	if err != nil {
-		return err
-	}
+		// Get target date
+		dateFlag, _ := cmd.Flags().GetString("date")
+		var targetDate time.Time
+		var entryId string
 
-	// Get target date
-	dateFlag, _ := cmd.Flags().GetString("date")
-	var targetDate time.Time
-	var entryId string
+		if dateFlag != "" {
+			targetDate, err = time.Parse("2006-01-02", dateFlag)
+			if err != nil {
+				return fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+			}
+			entryId = targetDate.Format("20060102")
+		} else {
+			targetDate = currentTime()
+			entryId = targetDate.Format("20060102")
+		}
 
-	if dateFlag != "" {
-		targetDate, err = time.Parse("2006-01-02", dateFlag)
+		entry, err := store.Get(entryId)
 		if err != nil {
-			return fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+			return err
 		}
-		entryId = targetDate.Format("20060102")
-	} else {
-		targetDate = time.Now()
-		entryId = targetDate.Format("20060102")
-	}
-
-	entry, idx := journal.FetchEntryByID(entryId, entries)
-	if idx == -1 {
-		return journal.EntryNotFoundError(entryId)
-	}
-
-	if breakIndex >= len(entry.Breaks) {
-		return fmt.Errorf("break ID %s not found. Use 'workday break list' to see available breaks", breakID)
-	}
 
-	// Confirm deletion
-	deletedBreak := entry.Breaks[breakIndex]
-	fmt.Printf("Delete break: %s %s-%s (%s)? [y/N]: ",
-		deletedBreak.StartTime.Format("15:04"),
-		deletedBreak.EndTime.Format("15:04"),
-		deletedBreak.Reason,
-		deletedBreak.Duration())
+		if breakIndex >= len(entry.Breaks) {
+			return fmt.Errorf("break ID %s not found. Use 'workday break list' to see available breaks", breakID)
+		}
 
-	var response string
-	fmt.Scanln(&response)
-	if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
-		fmt.Println("Deletion cancelled")
-		return nil
-	}
+		// Confirm deletion
+		deletedBreak := entry.Breaks[breakIndex]
+		fmt.Printf("Delete break: %s %s-%s (%s)? [y/N]: ",
+			deletedBreak.StartTime.Format("15:04"),
+			deletedBreak.EndTime.Format("15:04"),
+			deletedBreak.Reason,
+			deletedBreak.Duration())
+
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println("Deletion cancelled")
+			return nil
+		}
 
-	// Remove break from slice
-	entry.Breaks = append(entry.Breaks[:breakIndex], entry.Breaks[breakIndex+1:]...)
+		// Remove break from slice
+		entry.Breaks = append(entry.Breaks[:breakIndex], entry.Breaks[breakIndex+1:]...)
+		if err := validateEntry(store, entry, currentTime()); err != nil {
+			return err
+		}
 
-	// Save changes
-	entries[idx] = *entry
-	err = journal.SaveEntries(entries, journalPath)
-	if err != nil {
-		return err
-	}
+		// Save changes
+		err = store.Upsert(*entry)
+		if err != nil {
+			return err
+		}
 
-	fmt.Printf("✅ Break %s deleted successfully\n", breakID)
-	return nil
+		fmt.Printf("✅ Break %s deleted successfully\n", breakID)
+		return nil
+	})
 }
 
-// addBreakToJournal loads the journal, resolves the target day (today when
-// dateFlag is empty, otherwise the YYYY-MM-DD date), builds a completed break
-// from the field:value args anchored to the target date, validates it, and
-// persists the change. It returns the updated entry and its slice index so the
+// addBreakToJournal resolves the target day in store (today when dateFlag is
+// empty, otherwise the YYYY-MM-DD date), builds a completed break from the
+// field:value args anchored to the target date, validates it, and persists the
+// change while holding the journal lock. It returns the updated entry so the
 // caller can render a confirmation. All times-of-day are anchored to the
 // resolved target date rather than to now.
-func addBreakToJournal(journalPath string, dateFlag string, now time.Time, args []string) (*journal.JournalEntry, int, error) {
-	entries, err := journal.LoadEntries(journalPath)
-	if err != nil {
-		return nil, -1, err
-	}
+func addBreakToJournal(store journal.Store, dateFlag string, now time.Time, args []string) (*journal.JournalEntry, error) {
+	var updated *journal.JournalEntry
+	err := withJournalLock(store.Location(), func() error {
+		// Resolve the target date.
+		var targetDate time.Time
+		var entryId string
+		var err error
+		if dateFlag != "" {
+			// Parse in the local zone so the break's times-of-day are anchored to
+			// the same timezone that real-time commands (break start) use. Plain
+			// time.Parse defaults to UTC, which would shift the break by the local
+			// UTC offset and cause false overlaps against locally-stored breaks.
+			targetDate, err = time.ParseInLocation("2006-01-02", dateFlag, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+			}
+			entryId = targetDate.Format("20060102")
+		} else {
+			targetDate = now
+			entryId = targetDate.Format("20060102")
+		}
 
-	// Resolve the target date.
-	var targetDate time.Time
-	var entryId string
-	if dateFlag != "" {
-		// Parse in the local zone so the break's times-of-day are anchored to
-		// the same timezone that real-time commands (break start) use. Plain
-		// time.Parse defaults to UTC, which would shift the break by the local
-		// UTC offset and cause false overlaps against locally-stored breaks.
-		targetDate, err = time.ParseInLocation("2006-01-02", dateFlag, time.Local)
+		entry, err := store.Get(entryId)
+		if errors.Is(err, journal.ErrEntryNotFound) && dateFlag != "" {
+			return fmt.Errorf("no entry found for %s; use 'workday backfill' to create it", dateFlag)
+		}
 		if err != nil {
-			return nil, -1, fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+			return err
 		}
-		entryId = targetDate.Format("20060102")
-	} else {
-		targetDate = now
-		entryId = targetDate.Format("20060102")
-	}
 
-	entry, idx := journal.FetchEntryByID(entryId, entries)
-	if idx == -1 {
-		if dateFlag != "" {
-			return nil, -1, fmt.Errorf("no entry found for %s; use 'workday backfill' to create it", dateFlag)
+		// Parse field:value arguments
+		var startStr, endStr, reason string
+		for _, arg := range args {
+			parts := strings.SplitN(arg, ":", 2)
+			if len(parts) != 2 {
+				return fmt.Errorf("invalid argument format '%s'. Use field:value (e.g., start:12:00)", arg)
+			}
+			field := strings.ToLower(strings.TrimSpace(parts[0]))
+			value := strings.TrimSpace(parts[1])
+
+			switch field {
+			case "start":
+				startStr = value
+			case "end":
+				endStr = value
+			case "reason":
+				reason = value
+			default:
+				return fmt.Errorf("unknown field '%s'. Available fields: start, end, reason", field)
+			}
 		}
-		return nil, -1, journal.EntryNotFoundError(entryId)
-	}
 
-	// Parse field:value arguments
-	var startStr, endStr, reason string
-	for _, arg := range args {
-		parts := strings.SplitN(arg, ":", 2)
-		if len(parts) != 2 {
-			return nil, -1, fmt.Errorf("invalid argument format '%s'. Use field:value (e.g., start:12:00)", arg)
+		// Validate required fields
+		if startStr == "" {
+			return fmt.Errorf("start time is required. Usage: workday break add start:HH:MM end:HH:MM reason:text")
 		}
-		field := strings.ToLower(strings.TrimSpace(parts[0]))
-		value := strings.TrimSpace(parts[1])
-
-		switch field {
-		case "start":
-			startStr = value
-		case "end":
-			endStr = value
-		case "reason":
-			reason = value
-		default:
-			return nil, -1, fmt.Errorf("unknown field '%s'. Available fields: start, end, reason", field)
+		if endStr == "" {
+			return fmt.Errorf("end time is required. Usage: workday break add start:HH:MM end:HH:MM reason:text")
+		}
+		if strings.TrimSpace(reason) == "" {
+			return fmt.Errorf("reason is required. Usage: workday break add start:HH:MM end:HH:MM reason:text")
 		}
-	}
-
-	// Validate required fields
-	if startStr == "" {
-		return nil, -1, fmt.Errorf("start time is required. Usage: workday break add start:HH:MM end:HH:MM reason:text")
-	}
-	if endStr == "" {
-		return nil, -1, fmt.Errorf("end time is required. Usage: workday break add start:HH:MM end:HH:MM reason:text")
-	}
-	if strings.TrimSpace(reason) == "" {
-		return nil, -1, fmt.Errorf("reason is required. Usage: workday break add start:HH:MM end:HH:MM reason:text")
-	}
 
-	// Parse start time
-	startTime, err := time.Parse("15:04", startStr)
-	if err != nil {
-		return nil, -1, fmt.Errorf("invalid start time format '%s'. Use HH:MM", startStr)
-	}
+		// Parse start time
+		startTime, err := journal.ParseClockTime(startStr)
+		if err != nil {
+			return fmt.Errorf("invalid start time format '%s'. Use HH:MM or HH:MM+1", startStr)
+		}
 
-	// Parse end time
-	endTime, err := time.Parse("15:04", endStr)
-	if err != nil {
-		return nil, -1, fmt.Errorf("invalid end time format '%s'. Use HH:MM", endStr)
-	}
+		// Parse end time
+		endTime, err := journal.ParseClockTime(endStr)
+		if err != nil {
+			return fmt.Errorf("invalid end time format '%s'. Use HH:MM or HH:MM+1", endStr)
+		}
 
-	// Construct the break using the TARGET date with the specified times
-	newBreak := journal.Break{
-		StartTime: time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(),
-			startTime.Hour(), startTime.Minute(), 0, 0, targetDate.Location()),
-		EndTime: time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(),
-			endTime.Hour(), endTime.Minute(), 0, 0, targetDate.Location()),
-		Reason: reason,
-	}
+		// Construct the break using the TARGET date with the specified times;
+		// a +N suffix places a time on a later day, after midnight of a night shift
+		newBreak := journal.Break{
+			StartTime: startTime.On(targetDate),
+			EndTime:   endTime.On(targetDate),
+			Reason:    reason,
+		}
 
-	// Validate the break (checks start not zero, end after start, reason non-empty)
-	if result := journal.ValidateBreak(newBreak); !result.IsValid {
-		return nil, -1, fmt.Errorf("invalid break: %v", result.Error)
-	}
+		// Validate the break (checks start not zero, end after start, reason non-empty)
+		if result := journal.ValidateBreak(newBreak); !result.IsValid {
+			return fmt.Errorf("invalid break: %v", result.Error)
+		}
 
-	// Validate no overlap with existing breaks
-	if result := journal.ValidateBreakOverlap(newBreak, entry.Breaks); !result.IsValid {
-		return nil, -1, fmt.Errorf("cannot add break: %v", result.Error)
-	}
+		// Validate no overlap with existing breaks
+		if result := journal.ValidateBreakOverlap(newBreak, entry.Breaks); !result.IsValid {
+			return fmt.Errorf("cannot add break: %v", result.Error)
+		}
 
-	// Append the break and save
-	entry.Breaks = append(entry.Breaks, newBreak)
-	entries[idx] = *entry
+		// Append the break, check the day again and save
+		entry.Breaks = append(entry.Breaks, newBreak)
+		if err := validateEntry(store, entry, currentTime()); err != nil {
+			return err
+		}
+		if err := store.Upsert(*entry); err != nil {
+			return err
+		}
 
-	if err := journal.SaveEntries(entries, journalPath); err != nil {
-		return nil, -1, err
+		updated = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return entry, idx, nil
+	return updated, nil
 }
 
 func addBreak(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
 	dateFlag, _ := cmd.Flags().GetString("date")
 
-	entry, _, err := addBreakToJournal(journalPath, dateFlag, time.Now(), args)
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	entry, err := addBreakToJournal(store, dateFlag, currentTime(), args)
 	if err != nil {
 		return err
 	}
@@ -772,7 +790,10 @@ func parseBreakID(id string) (int, error) {
 	return breakIndex - 1, nil // Convert to 0-based index
 }
 
-func applyBreakModification(br *journal.Break, modification string) error {
+// applyBreakModification applies one field:value modification to br. Times
+// are anchored to day, the day of the entry the break belongs to, so a +N
+// suffix places them after midnight of a night shift.
+func applyBreakModification(br *journal.Break, day time.Time, modification string) error {
 	parts := strings.SplitN(modification, ":", 2)
 	if len(parts) != 2 {
 		return fmt.Errorf("invalid modification format. Use field:value")
@@ -785,21 +806,17 @@ func applyBreakModification(br *journal.Break, modification string) error {
 	case "reason":
 		br.Reason = value
 	case "start":
-		startTime, err := time.Parse("15:04", value)
+		startTime, err := journal.ParseClockTime(value)
 		if err != nil {
-			return fmt.Errorf("invalid start time format. Use HH:MM")
+			return fmt.Errorf("invalid start time format. Use HH:MM or HH:MM+1")
 		}
-		// Keep the same date, just change time
-		br.StartTime = time.Date(br.StartTime.Year(), br.StartTime.Month(), br.StartTime.Day(),
-			startTime.Hour(), startTime.Minute(), 0, 0, br.StartTime.Location())
+		br.StartTime = startTime.On(day)
 	case "end":
-		endTime, err := time.Parse("15:04", value)
+		endTime, err := journal.ParseClockTime(value)
 		if err != nil {
-			return fmt.Errorf("invalid end time format. Use HH:MM")
+			return fmt.Errorf("invalid end time format. Use HH:MM or HH:MM+1")
 		}
-		// Keep the same date, just change time
-		br.EndTime = time.Date(br.StartTime.Year(), br.StartTime.Month(), br.StartTime.Day(),
-			endTime.Hour(), endTime.Minute(), 0, 0, br.StartTime.Location())
+		br.EndTime = endTime.On(day)
 	case "duration":
 		duration, err := time.ParseDuration(value)
 		if err != nil {
@@ -26,6 +26,11 @@ func bootstrapBreakJournal(t *testing.T, entries []journal.JournalEntry) string
 	return name
 }
 
+// openBreakStore returns a JSON store over the journal at filename.
+func openBreakStore(filename string) journal.Store {
+	return journal.NewJSONStore(filename, journal.BackupPolicy{})
+}
+
 // loadBreakJournal reloads entries from filename for assertions.
 func loadBreakJournal(t *testing.T, filename string) []journal.JournalEntry {
 	t.Helper()
@@ -49,14 +54,11 @@ func TestAddBreakToJournal(t *testing.T) {
 		}
 		journalPath := bootstrapBreakJournal(t, entries)
 
-		entry, idx, err := addBreakToJournal(journalPath, "2024-05-27", now,
+		entry, err := addBreakToJournal(openBreakStore(journalPath), "2024-05-27", now,
 			[]string{"start:12:00", "end:13:00", "reason:lunch"})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if idx != 0 {
-			t.Errorf("expected entry index 0, got %d", idx)
-		}
 		if len(entry.Breaks) != 1 {
 			t.Fatalf("expected 1 break, got %d", len(entry.Breaks))
 		}
@@ -100,7 +102,7 @@ func TestAddBreakToJournal(t *testing.T) {
 		}
 		journalPath := bootstrapBreakJournal(t, entries)
 
-		_, _, err := addBreakToJournal(journalPath, "2024-05-27", now,
+		_, err := addBreakToJournal(openBreakStore(journalPath), "2024-05-27", now,
 			[]string{"start:12:00", "end:13:00", "reason:lunch"})
 		if err == nil {
 			t.Fatalf("expected error for missing entry, got nil")
@@ -117,7 +119,7 @@ func TestAddBreakToJournal(t *testing.T) {
 		}
 		journalPath := bootstrapBreakJournal(t, entries)
 
-		_, _, err := addBreakToJournal(journalPath, "2024/05/27", now,
+		_, err := addBreakToJournal(openBreakStore(journalPath), "2024/05/27", now,
 			[]string{"start:12:00", "end:13:00", "reason:lunch"})
 		if err == nil {
 			t.Fatalf("expected error for invalid date format, got nil")
@@ -133,7 +135,7 @@ func TestAddBreakToJournal(t *testing.T) {
 		}
 		journalPath := bootstrapBreakJournal(t, entries)
 
-		entry, _, err := addBreakToJournal(journalPath, "", now,
+		entry, err := addBreakToJournal(openBreakStore(journalPath), "", now,
 			[]string{"start:12:00", "end:13:00", "reason:lunch"})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -164,7 +166,7 @@ func TestAddBreakToJournal(t *testing.T) {
 	t.Run("date unset, today entry missing: refused", func(t *testing.T) {
 		journalPath := bootstrapBreakJournal(t, []journal.JournalEntry{})
 
-		_, _, err := addBreakToJournal(journalPath, "", now,
+		_, err := addBreakToJournal(openBreakStore(journalPath), "", now,
 			[]string{"start:12:00", "end:13:00", "reason:lunch"})
 		if err == nil {
 			t.Fatalf("expected error for missing today entry, got nil")
@@ -184,7 +186,7 @@ func TestAddBreakToJournal(t *testing.T) {
 		}
 		journalPath := bootstrapBreakJournal(t, entries)
 
-		_, _, err := addBreakToJournal(journalPath, "2024-05-27", now,
+		_, err := addBreakToJournal(openBreakStore(journalPath), "2024-05-27", now,
 			[]string{"start:12:30", "end:13:30", "reason:coffee"})
 		if err == nil {
 			t.Fatalf("expected overlap error, got nil")
@@ -220,7 +222,7 @@ func TestAddBreakToJournal(t *testing.T) {
 		}
 		journalPath := bootstrapBreakJournal(t, entries)
 
-		entry, _, err := addBreakToJournal(journalPath, "2026-06-23", now,
+		entry, err := addBreakToJournal(openBreakStore(journalPath), "2026-06-23", now,
 			[]string{"start:11:56", "end:13:00", "reason:lunch"})
 		if err != nil {
 			t.Fatalf("unexpected error adding non-overlapping break: %v", err)
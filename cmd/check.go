@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Checks the journal against the schedule and labor rules",
+	Long: `The check command lists, per day, the days that break the work schedule or
+one of the labor rules from the rules section of the config. Without flags it
+checks the current month up to today.
+
+The rules that can be configured are:
+  break_after  breaks of at least 'break' (30m) after 'after' (6h) of work
+  daily_rest   at least 'min' (11h) of rest between two days
+  max_weekly   at most 'max' (48h) of work from Monday to Sunday
+  no_work_on   no work on the weekdays listed in 'days'
+
+Every rule takes a severity of info, warning (the default) or error:
+
+  rules:
+    - type: break_after
+      after: 6h
+      break: 30m
+    - type: break_after
+      after: 9h
+      break: 45m
+    - type: daily_rest
+      min: 11h
+      severity: error
+    - type: no_work_on
+      days: [sunday]
+
+The rules are also checked when a day is finished with 'workday end' or added
+with 'workday backfill'.
+
+Examples:
+  workday check
+  workday check --from 2026-01-01 --to 2026-03-31`,
+	RunE: checkJournal,
+}
+
+// scheduleRule checks every worked day against the work schedule, the same
+// way workday end does.
+type scheduleRule struct {
+	schedule workSchedule
+}
+
+func (r scheduleRule) Name() string { return "schedule" }
+
+func (r scheduleRule) Check(entries []journal.JournalEntry, i int) []journal.Violation {
+	entry := &entries[i]
+	if len(entry.WorkSessions()) == 0 {
+		return nil
+	}
+	return scheduleViolations(r.schedule.For(entry.StartTime), entry)
+}
+
+// loadRules builds the labor rules of the rules section of the config.
+func loadRules() ([]journal.Rule, error) {
+	var configs []journal.RuleConfig
+	if err := viper.UnmarshalKey("rules", &configs); err != nil {
+		return nil, fmt.Errorf("invalid rules in config: %v", err)
+	}
+	return journal.BuildRules(configs)
+}
+
+// checkEntryRules checks entry against the configured labor rules. The rest
+// of its week, and the day before it, are read from store for the rules that
+// span several days.
+func checkEntryRules(store journal.Store, entry *journal.JournalEntry) ([]journal.Violation, error) {
+	rules, err := loadRules()
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+
+	monday, _ := journal.WeekBounds(entry.StartTime)
+	entries, err := store.Range(monday.AddDate(0, 0, -1), entry.StartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// The store may still hold the entry as it was before the change
+	replaced := false
+	for i := range entries {
+		if entries[i].ID == entry.ID {
+			entries[i], replaced = *entry, true
+		}
+	}
+	if !replaced {
+		entries = append(entries, *entry)
+	}
+	return journal.CheckRules(rules, entries, entry.StartTime, entry.StartTime), nil
+}
+
+// findingStyle renders a finding in the style of its severity.
+func findingStyle(f journal.Finding) string {
+	return violationStyle(journal.Violation{Rule: f.Code, Severity: f.Severity, Message: f.Message})
+}
+
+// renderFindings lists findings one per line, in the style of their severity.
+// Acknowledged findings are shown dimmed.
+func renderFindings(findings []journal.Finding) string {
+	var content strings.Builder
+	for _, finding := range findings {
+		if finding.Acknowledged {
+			content.WriteString(styles.HelpStyle.Render("✓  " + finding.String() + " (acknowledged)"))
+		} else {
+			content.WriteString(findingStyle(finding))
+		}
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// findingsSummary counts the findings of entries that have not been
+// acknowledged, for the summary of the period reports. It is empty when there
+// are none.
+func findingsSummary(entries []journal.JournalEntry) string {
+	findings, days := 0, 0
+	for _, entry := range entries {
+		if active := len(entry.ActiveFindings()); active > 0 {
+			findings += active
+			days++
+		}
+	}
+	if findings == 0 {
+		return ""
+	}
+	return fmt.Sprintf("🔍 %d findings to review on %d days, see 'workday ack'", findings, days)
+}
+
+// violationStyle renders a violation in the style of its severity.
+func violationStyle(v journal.Violation) string {
+	switch v.Severity {
+	case journal.SeverityError:
+		return styles.ErrorStyle.Render("❌ " + v.String())
+	case journal.SeverityInfo:
+		return styles.InfoStyle.Render("💡 " + v.String())
+	default:
+		return styles.ErrorStyle.Render("⚠️  " + v.String())
+	}
+}
+
+func checkJournal(cmd *cobra.Command, args []string) error {
+	fromStr, _ := cmd.Flags().GetString("from")
+	toStr, _ := cmd.Flags().GetString("to")
+
+	now := currentTime()
+	from, to, period, err := resolveReportPeriod(false, fromStr == "" && toStr == "", fromStr, toStr, now)
+	if err != nil {
+		return err
+	}
+	if today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()); to.After(today) {
+		to = today
+	}
+
+	schedule, err := loadSchedule()
+	if err != nil {
+		return err
+	}
+	rules, err := loadRules()
+	if err != nil {
+		return err
+	}
+	rules = append([]journal.Rule{scheduleRule{schedule: schedule}}, rules...)
+
+	// Start a week early, for the rules that look at the days before
+	entries, err := loadEntriesInRange(from.AddDate(0, 0, -7), to)
+	if err != nil {
+		return err
+	}
+	violations := journal.CheckRules(rules, entries, from, to)
+
+	fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("🔍 Compliance Check - %s", period)))
+	fmt.Println()
+	if len(violations) == 0 {
+		fmt.Println(styles.SuccessStyle.Render("✅ No violations found"))
+		return nil
+	}
+
+	// Violations that were acknowledged as findings are shown, but dimmed
+	acknowledged := make(map[string]bool)
+	for _, entry := range entries {
+		for _, finding := range entry.Findings {
+			if finding.Acknowledged {
+				acknowledged[entry.ID+"/"+finding.Code] = true
+			}
+		}
+	}
+
+	days, acked := 0, 0
+	for i, v := range violations {
+		if i == 0 || violations[i-1].Day != v.Day {
+			days++
+			day, _ := time.ParseInLocation("20060102", v.Day, now.Location())
+			fmt.Println(styles.SectionStyle.Render("📅 " + day.Format("Mon, Jan 2 2006")))
+		}
+		if acknowledged[v.Day+"/"+v.Rule] {
+			acked++
+			fmt.Println(styles.HelpStyle.Render("✓  " + v.String() + " (acknowledged)"))
+			continue
+		}
+		fmt.Println(violationStyle(v))
+	}
+	fmt.Println()
+	summary := fmt.Sprintf("📊 %d violations on %d days", len(violations), days)
+	if acked > 0 {
+		summary += fmt.Sprintf(", %d acknowledged", acked)
+	}
+	fmt.Println(styles.SummaryStyle.Render(summary))
+	return nil
+}
+
+func init() {
+	checkCmd.Flags().String("from", "", "Start of the checked range in YYYY-MM-DD format")
+	checkCmd.Flags().String("to", "", "End of the checked range in YYYY-MM-DD format")
+	rootCmd.AddCommand(checkCmd)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/spf13/viper"
+)
+
+func TestLoadRules(t *testing.T) {
+	setStorageViper(t, "", "", "")
+	viper.SetConfigType("yaml")
+	config := `
+rules:
+  - type: break_after
+    after: 9h
+    break: 45m
+  - type: no_work_on
+    days: [sunday]
+    severity: info
+`
+	if err := viper.ReadConfig(strings.NewReader(config)); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := loadRules()
+	if err != nil {
+		t.Fatalf("loadRules() error = %v", err)
+	}
+	if len(rules) != 2 || rules[0].Name() != "break_after" || rules[1].Name() != "no_work_on" {
+		t.Errorf("unexpected rules %v", rules)
+	}
+
+	viper.Set("rules", []interface{}{map[string]interface{}{"type": "siesta"}})
+	if _, err := loadRules(); err == nil {
+		t.Error("expected an error for an unknown rule type")
+	}
+}
+
+func TestBackfillChecksRules(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 5, day, hour, 0, 0, 0, time.Local)
+	}
+	lateShift := journal.JournalEntry{
+		ID:        "20240526",
+		StartTime: at(26, 14),
+		EndTime:   at(26, 23),
+		Sessions:  []journal.Session{{StartTime: at(26, 14), EndTime: at(26, 23)}},
+	}
+	path := writeTempJournal(t, []journal.JournalEntry{lateShift})
+	setBackfillViper(t, path, "8h", "1h", "10h")
+	viper.Set("rules", []interface{}{map[string]interface{}{"type": "daily_rest", "severity": "error"}})
+
+	entry, err := backfillAndSave([]string{"20240527", "start:07:00", "end:16:00", "break:12:00-13:00:lunch"})
+	if err != nil {
+		t.Fatalf("backfillAndSave returned hard error: %v", err)
+	}
+	findings := entry.ActiveFindings()
+	if len(findings) != 1 || findings[0].Code != "daily_rest" || findings[0].Severity != journal.SeverityError {
+		t.Fatalf("expected one daily_rest error, got %v", findings)
+	}
+}
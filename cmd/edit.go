@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -8,8 +9,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // editCmd represents the edit command
@@ -23,17 +24,23 @@ You can specify a date in YYYYMMDD format, or if no date is provided,
 it will edit today's entry. The TUI allows you to edit notes, times, 
 and other entry details in a form-like interface.
 
+With --editor the whole day is opened as a text document in $VISUAL or
+$EDITOR instead: its sessions, breaks, notes with their tags and tracked time
+segments, one per line. The document is checked like a backfilled day when
+the editor is closed, and opened again with the problems found as comments
+until it is valid. Saving it unchanged cancels the edit.
+
 Examples:
-  workday edit           # Edit today's entry
-  workday edit 20231201  # Edit entry for December 1, 2023`,
+  workday edit                    # Edit today's entry
+  workday edit 20231201           # Edit entry for December 1, 2023
+  workday edit --editor 20231201  # Edit it in your text editor`,
 	RunE: runEditTUI,
 }
 
 type editModel struct {
-	entry       *journal.JournalEntry
-	entryIndex  int
-	entries     []journal.JournalEntry
-	journalPath string
+	entry  *journal.JournalEntry
+	store  journal.Store
+	opened string // fingerprint of the entry when it was loaded
 
 	// Form fields
 	inputs  []textinput.Model
@@ -44,6 +51,7 @@ type editModel struct {
 	height   int
 	quitting bool
 	saved    bool
+	err      error
 }
 
 const (
@@ -72,8 +80,9 @@ func (m editModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "ctrl+s":
 			if err := m.saveEntry(); err != nil {
-				// Handle error - in a real app you might show an error message
-				return m, tea.Quit
+				// Stay in the form so the input can be fixed
+				m.err = err
+				return m, nil
 			}
 			m.saved = true
 			m.quitting = true
@@ -146,6 +155,12 @@ func (m editModel) View() string {
 		labelStyle.Render("Notes:")+"\n"+m.inputs[inputNotes].View(),
 	) + "\n\n"
 
+	// Error display
+	if m.err != nil {
+		s += styles.ErrorStyle.Render(fmt.Sprintf("❌ Error: %s", m.err.Error()))
+		s += "\n\n"
+	}
+
 	// Instructions
 	helpStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("241")).
@@ -174,49 +189,90 @@ func (m *editModel) prevInput() {
 }
 
 func (m *editModel) saveEntry() error {
-	// Parse and update start time
-	if startTimeStr := m.inputs[inputStartTime].Value(); startTimeStr != "" {
-		if startTime, err := time.Parse("15:04", startTimeStr); err == nil {
-			// Keep the original date, update time
-			originalDate := m.entry.StartTime
-			m.entry.StartTime = time.Date(
-				originalDate.Year(), originalDate.Month(), originalDate.Day(),
-				startTime.Hour(), startTime.Minute(), 0, 0, originalDate.Location(),
-			)
+	// Parse both times before changing anything
+	var startTime time.Time
+	var endTime journal.ClockTime
+	startTimeStr := m.inputs[inputStartTime].Value()
+	if startTimeStr != "" {
+		var err error
+		if startTime, err = time.Parse("15:04", startTimeStr); err != nil {
+			return fmt.Errorf("invalid start time '%s'. Use HH:MM", startTimeStr)
 		}
 	}
-
-	// Parse and update end time
-	if endTimeStr := m.inputs[inputEndTime].Value(); endTimeStr != "" {
-		if endTime, err := time.Parse("15:04", endTimeStr); err == nil {
-			// Keep the original date, update time
-			originalDate := m.entry.StartTime
-			m.entry.EndTime = time.Date(
-				originalDate.Year(), originalDate.Month(), originalDate.Day(),
-				endTime.Hour(), endTime.Minute(), 0, 0, originalDate.Location(),
-			)
+	endTimeStr := m.inputs[inputEndTime].Value()
+	if endTimeStr != "" {
+		var err error
+		if endTime, err = journal.ParseClockTime(endTimeStr); err != nil {
+			return err
 		}
 	}
 
-	// Update notes
+	// Update start time, keeping the original date
+	if startTimeStr != "" {
+		originalDate := m.entry.StartTime
+		m.entry.SetStartTime(time.Date(
+			originalDate.Year(), originalDate.Month(), originalDate.Day(),
+			startTime.Hour(), startTime.Minute(), 0, 0, originalDate.Location(),
+		))
+	}
+
+	// Update end time, keeping the original date; a +N suffix moves it to a later day
+	if endTimeStr != "" {
+		m.entry.SetEndTime(endTime.On(m.entry.StartTime))
+	}
+
+	// Update notes line by line, so edited notes keep their tags, times and
+	// revisions
 	notesText := m.inputs[inputNotes].Value()
 	if notesText != "" {
-		// Clear existing notes and add new ones
-		m.entry.Notes = []journal.Note{}
-		// Split by lines and create notes
-		lines := splitLines(notesText)
-		for _, line := range lines {
-			if line != "" {
-				m.entry.Notes = append(m.entry.Notes, journal.Note{Contents: line})
+		now := currentTime()
+		var notes []journal.Note
+		for _, line := range splitLines(notesText) {
+			if line == "" {
+				continue
+			}
+			if len(notes) < len(m.entry.Notes) {
+				note := m.entry.Notes[len(notes)]
+				note.Edit(line, now)
+				notes = append(notes, note)
+			} else {
+				notes = append(notes, journal.NewNote(line, now))
 			}
 		}
+		m.entry.Notes = notes
 	}
 
-	// Update the entry in the slice
-	m.entries[m.entryIndex] = *m.entry
+	// Reload the entry under the lock, and save nothing if another command
+	// changed it while the form was open, as the notes would replace its own
+	return withJournalLock(m.store.Location(), func() error {
+		entry, err := m.store.Get(m.entry.ID)
+		if err != nil {
+			return err
+		}
+		fingerprint, err := entryFingerprint(entry)
+		if err != nil {
+			return err
+		}
+		if fingerprint != m.opened {
+			return fmt.Errorf("%w, press Esc and edit it again", errEntryChanged)
+		}
+		// Only the first session's start and the last session's end are
+		// editable here
+		entry.SetStartTime(m.entry.StartTime)
+		if !m.entry.EndTime.IsZero() {
+			entry.SetEndTime(m.entry.EndTime)
+		}
+		entry.Notes = m.entry.Notes
+		if result := journal.ValidateEntry(entry); !result.IsValid {
+			return result.Error
+		}
 
-	// Save to file
-	return journal.SaveEntries(m.entries, m.journalPath)
+		// The new times may raise or settle findings
+		if err := validateEntry(m.store, entry, currentTime()); err != nil {
+			return err
+		}
+		return m.store.Upsert(*entry)
+	})
 }
 
 func splitLines(text string) []string {
@@ -237,24 +293,40 @@ func splitLines(text string) []string {
 }
 
 func runEditTUI(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
+	store, err := openStore()
 	if err != nil {
 		return fmt.Errorf("failed to load journal: %w", err)
 	}
+	defer store.Close()
 
 	// Determine which entry to edit
 	var targetDate string
 	if len(args) > 0 {
 		targetDate = args[0]
 	} else {
-		targetDate = time.Now().Format("20060102")
+		targetDate = currentTime().Format("20060102")
+	}
+
+	if useEditor, _ := cmd.Flags().GetBool("editor"); useEditor {
+		saved, err := editEntryInEditor(store, targetDate, editorCommand(), currentTime())
+		if err != nil {
+			return err
+		}
+		if !saved {
+			fmt.Println("Edit cancelled.")
+			return nil
+		}
+		fmt.Println(styles.SuccessStyle.Render("✅ Entry saved successfully!"))
+		return nil
 	}
 
-	entry, idx := journal.FetchEntryByID(targetDate, entries)
-	if idx == -1 {
+	entry, err := store.Get(targetDate)
+	if errors.Is(err, journal.ErrEntryNotFound) {
 		return fmt.Errorf("no entry found for date: %s", targetDate)
 	}
+	if err != nil {
+		return fmt.Errorf("failed to load journal: %w", err)
+	}
 
 	// Create text inputs
 	inputs := make([]textinput.Model, 3)
@@ -268,9 +340,9 @@ func runEditTUI(cmd *cobra.Command, args []string) error {
 
 	// End time input
 	inputs[inputEndTime] = textinput.New()
-	inputs[inputEndTime].Placeholder = "17:30"
+	inputs[inputEndTime].Placeholder = "17:30 (06:00+1 for the next day)"
 	if !entry.EndTime.IsZero() {
-		inputs[inputEndTime].SetValue(entry.EndTime.Format("15:04"))
+		inputs[inputEndTime].SetValue(journal.FormatClockTime(entry.EndTime, entry.StartTime))
 	}
 	inputs[inputEndTime].Width = 20
 
@@ -288,13 +360,17 @@ func runEditTUI(cmd *cobra.Command, args []string) error {
 	}
 	inputs[inputNotes].SetValue(notesText)
 
+	opened, err := entryFingerprint(entry)
+	if err != nil {
+		return err
+	}
+
 	model := editModel{
-		entry:       entry,
-		entryIndex:  idx,
-		entries:     entries,
-		journalPath: journalPath,
-		inputs:      inputs,
-		focused:     0,
+		entry:   entry,
+		store:   store,
+		opened:  opened,
+		inputs:  inputs,
+		focused: 0,
 	}
 
 	p := tea.NewProgram(&model, tea.WithAltScreen())
@@ -303,6 +379,7 @@ func runEditTUI(cmd *cobra.Command, args []string) error {
 }
 
 func init() {
+	editCmd.Flags().Bool("editor", false, "Edit the whole day as a text document in $VISUAL or $EDITOR")
 	rootCmd.AddCommand(editCmd)
 }
 
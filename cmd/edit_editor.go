@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+)
+
+// editorCommand returns the editor to open documents in, from $VISUAL or
+// $EDITOR with any arguments they carry, such as "code --wait", falling back
+// to vi.
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// runEditor opens path in editor on the terminal and waits for it to exit.
+func runEditor(editor []string, path string) error {
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", editor[0], err)
+	}
+	return nil
+}
+
+// editEntryInEditor edits the entry with the given ID as a text document in
+// editor (see journal.FormatEntryDocument) and saves it when the document is
+// valid. A document with problems is opened again with a comment after each
+// line at fault, until it is fixed or saved without changes, which cancels
+// the edit like an empty document does. It reports whether the entry was
+// saved.
+//
+// The journal is not locked while the editor is open. If another command
+// changes the entry meanwhile, nothing is saved: the editor is opened again
+// with the current version of the entry added as comments (see
+// journal.ConflictEntryDocument), to be merged by hand.
+func editEntryInEditor(store journal.Store, id string, editor []string, now time.Time) (bool, error) {
+	entry, err := store.Get(id)
+	if errors.Is(err, journal.ErrEntryNotFound) {
+		return false, fmt.Errorf("no entry found for date: %s", id)
+	}
+	if err != nil {
+		return false, err
+	}
+	opened, err := entryFingerprint(entry)
+	if err != nil {
+		return false, err
+	}
+
+	file, err := os.CreateTemp("", "workday-"+id+"-*.txt")
+	if err != nil {
+		return false, err
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	doc := journal.FormatEntryDocument(entry)
+	for {
+		if err := os.WriteFile(path, []byte(doc), 0600); err != nil {
+			return false, err
+		}
+		if err := runEditor(editor, path); err != nil {
+			return false, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return false, err
+		}
+		text := string(data)
+		if text == doc || journal.IsEmptyEntryDocument(text) {
+			return false, nil
+		}
+
+		edited, problems := journal.ParseEntryDocument(text, entry, now)
+		if len(problems) == 0 {
+			current, err := saveEditedEntry(store, edited, opened, now)
+			if !errors.Is(err, errEntryChanged) {
+				return err == nil, err
+			}
+			fmt.Println(styles.ErrorStyle.Render("❌ The entry was changed while the editor was open, reopening the editor:"))
+			entry = current
+			if opened, err = entryFingerprint(entry); err != nil {
+				return false, err
+			}
+			doc = journal.ConflictEntryDocument(text, entry)
+			continue
+		}
+		fmt.Println(styles.ErrorStyle.Render("❌ The entry has problems, reopening the editor:"))
+		for _, problem := range problems {
+			fmt.Println(styles.ErrorStyle.Render("  " + problem.Error()))
+		}
+		doc = journal.AnnotateEntryDocument(text, problems)
+	}
+}
+
+// errEntryChanged is returned when an entry is saved from an editor after
+// another command changed it.
+var errEntryChanged = errors.New("the entry was changed while it was being edited")
+
+// entryFingerprint returns a digest of entry, to tell whether it was changed
+// since it was read.
+func entryFingerprint(entry *journal.JournalEntry) (string, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// saveEditedEntry saves the parts of edited that the entry document holds
+// under the journal lock, if the entry in the journal still has the
+// fingerprint opened it had when the editor was opened. Otherwise nothing is
+// saved and the current entry is returned with errEntryChanged.
+func saveEditedEntry(store journal.Store, edited *journal.JournalEntry, opened string, now time.Time) (*journal.JournalEntry, error) {
+	var current *journal.JournalEntry
+	err := withJournalLock(store.Location(), func() error {
+		entry, err := store.Get(edited.ID)
+		if err != nil {
+			return err
+		}
+		fingerprint, err := entryFingerprint(entry)
+		if err != nil {
+			return err
+		}
+		if fingerprint != opened {
+			current = entry
+			return errEntryChanged
+		}
+		entry.StartTime = edited.StartTime
+		entry.EndTime = edited.EndTime
+		entry.Sessions = edited.Sessions
+		entry.Breaks = edited.Breaks
+		entry.Notes = edited.Notes
+		entry.TimeSegments = edited.TimeSegments
+
+		// The new times may raise or settle findings
+		if err := validateEntry(store, entry, now); err != nil {
+			return err
+		}
+		return store.Upsert(*entry)
+	})
+	return current, err
+}
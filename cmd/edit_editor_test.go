@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/google/go-cmp/cmp"
+)
+
+// scriptEditor writes a shell script that edits the file it is given with
+// the sed expressions of each run in turn, and returns it as an editor.
+func scriptEditor(t *testing.T, runs ...string) []string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the editor script needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\nrun=$(cat " + dir + "/runs 2>/dev/null || echo 0)\necho $((run + 1)) > " + dir + "/runs\ncase $run in\n"
+	for i, run := range runs {
+		script += "  " + string(rune('0'+i)) + ") sed -e '" + run + "' \"$1\" > " + dir + "/out && cat " + dir + "/out > \"$1\" ;;\n"
+	}
+	script += "esac\ncp \"$1\" " + dir + "/last\n"
+	path := filepath.Join(dir, "editor.sh")
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return []string{path}
+}
+
+func TestEditEntryInEditor(t *testing.T) {
+	day := time.Date(2024, 5, 27, 0, 0, 0, 0, time.Local)
+	now := day.Add(20 * time.Hour)
+	seed := func(t *testing.T) journal.Store {
+		entry, err := journal.NewBackfilledEntry(day, day.Add(9*time.Hour), day.Add(17*time.Hour),
+			[]journal.Break{{StartTime: day.Add(12 * time.Hour), EndTime: day.Add(13 * time.Hour), Reason: "lunch"}},
+			[]journal.Note{{Contents: "Reviewed PRs #review"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := writeTempJournal(t, []journal.JournalEntry{*entry})
+		setBackfillViper(t, path, "7h", "1h", "10h")
+		return journal.NewJSONStore(path, journal.BackupPolicy{})
+	}
+
+	tests := []struct {
+		name      string
+		runs      []string
+		wantSaved bool
+		wantNotes []string
+		wantEnd   string
+	}{
+		{
+			name:      "saves the edits",
+			runs:      []string{"s/^09:00-17:00$/09:00-18:00/; s/^Reviewed PRs #review$/Reviewed PRs #review #team/"},
+			wantSaved: true,
+			wantNotes: []string{"Reviewed PRs #review #team"},
+			wantEnd:   "18:00",
+		},
+		{
+			name:      "reopens until fixed",
+			runs:      []string{"s/^12:00-13:00 lunch$/12:00-13:00/", "s/^12:00-13:00$/12:00-13:00 lunch/; s/^09:00-17:00$/08:30-17:00/"},
+			wantSaved: true,
+			wantNotes: []string{"Reviewed PRs #review"},
+			wantEnd:   "17:00",
+		},
+		{
+			name:      "unchanged cancels",
+			runs:      []string{"s/^//"},
+			wantNotes: []string{"Reviewed PRs #review"},
+			wantEnd:   "17:00",
+		},
+		{
+			name:      "emptied cancels",
+			runs:      []string{"/^[^#]/d"},
+			wantNotes: []string{"Reviewed PRs #review"},
+			wantEnd:   "17:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := seed(t)
+			editor := scriptEditor(t, tt.runs...)
+
+			saved, err := editEntryInEditor(store, "20240527", editor, now)
+			if err != nil {
+				t.Fatalf("editEntryInEditor() error = %v", err)
+			}
+			if saved != tt.wantSaved {
+				t.Errorf("editEntryInEditor() = %v, want %v", saved, tt.wantSaved)
+			}
+
+			entry, err := store.Get("20240527")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var notes []string
+			for _, note := range entry.Notes {
+				notes = append(notes, journal.FormatNoteWithTags(note.Contents, note.Tags))
+			}
+			if diff := cmp.Diff(tt.wantNotes, notes); diff != "" {
+				t.Errorf("notes mismatch (-want +got):\n%s", diff)
+			}
+			if got := entry.EndTime.Format("15:04"); got != tt.wantEnd {
+				t.Errorf("end time = %s, want %s", got, tt.wantEnd)
+			}
+		})
+	}
+
+	// The second run of "reopens until fixed" is shown the problem
+	store := seed(t)
+	editor := scriptEditor(t, "s/^12:00-13:00 lunch$/12:00-13:00/", "s/^//")
+	if _, err := editEntryInEditor(store, "20240527", editor, now); err != nil {
+		t.Fatal(err)
+	}
+	last, err := os.ReadFile(filepath.Join(filepath.Dir(editor[0]), "last"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(last), "12:00-13:00\n#: ERROR: ") {
+		t.Errorf("reopened document has no error after the break:\n%s", last)
+	}
+
+	if _, err := editEntryInEditor(store, "20240101", editor, now); err == nil {
+		t.Error("expected an error for a day without an entry")
+	}
+}
+
+func TestEditEntryInEditorConflict(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the editor script needs a POSIX shell")
+	}
+	day := time.Date(2024, 5, 27, 0, 0, 0, 0, time.Local)
+	now := day.Add(20 * time.Hour)
+	entry, err := journal.NewBackfilledEntry(day, day.Add(9*time.Hour), day.Add(17*time.Hour),
+		[]journal.Break{{StartTime: day.Add(12 * time.Hour), EndTime: day.Add(13 * time.Hour), Reason: "lunch"}},
+		[]journal.Note{{Contents: "Reviewed PRs #review"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writeTempJournal(t, []journal.JournalEntry{*entry})
+	setBackfillViper(t, path, "7h", "1h", "10h")
+	store := journal.NewJSONStore(path, journal.BackupPolicy{})
+
+	// The journal as another command leaves it while the editor is open
+	dir := t.TempDir()
+	changed := *entry
+	changed.Notes = append(append([]journal.Note{}, entry.Notes...), journal.NewNote("Deployed the fix", now))
+	if err := journal.SaveEntries([]journal.JournalEntry{changed}, filepath.Join(dir, "changed.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first run edits the note while the journal changes, the second
+	// keeps the new note from the conflict comments
+	script := "#!/bin/sh\nrun=$(cat " + dir + "/runs 2>/dev/null || echo 0)\necho $((run + 1)) > " + dir + "/runs\ncase $run in\n" +
+		"  0) sed -e 's/^Reviewed PRs #review$/Reviewed PRs #review #team/' \"$1\" > " + dir + "/out && cat " + dir + "/out > \"$1\"\n" +
+		"     cp " + dir + "/changed.json " + path + " ;;\n" +
+		"  1) cp \"$1\" " + dir + "/conflict\n" +
+		"     sed -e '/^\\[notes\\]$/a\\\nDeployed the fix' \"$1\" > " + dir + "/out && cat " + dir + "/out > \"$1\" ;;\n" +
+		"esac\n"
+	editor := filepath.Join(dir, "editor.sh")
+	if err := os.WriteFile(editor, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := editEntryInEditor(store, "20240527", []string{editor}, now)
+	if err != nil || !saved {
+		t.Fatalf("editEntryInEditor() = %v, %v, want saved", saved, err)
+	}
+
+	conflict, err := os.ReadFile(filepath.Join(dir, "conflict"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"Reviewed PRs #review #team", "#: CURRENT: Deployed the fix"} {
+		if !strings.Contains(string(conflict), "\n"+line+"\n") {
+			t.Errorf("reopened document is missing line %q:\n%s", line, conflict)
+		}
+	}
+
+	got, err := store.Get("20240527")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var notes []string
+	for _, note := range got.Notes {
+		notes = append(notes, journal.FormatNoteWithTags(note.Contents, note.Tags))
+	}
+	if diff := cmp.Diff([]string{"Deployed the fix", "Reviewed PRs #review #team"}, notes); diff != "" {
+		t.Errorf("notes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEditModelSaveEntryConflict(t *testing.T) {
+	day := time.Date(2024, 5, 27, 0, 0, 0, 0, time.Local)
+	entry, err := journal.NewBackfilledEntry(day, day.Add(9*time.Hour), day.Add(17*time.Hour), nil,
+		[]journal.Note{{Contents: "Reviewed PRs"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writeTempJournal(t, []journal.JournalEntry{*entry})
+	setBackfillViper(t, path, "7h", "1h", "10h")
+	store := journal.NewJSONStore(path, journal.BackupPolicy{})
+
+	opened, err := entryFingerprint(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := make([]textinput.Model, 3)
+	for i := range inputs {
+		inputs[i] = textinput.New()
+	}
+	inputs[inputNotes].SetValue("Reviewed PRs in depth")
+	model := editModel{entry: entry, store: store, opened: opened, inputs: inputs}
+
+	// A note added by another command while the form is open
+	changed := *entry
+	changed.Notes = append(append([]journal.Note{}, entry.Notes...), journal.NewNote("Deployed the fix", day.Add(16*time.Hour)))
+	if err := store.Upsert(changed); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := model.saveEntry(); !errors.Is(err, errEntryChanged) {
+		t.Fatalf("saveEntry() error = %v, want errEntryChanged", err)
+	}
+	got, err := store.Get(entry.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Notes) != 2 || got.Notes[0].Contents != "Reviewed PRs" || got.Notes[1].Contents != "Deployed the fix" {
+		t.Errorf("notes = %+v, want both notes untouched", got.Notes)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,7 +10,6 @@ import (
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // endCmd represents the end command
@@ -29,7 +29,6 @@ type endModel struct {
 	entry         *journal.JournalEntry
 	date          time.Time
 	totalWorkTime time.Duration
-	validationErr error
 	width         int
 	height        int
 	quitting      bool
@@ -66,7 +65,8 @@ func (m endModel) View() string {
 
 	// Title
 	dateStr := m.date.Format("Monday, January 2, 2006")
-	if m.validationErr != nil {
+	findings := m.entry.ActiveFindings()
+	if len(findings) > 0 {
 		content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("⚠️  Workday Completed - %s", dateStr)))
 	} else {
 		content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("✅ Workday Completed - %s", dateStr)))
@@ -81,10 +81,15 @@ func (m endModel) View() string {
 	content.WriteString(styles.LabelStyle.Render("Started:") + " " + styles.ValueStyle.Render(startTime))
 	content.WriteString("\n")
 
-	endTime := m.entry.EndTime.Format("15:04")
+	endTime := journal.FormatClockTime(m.entry.EndTime, m.entry.StartTime)
 	content.WriteString(styles.LabelStyle.Render("Ended:") + " " + styles.ValueStyle.Render(endTime))
 	content.WriteString("\n")
 
+	if sessions := m.entry.WorkSessions(); len(sessions) > 1 {
+		content.WriteString(styles.LabelStyle.Render("Sessions:") + " " + styles.ValueStyle.Render(formatSessions(sessions, m.entry.StartTime)))
+		content.WriteString("\n")
+	}
+
 	hours := int(m.totalWorkTime.Hours())
 	minutes := int(m.totalWorkTime.Minutes()) % 60
 	durationStr := fmt.Sprintf("%dh %dm", hours, minutes)
@@ -96,10 +101,11 @@ func (m endModel) View() string {
 	content.WriteString(styles.SectionStyle.Render("🔍 Validation"))
 	content.WriteString("\n")
 
-	if m.validationErr != nil {
-		content.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("⚠️  %s", m.validationErr.Error())))
-	} else {
+	content.WriteString(renderFindings(findings))
+	if len(findings) == 0 {
 		content.WriteString(styles.SuccessStyle.Render("✅ All validations passed"))
+	} else {
+		content.WriteString(styles.InfoStyle.Render("💡 Use 'workday ack' to dismiss findings you have reviewed"))
 	}
 	content.WriteString("\n")
 
@@ -111,30 +117,29 @@ func (m endModel) View() string {
 
 // markDayAsFinished marks the current day's JournalEntry as finished.
 // It loads the journal entries from the file, finds the entry for the current day,
-// and sets its EndTime to the current time.
+// and ends its open session at the current time.
+// If every session has already ended, it offers to move the end of the last one instead.
 // If no entry is found for the current day, it returns and error.
 // After modifying the entry, it saves the updated entries back to the file.
 func markDayAsFinished(cmd *cobra.Command, args []string) error {
 	// Get current date
-	now := time.Now()
-	currentDayId := now.Format("20060102")
-	dateStr := now.Format("2006-01-02")
+	now := currentTime()
 
-	// Load entries
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
+	// After midnight this is still the previous day's entry if that shift has
+	// not ended yet
+	current, err := loadCurrentEntry(now)
+	if errors.Is(err, journal.ErrEntryNotFound) {
+		return fmt.Errorf("No entry found for the current day")
+	}
 	if err != nil {
 		return err
 	}
+	dateStr := current.StartTime.Format("2006-01-02")
 
-	// Get the index of the entry on the slice, -1 if not found
-	entry, idx := journal.FetchEntryByID(currentDayId, entries)
-
-	if idx == -1 {
-		return fmt.Errorf("No entry found for the current day")
-	}
-
-	if !entry.EndTime.IsZero() {
+	// Ask before taking the lock, so other commands are not kept waiting for
+	// the answer
+	override := current.OpenSession() == nil
+	if override {
 		fmt.Printf("There is already an EndTime for %s. Do you want to override it? (y/N): ", dateStr)
 		userInput, err := getUserInput()
 		if err != nil {
@@ -144,32 +149,48 @@ func markDayAsFinished(cmd *cobra.Command, args []string) error {
 			fmt.Println("No changes made...")
 			return nil
 		}
-		entries[idx].EndDay()
-		fmt.Printf("Data for %s overwrote. Saving...", dateStr)
-	} else {
-		entries[idx].EndDay()
 	}
 
-	validationErr := validateEntry(&entries[idx])
-	if validationErr != nil {
-		validationNote := journal.Note{Contents: fmt.Sprintf("Validation Error: %s", validationErr)}
-		entries[idx].AddNote(validationNote)
-	}
+	var entry journal.JournalEntry
+	err = withStore(func(store journal.Store) error {
+		reloaded, err := journal.CurrentEntry(store, now)
+		if err != nil && !errors.Is(err, journal.ErrEntryNotFound) {
+			return err
+		}
+		// The answer only holds for the entry as it was when it was asked
+		if reloaded == nil || reloaded.ID != current.ID || (reloaded.OpenSession() == nil) != override {
+			return fmt.Errorf("the entry for %s changed while waiting for an answer, nothing was changed", dateStr)
+		}
+		current = reloaded
+		current.SetEndTime(now)
+		if override {
+			fmt.Printf("Data for %s overwrote. Saving...", dateStr)
+		}
+
+		if err := validateEntry(store, current, now); err != nil {
+			return err
+		}
 
-	err = journal.SaveEntries(entries, journalPath)
+		err = store.Upsert(*current)
+		if err != nil {
+			return fmt.Errorf("Failed to save journal entries: %v\n", err)
+		}
+
+		entry = *current
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("Failed to save journal entries: %v\n", err)
+		return err
 	}
 
 	// Calculate total work time for display
-	totalWorkTime := entries[idx].TotalWorkTime()
+	totalWorkTime := entry.TotalWorkTime()
 
 	// Create and run the Bubble Tea program for styled summary
 	model := endModel{
-		entry:         &entries[idx],
-		date:          now,
+		entry:         &entry,
+		date:          entry.StartTime,
 		totalWorkTime: totalWorkTime,
-		validationErr: validationErr,
 	}
 
 	p := tea.NewProgram(&model)
@@ -181,37 +202,67 @@ func init() {
 	rootCmd.AddCommand(endCmd)
 }
 
-func validateEntry(entry *journal.JournalEntry) error {
-	minWorkTime, err := time.ParseDuration(viper.GetString("minWorkTime"))
-	if err != nil {
-		return fmt.Errorf("invalid minimum work time format in config: %v", err)
+// validateEntry checks an ended entry against the schedule of its weekday and
+// the configured labor rules, and records the problems it finds as the
+// findings of the entry. Rules that span several days read the days before
+// entry from store. An entry with an open session is left as it is.
+func validateEntry(store journal.Store, entry *journal.JournalEntry, now time.Time) error {
+	if entry.OpenSession() != nil {
+		return nil
+	}
+	if len(entry.WorkSessions()) == 0 {
+		entry.UpdateFindings(nil, now)
+		return nil
 	}
-	lunchTime, err := time.ParseDuration(viper.GetString("lunchTime"))
+
+	schedule, err := loadSchedule()
 	if err != nil {
-		return fmt.Errorf("invalid lunch time format in config: %v", err)
+		return err
 	}
-	maxWorkTime, err := time.ParseDuration(viper.GetString("maxWorkTime"))
+	violations := scheduleViolations(schedule.For(entry.StartTime), entry)
+	ruleViolations, err := checkEntryRules(store, entry)
 	if err != nil {
-		return fmt.Errorf("invalid maximum work time format in config: %v", err)
+		return err
+	}
+	entry.UpdateFindings(append(violations, ruleViolations...), now)
+	return nil
+}
+
+// scheduleViolations checks entry against the schedule of its day: the
+// minimum and maximum work time, and a break long enough for lunch. Days off,
+// either in the schedule or marked with a day type, only have the maximum
+// checked.
+func scheduleViolations(day daySchedule, entry *journal.JournalEntry) []journal.Violation {
+	minWorkTime, lunchTime, maxWorkTime := day.MinWorkTime, day.LunchTime, day.MaxWorkTime
+	if entry.IsDayOff() {
+		minWorkTime = 0
+	}
+
+	var violations []journal.Violation
+	violation := func(code, message string) {
+		violations = append(violations, journal.Violation{Day: entry.ID, Rule: code, Severity: journal.SeverityWarning, Message: message})
 	}
 
 	totalWorkTime := entry.TotalWorkTime()
 
 	// Check if total work time (accounting for breaks) is less than minimum
 	if totalWorkTime < minWorkTime {
-		return fmt.Errorf("total work time (%s) is less than the minimum required (%s)", totalWorkTime.String(), minWorkTime.String())
+		violation("min_work_time", fmt.Sprintf("total work time (%s) is less than the minimum required (%s)", totalWorkTime.String(), minWorkTime.String()))
 	}
 	// Check if total work time (accounting for breaks) above allowed maximum
 	if totalWorkTime > maxWorkTime {
-		return fmt.Errorf("total work time (%s) exceeds the maximum allowed (%s) by %s", totalWorkTime.String(), maxWorkTime.String(), totalWorkTime-maxWorkTime)
+		violation("max_work_time", fmt.Sprintf("total work time (%s) exceeds the maximum allowed (%s) by %s", totalWorkTime.String(), maxWorkTime.String(), totalWorkTime-maxWorkTime))
+	}
+	if minWorkTime == 0 {
+		return violations
 	}
 
 	// Check if there's at least one break of `lunchtime` duration
 	for _, br := range entry.Breaks {
 		if br.Duration() >= lunchTime {
-			return nil
+			return violations
 		}
 	}
-	// If not, return an error
-	return fmt.Errorf("did not find any breaks during the day that have at least (%s) for lunchtime break.", lunchTime)
+	violation("lunch_break", fmt.Sprintf("did not find any breaks during the day that have at least (%s) for lunchtime break.", lunchTime))
+	return violations
 }
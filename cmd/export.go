@@ -1,16 +1,13 @@
 package cmd
 
 import (
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"os"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // ExportData represents the structure for JSON exports
@@ -31,13 +28,18 @@ type ExportSummary struct {
 var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export workday data in various formats",
-	Long:  "Export workday data to JSON or CSV formats with filtering options",
+	Long: `Export workday data with filtering options, in one of these formats:
+  json      the data as JSON
+  csv       one row per day or per break
+  markdown  a daily log with the times, breaks and notes of every day
+  html      a self-contained HTML report
+  ics       an iCalendar file with an event for every work session and break`,
 }
 
 var exportBreaksCmd = &cobra.Command{
 	Use:   "breaks",
 	Short: "Export break data",
-	Long:  "Export break data to JSON or CSV format",
+	Long:  "Export break data in any of the export formats, e.g. CSV or iCalendar",
 	RunE:  exportBreaks,
 }
 
@@ -54,8 +56,7 @@ func exportBreaks(cmd *cobra.Command, args []string) error {
 	dateFilter, _ := cmd.Flags().GetString("date")
 	last, _ := cmd.Flags().GetInt("last")
 
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
+	entries, err := loadEntries()
 	if err != nil {
 		return err
 	}
@@ -67,7 +68,12 @@ func exportBreaks(cmd *cobra.Command, args []string) error {
 	}
 
 	// Extract breaks from filtered entries
-	var allBreaks []BreakExportData
+	set := ExportSet{
+		Kind:        exportKindBreaks,
+		GeneratedAt: time.Now(),
+		DateRange:   dateRange,
+		Entries:     filteredEntries,
+	}
 	for _, entry := range filteredEntries {
 		for i, br := range entry.Breaks {
 			breakData := BreakExportData{
@@ -82,13 +88,16 @@ func exportBreaks(cmd *cobra.Command, args []string) error {
 			if !br.EndTime.IsZero() {
 				breakData.EndTime = br.EndTime.Format("15:04:05")
 				breakData.Duration = br.Duration().String()
+				set.Summary.TotalBreakTime += br.Duration()
 			}
 			
-			allBreaks = append(allBreaks, breakData)
+			set.Breaks = append(set.Breaks, breakData)
 		}
 	}
+	set.Summary.TotalEntries = len(filteredEntries)
+	set.Summary.TotalBreaks = len(set.Breaks)
 
-	return exportData(allBreaks, format, output, "breaks", dateRange)
+	return exportData(set, format, output)
 }
 
 func exportTimesheet(cmd *cobra.Command, args []string) error {
@@ -97,8 +106,7 @@ func exportTimesheet(cmd *cobra.Command, args []string) error {
 	dateFilter, _ := cmd.Flags().GetString("date")
 	last, _ := cmd.Flags().GetInt("last")
 
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
+	entries, err := loadEntries()
 	if err != nil {
 		return err
 	}
@@ -128,39 +136,41 @@ func exportTimesheet(cmd *cobra.Command, args []string) error {
 		totalBreaks += len(entry.Breaks)
 
 		timesheetEntry := TimesheetExportData{
-			Date:          entry.StartTime.Format("2006-01-02"),
-			StartTime:     entry.StartTime.Format("15:04:05"),
-			EndTime:       "",
-			WorkTime:      workTime.String(),
-			BreakTime:     breakTime.String(),
-			NumberBreaks:  len(entry.Breaks),
-			Notes:         len(entry.Notes),
+			Date:           entry.StartTime.Format("2006-01-02"),
+			DayType:        string(journal.DayWork),
+			StartTime:      entry.StartTime.Format("15:04:05"),
+			EndTime:        "",
+			WorkTime:       workTime.String(),
+			BreakTime:      breakTime.String(),
+			NumberSessions: len(entry.WorkSessions()),
+			NumberBreaks:   len(entry.Breaks),
+			Notes:          len(entry.Notes),
 		}
 
 		if !entry.EndTime.IsZero() {
 			timesheetEntry.EndTime = entry.EndTime.Format("15:04:05")
 		}
+		if entry.IsDayOff() {
+			timesheetEntry.DayType = string(entry.DayType)
+		}
 
 		timesheetData = append(timesheetData, timesheetEntry)
 	}
 
-	// Add summary if JSON format
-	if format == "json" {
-		exportDataStruct := ExportData{
-			GeneratedAt: time.Now(),
-			DateRange:   dateRange,
-			Entries:     filteredEntries,
-			Summary: ExportSummary{
-				TotalEntries:   len(filteredEntries),
-				TotalWorkTime:  totalWorkTime,
-				TotalBreakTime: totalBreakTime,
-				TotalBreaks:    totalBreaks,
-			},
-		}
-		return exportData(exportDataStruct, format, output, "timesheet", dateRange)
+	set := ExportSet{
+		Kind:        exportKindTimesheet,
+		GeneratedAt: time.Now(),
+		DateRange:   dateRange,
+		Entries:     filteredEntries,
+		Timesheet:   timesheetData,
+		Summary: ExportSummary{
+			TotalEntries:   len(filteredEntries),
+			TotalWorkTime:  totalWorkTime,
+			TotalBreakTime: totalBreakTime,
+			TotalBreaks:    totalBreaks,
+		},
 	}
-
-	return exportData(timesheetData, format, output, "timesheet", dateRange)
+	return exportData(set, format, output)
 }
 
 type BreakExportData struct {
@@ -173,13 +183,15 @@ type BreakExportData struct {
 }
 
 type TimesheetExportData struct {
-	Date         string `json:"date" csv:"Date"`
-	StartTime    string `json:"start_time" csv:"Start Time"`
-	EndTime      string `json:"end_time" csv:"End Time"`
-	WorkTime     string `json:"work_time" csv:"Work Time"`
-	BreakTime    string `json:"break_time" csv:"Break Time"`
-	NumberBreaks int    `json:"number_breaks" csv:"Number of Breaks"`
-	Notes        int    `json:"notes" csv:"Number of Notes"`
+	Date           string `json:"date" csv:"Date"`
+	DayType        string `json:"day_type" csv:"Day Type"`
+	StartTime      string `json:"start_time" csv:"Start Time"`
+	EndTime        string `json:"end_time" csv:"End Time"`
+	WorkTime       string `json:"work_time" csv:"Work Time"`
+	BreakTime      string `json:"break_time" csv:"Break Time"`
+	NumberSessions int    `json:"number_sessions" csv:"Number of Sessions"`
+	NumberBreaks   int    `json:"number_breaks" csv:"Number of Breaks"`
+	Notes          int    `json:"notes" csv:"Number of Notes"`
 }
 
 func filterEntries(entries []journal.JournalEntry, dateFilter string, last int) ([]journal.JournalEntry, string, error) {
@@ -203,7 +215,7 @@ func filterEntries(entries []journal.JournalEntry, dateFilter string, last int)
 		dateRange = dateFilter
 	} else if last > 0 {
 		// Get last N days
-		now := time.Now()
+		now := currentTime()
 		cutoff := now.AddDate(0, 0, -last)
 		
 		for _, entry := range entries {
@@ -221,13 +233,18 @@ func filterEntries(entries []journal.JournalEntry, dateFilter string, last int)
 	return filteredEntries, dateRange, nil
 }
 
-func exportData(data interface{}, format, output, dataType, dateRange string) error {
-	var filename string
-	if output != "" {
-		filename = output
-	} else {
+// exportData writes set in format to output, or to a file named after the
+// kind of data and the current time when output is empty.
+func exportData(set ExportSet, format, output string) error {
+	exporter, err := lookupExporter(format)
+	if err != nil {
+		return err
+	}
+
+	filename := output
+	if filename == "" {
 		timestamp := time.Now().Format("20060102_150405")
-		filename = fmt.Sprintf("workday_%s_%s.%s", dataType, timestamp, format)
+		filename = fmt.Sprintf("workday_%s_%s.%s", set.Kind, timestamp, exporter.Extension())
 	}
 
 	file, err := os.Create(filename)
@@ -236,65 +253,11 @@ func exportData(data interface{}, format, output, dataType, dateRange string) er
 	}
 	defer file.Close()
 
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(file)
-		encoder.SetIndent("", "  ")
-		err = encoder.Encode(data)
-	case "csv":
-		err = writeCSV(file, data)
-	default:
-		return fmt.Errorf("unsupported format: %s. Use 'json' or 'csv'", format)
-	}
-
-	if err != nil {
+	if err := exporter.Export(file, set); err != nil {
 		return fmt.Errorf("failed to write data: %v", err)
 	}
 
-	fmt.Printf("✅ Exported %s data (%s) to %s\n", dataType, dateRange, filename)
-	return nil
-}
-
-func writeCSV(file *os.File, data interface{}) error {
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	switch v := data.(type) {
-	case []BreakExportData:
-		// Write header
-		writer.Write([]string{"Date", "Break ID", "Start Time", "End Time", "Duration", "Reason"})
-		
-		// Write rows
-		for _, break_ := range v {
-			writer.Write([]string{
-				break_.Date,
-				strconv.Itoa(break_.BreakID),
-				break_.StartTime,
-				break_.EndTime,
-				break_.Duration,
-				break_.Reason,
-			})
-		}
-	case []TimesheetExportData:
-		// Write header
-		writer.Write([]string{"Date", "Start Time", "End Time", "Work Time", "Break Time", "Number of Breaks", "Number of Notes"})
-		
-		// Write rows
-		for _, entry := range v {
-			writer.Write([]string{
-				entry.Date,
-				entry.StartTime,
-				entry.EndTime,
-				entry.WorkTime,
-				entry.BreakTime,
-				strconv.Itoa(entry.NumberBreaks),
-				strconv.Itoa(entry.Notes),
-			})
-		}
-	default:
-		return fmt.Errorf("unsupported data type for CSV export")
-	}
-
+	fmt.Printf("✅ Exported %s data (%s) to %s\n", set.Kind, set.DateRange, filename)
 	return nil
 }
 
@@ -305,7 +268,7 @@ func init() {
 
 	// Common flags for export commands
 	for _, cmd := range []*cobra.Command{exportBreaksCmd, exportTimesheetCmd} {
-		cmd.Flags().StringP("format", "f", "json", "Export format (json or csv)")
+		cmd.Flags().StringP("format", "f", "json", "Export format ("+strings.Join(exportFormats(), ", ")+")")
 		cmd.Flags().StringP("output", "o", "", "Output filename (default: auto-generated)")
 		cmd.Flags().StringP("date", "d", "", "Specific date to export (YYYY-MM-DD)")
 		cmd.Flags().IntP("last", "l", 0, "Export last N days")
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+// htmlExporter writes a self-contained HTML report, with its styles inline so
+// the file can be mailed or opened without anything else.
+type htmlExporter struct{}
+
+func (htmlExporter) Extension() string { return "html" }
+
+// htmlDay is one day of the HTML timesheet.
+type htmlDay struct {
+	Row    TimesheetExportData
+	Breaks []string
+	Notes  []journal.Note
+}
+
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Workday {{.Title}} - {{.Set.DateRange}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em auto; max-width: 60em; color: #222; }
+h1 { color: #7d56f4; }
+table { border-collapse: collapse; width: 100%; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+th { background: #7d56f4; color: #fff; }
+tr:nth-child(even) td { background: #f6f4fe; }
+.meta, .summary { color: #666; }
+.tag { background: #eee; border-radius: 0.3em; padding: 0 0.3em; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>Workday {{.Title}}</h1>
+<p class="meta">{{.Set.DateRange}}, generated {{.Set.GeneratedAt.Format "2006-01-02 15:04"}}</p>
+{{- if .Timesheet}}
+<table>
+<tr><th>Date</th><th>Day Type</th><th>Start</th><th>End</th><th>Work Time</th><th>Break Time</th><th>Sessions</th></tr>
+{{- range .Days}}
+<tr><td>{{.Row.Date}}</td><td>{{.Row.DayType}}</td><td>{{.Row.StartTime}}</td><td>{{.Row.EndTime}}</td><td>{{.Row.WorkTime}}</td><td>{{.Row.BreakTime}}</td><td>{{.Row.NumberSessions}}</td></tr>
+{{- end}}
+</table>
+<p class="summary">Total: {{.Total}} across {{.Set.Summary.TotalEntries}} days, {{.Set.Summary.TotalBreaks}} breaks</p>
+{{- range .Days}}
+{{- if or .Breaks .Notes}}
+<h2>{{.Row.Date}}</h2>
+<ul>
+{{- range .Breaks}}
+<li>Break: {{.}}</li>
+{{- end}}
+{{- range .Notes}}
+<li>{{.Contents}}{{range .Tags}} <span class="tag">#{{.}}</span>{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+{{- else}}
+<table>
+<tr><th>Date</th><th>#</th><th>Start</th><th>End</th><th>Duration</th><th>Reason</th></tr>
+{{- range .Set.Breaks}}
+<tr><td>{{.Date}}</td><td>{{.BreakID}}</td><td>{{.StartTime}}</td><td>{{.EndTime}}</td><td>{{.Duration}}</td><td>{{.Reason}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+func (htmlExporter) Export(w io.Writer, set ExportSet) error {
+	data := struct {
+		Title     string
+		Set       ExportSet
+		Timesheet bool
+		Days      []htmlDay
+		Total     string
+	}{
+		Title:     exportTitle(set.Kind),
+		Set:       set,
+		Timesheet: set.Kind == exportKindTimesheet,
+		Total:     formatDuration(set.Summary.TotalWorkTime),
+	}
+
+	if data.Timesheet {
+		for i, entry := range set.Entries {
+			day := htmlDay{Row: set.Timesheet[i]}
+			for _, br := range entry.Breaks {
+				day.Breaks = append(day.Breaks, describeBreak(br, entry.StartTime))
+			}
+			day.Notes = entry.Notes
+			data.Days = append(data.Days, day)
+		}
+	}
+	return htmlReport.Execute(w, data)
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+// icsExporter writes an iCalendar file with an event for every work session
+// and every break, so the days can be laid over a calendar. Sessions and
+// breaks that are still open have no end yet and are left out.
+type icsExporter struct{}
+
+func (icsExporter) Extension() string { return "ics" }
+
+// icsEvent is one VEVENT of the export.
+type icsEvent struct {
+	uid         string
+	start, end  time.Time
+	summary     string
+	description string
+}
+
+func (icsExporter) Export(w io.Writer, set ExportSet) error {
+	var events []icsEvent
+	for _, entry := range set.Entries {
+		if set.Kind == exportKindTimesheet {
+			// Notes keep their tags inline, so 'workday import' can read them back
+			var notes []string
+			for _, note := range entry.Notes {
+				notes = append(notes, journal.FormatNoteWithTags(note.Contents, note.Tags))
+			}
+			for i, session := range entry.WorkSessions() {
+				if session.IsOpen() {
+					continue
+				}
+				events = append(events, icsEvent{
+					uid:         fmt.Sprintf("%s-session-%d@workday", entry.ID, i+1),
+					start:       session.StartTime,
+					end:         session.EndTime,
+					summary:     "Work",
+					description: strings.Join(notes, "\n"),
+				})
+			}
+		}
+		for i, br := range entry.Breaks {
+			if br.EndTime.IsZero() {
+				continue
+			}
+			summary := "Break"
+			if br.Reason != "" {
+				summary += ": " + br.Reason
+			}
+			events = append(events, icsEvent{
+				uid:     fmt.Sprintf("%s-break-%d@workday", entry.ID, i+1),
+				start:   br.StartTime,
+				end:     br.EndTime,
+				summary: summary,
+			})
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	writeICSLine(out, "BEGIN:VCALENDAR")
+	writeICSLine(out, "VERSION:2.0")
+	writeICSLine(out, "PRODID:-//deadpyxel//workday//EN")
+	writeICSLine(out, "CALSCALE:GREGORIAN")
+	for _, event := range events {
+		writeICSLine(out, "BEGIN:VEVENT")
+		writeICSLine(out, "UID:"+event.uid)
+		writeICSLine(out, "DTSTAMP:"+formatICSTime(set.GeneratedAt))
+		writeICSLine(out, "DTSTART:"+formatICSTime(event.start))
+		writeICSLine(out, "DTEND:"+formatICSTime(event.end))
+		writeICSLine(out, "SUMMARY:"+escapeICSText(event.summary))
+		if event.description != "" {
+			writeICSLine(out, "DESCRIPTION:"+escapeICSText(event.description))
+		}
+		writeICSLine(out, "END:VEVENT")
+	}
+	writeICSLine(out, "END:VCALENDAR")
+	return out.Flush()
+}
+
+// formatICSTime formats t as an iCalendar UTC date-time, e.g. 20260407T090000Z.
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeICSText escapes a value of the iCalendar TEXT type.
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// writeICSLine writes a content line, folded into lines of at most 75 bytes
+// as RFC 5545 asks, without splitting a UTF-8 character.
+func writeICSLine(out *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		out.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = 74
+	}
+	out.WriteString(line + "\r\n")
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+// markdownExporter writes a daily log with the times, breaks and notes of
+// every day, or a table of the breaks.
+type markdownExporter struct{}
+
+func (markdownExporter) Extension() string { return "md" }
+
+func (markdownExporter) Export(w io.Writer, set ExportSet) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "# Workday %s\n\n", exportTitle(set.Kind))
+	fmt.Fprintf(out, "%s, generated %s\n\n", set.DateRange, set.GeneratedAt.Format("2006-01-02 15:04"))
+
+	if set.Kind == exportKindBreaks {
+		fmt.Fprintln(out, "| Date | # | Start | End | Duration | Reason |")
+		fmt.Fprintln(out, "|------|---|-------|-----|----------|--------|")
+		for _, br := range set.Breaks {
+			fmt.Fprintf(out, "| %s | %d | %s | %s | %s | %s |\n",
+				br.Date, br.BreakID, br.StartTime, orDash(br.EndTime), orDash(br.Duration), escapeMarkdownCell(br.Reason))
+		}
+		return out.Flush()
+	}
+
+	for _, entry := range set.Entries {
+		fmt.Fprintf(out, "## %s\n\n", entry.StartTime.Format("Mon, Jan 2 2006"))
+		if entry.IsDayOff() {
+			fmt.Fprintf(out, "- **Day off:** %s\n", dayOffLabel(entry))
+		}
+		if sessions := entry.WorkSessions(); len(sessions) > 0 {
+			worked := "in progress"
+			if !entry.EndTime.IsZero() {
+				worked = formatDuration(entry.TotalWorkTime())
+			}
+			fmt.Fprintf(out, "- **Worked:** %s (%s)\n", formatSessions(sessions, entry.StartTime), worked)
+		}
+		for _, br := range entry.Breaks {
+			fmt.Fprintf(out, "- **Break:** %s\n", describeBreak(br, entry.StartTime))
+		}
+		if len(entry.Notes) > 0 {
+			fmt.Fprintln(out, "\n### Notes")
+			fmt.Fprintln(out)
+			for _, note := range entry.Notes {
+				fmt.Fprintf(out, "- %s%s\n", note.Contents, markdownTags(note.Tags))
+			}
+		}
+		fmt.Fprintln(out)
+	}
+
+	fmt.Fprintf(out, "---\n\n**Total:** %s across %d days, %d breaks (%s)\n",
+		formatDuration(set.Summary.TotalWorkTime), set.Summary.TotalEntries, set.Summary.TotalBreaks, formatDuration(set.Summary.TotalBreakTime))
+	return out.Flush()
+}
+
+// exportTitle names the kind of an export for the document formats.
+func exportTitle(kind string) string {
+	if kind == exportKindBreaks {
+		return "Breaks"
+	}
+	return "Timesheet"
+}
+
+// describeBreak renders a break as "12:00-12:45 (45m) lunch", with the clock
+// times relative to day.
+func describeBreak(br journal.Break, day time.Time) string {
+	if br.EndTime.IsZero() {
+		return fmt.Sprintf("%s-ongoing %s", journal.FormatClockTime(br.StartTime, day), br.Reason)
+	}
+	return fmt.Sprintf("%s-%s (%s) %s", journal.FormatClockTime(br.StartTime, day),
+		journal.FormatClockTime(br.EndTime, day), formatDuration(br.Duration()), br.Reason)
+}
+
+// markdownTags renders tags as inline code, e.g. " `#backend` `#review`".
+func markdownTags(tags []string) string {
+	var b strings.Builder
+	for _, tag := range tags {
+		b.WriteString(" `#" + tag + "`")
+	}
+	return b.String()
+}
+
+// escapeMarkdownCell keeps s from breaking out of a table cell.
+func escapeMarkdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "--"
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+// Kinds of data the export commands write
+const (
+	exportKindBreaks    = "breaks"
+	exportKindTimesheet = "timesheet"
+)
+
+// ExportSet is the data of one export: the entries picked by the filter
+// flags, and the rows of the export command built from them.
+type ExportSet struct {
+	Kind        string // exportKindBreaks or exportKindTimesheet
+	GeneratedAt time.Time
+	DateRange   string
+	Entries     []journal.JournalEntry
+	Breaks      []BreakExportData
+	Timesheet   []TimesheetExportData
+	Summary     ExportSummary
+}
+
+// Exporter writes an export set in one file format.
+type Exporter interface {
+	// Extension is the file extension of the format, without the dot.
+	Extension() string
+
+	// Export writes set to w.
+	Export(w io.Writer, set ExportSet) error
+}
+
+// exporters is the registry of formats the export commands can write.
+var exporters = map[string]Exporter{
+	"json":     jsonExporter{},
+	"csv":      csvExporter{},
+	"markdown": markdownExporter{},
+	"html":     htmlExporter{},
+	"ics":      icsExporter{},
+}
+
+// RegisterExporter makes a format available to the export commands.
+// Registering a name twice replaces the earlier exporter.
+func RegisterExporter(name string, exporter Exporter) {
+	exporters[name] = exporter
+}
+
+// exportFormats returns the names of the registered formats, sorted.
+func exportFormats() []string {
+	names := make([]string, 0, len(exporters))
+	for name := range exporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupExporter returns the exporter registered for format.
+func lookupExporter(format string) (Exporter, error) {
+	exporter, ok := exporters[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format: %s. Use one of %s", format, strings.Join(exportFormats(), ", "))
+	}
+	return exporter, nil
+}
+
+// jsonExporter writes the break rows, or the full entries with a summary for
+// timesheets.
+type jsonExporter struct{}
+
+func (jsonExporter) Extension() string { return "json" }
+
+func (jsonExporter) Export(w io.Writer, set ExportSet) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if set.Kind == exportKindBreaks {
+		return encoder.Encode(set.Breaks)
+	}
+	return encoder.Encode(ExportData{
+		GeneratedAt: set.GeneratedAt,
+		DateRange:   set.DateRange,
+		Entries:     set.Entries,
+		Summary:     set.Summary,
+	})
+}
+
+// csvExporter writes one row per break or per day.
+type csvExporter struct{}
+
+func (csvExporter) Extension() string { return "csv" }
+
+func (csvExporter) Export(w io.Writer, set ExportSet) error {
+	writer := csv.NewWriter(w)
+
+	if set.Kind == exportKindBreaks {
+		writer.Write([]string{"Date", "Break ID", "Start Time", "End Time", "Duration", "Reason"})
+		for _, break_ := range set.Breaks {
+			writer.Write([]string{
+				break_.Date,
+				strconv.Itoa(break_.BreakID),
+				break_.StartTime,
+				break_.EndTime,
+				break_.Duration,
+				break_.Reason,
+			})
+		}
+	} else {
+		writer.Write([]string{"Date", "Day Type", "Start Time", "End Time", "Work Time", "Break Time", "Number of Sessions", "Number of Breaks", "Number of Notes"})
+		for _, entry := range set.Timesheet {
+			writer.Write([]string{
+				entry.Date,
+				entry.DayType,
+				entry.StartTime,
+				entry.EndTime,
+				entry.WorkTime,
+				entry.BreakTime,
+				strconv.Itoa(entry.NumberSessions),
+				strconv.Itoa(entry.NumberBreaks),
+				strconv.Itoa(entry.Notes),
+			})
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
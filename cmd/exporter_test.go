@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+// testExportSet is a timesheet of one day with two sessions, a break and a
+// tagged note.
+func testExportSet(kind string) ExportSet {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 4, 7, hour, minute, 0, 0, time.UTC)
+	}
+	entry := journal.JournalEntry{
+		ID:        "20260407",
+		StartTime: at(8, 0),
+		EndTime:   at(22, 0),
+		Sessions:  []journal.Session{{StartTime: at(8, 0), EndTime: at(12, 0)}, {StartTime: at(20, 0), EndTime: at(22, 0)}},
+		Breaks:    []journal.Break{{StartTime: at(10, 0), EndTime: at(10, 15), Reason: "coffee, black"}},
+		Notes:     []journal.Note{{Contents: "Reviewed the parser", Tags: []string{"review"}}},
+	}
+	return ExportSet{
+		Kind:        kind,
+		GeneratedAt: at(23, 0),
+		DateRange:   "2026-04-07",
+		Entries:     []journal.JournalEntry{entry},
+		Breaks:      []BreakExportData{{Date: "2026-04-07", BreakID: 1, StartTime: "10:00:00", EndTime: "10:15:00", Duration: "15m0s", Reason: "coffee, black"}},
+		Timesheet:   []TimesheetExportData{{Date: "2026-04-07", DayType: "work", StartTime: "08:00:00", EndTime: "22:00:00", WorkTime: "5h45m0s", NumberSessions: 2, NumberBreaks: 1, Notes: 1}},
+		Summary:     ExportSummary{TotalEntries: 1, TotalWorkTime: 5*time.Hour + 45*time.Minute, TotalBreaks: 1, TotalBreakTime: 15 * time.Minute},
+	}
+}
+
+func TestExporters(t *testing.T) {
+	tests := []struct {
+		format string
+		kind   string
+		want   []string
+		count  map[string]int
+	}{
+		{format: "csv", kind: exportKindBreaks, want: []string{"Date,Break ID,Start Time", `2026-04-07,1,10:00:00,10:15:00,15m0s,"coffee, black"`}},
+		{format: "json", kind: exportKindTimesheet, want: []string{`"date_range": "2026-04-07"`, `"total_entries": 1`}},
+		{format: "markdown", kind: exportKindTimesheet, want: []string{"## Tue, Apr 7 2026", "08:00-12:00, 20:00-22:00", "- Reviewed the parser `#review`", "**Break:** 10:00-10:15 (15m) coffee, black"}},
+		{format: "markdown", kind: exportKindBreaks, want: []string{"| 2026-04-07 | 1 | 10:00:00 | 10:15:00 | 15m0s | coffee, black |"}},
+		{format: "html", kind: exportKindTimesheet, want: []string{"<style>", "<td>5h45m0s</td>", `<span class="tag">#review</span>`}},
+		{
+			format: "ics",
+			kind:   exportKindTimesheet,
+			want:   []string{"DTSTART:20260407T080000Z\r\n", "DTEND:20260407T220000Z\r\n", `SUMMARY:Break: coffee\, black`, "DESCRIPTION:Reviewed the parser #review"},
+			count:  map[string]int{"BEGIN:VEVENT": 3},
+		},
+		{format: "ics", kind: exportKindBreaks, count: map[string]int{"BEGIN:VEVENT": 1, "SUMMARY:Work": 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+"/"+tt.kind, func(t *testing.T) {
+			exporter, err := lookupExporter(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out bytes.Buffer
+			if err := exporter.Export(&out, testExportSet(tt.kind)); err != nil {
+				t.Fatalf("Export() error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("expected %q in the output:\n%s", want, out.String())
+				}
+			}
+			for s, n := range tt.count {
+				if got := strings.Count(out.String(), s); got != n {
+					t.Errorf("expected %d times %q, got %d", n, s, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterExporter(t *testing.T) {
+	if _, err := lookupExporter("yaml"); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+
+	RegisterExporter("yaml", yamlTestExporter{})
+	defer delete(exporters, "yaml")
+
+	exporter, err := lookupExporter("YAML")
+	if err != nil || exporter.Extension() != "yaml" {
+		t.Errorf("lookupExporter() = %v, %v, want the registered exporter", exporter, err)
+	}
+}
+
+type yamlTestExporter struct{}
+
+func (yamlTestExporter) Extension() string                     { return "yaml" }
+func (yamlTestExporter) Export(w io.Writer, _ ExportSet) error { return nil }
+
+func TestWriteICSLineFolds(t *testing.T) {
+	var out bytes.Buffer
+	line := "DESCRIPTION:" + strings.Repeat("é", 60)
+	w := bufio.NewWriter(&out)
+	writeICSLine(w, line)
+	w.Flush()
+
+	for _, physical := range strings.Split(strings.TrimSuffix(out.String(), "\r\n"), "\r\n") {
+		if len(physical) > 75 {
+			t.Errorf("line of %d bytes: %q", len(physical), physical)
+		}
+	}
+	if unfolded := strings.ReplaceAll(strings.TrimSuffix(out.String(), "\r\n"), "\r\n ", ""); unfolded != line {
+		t.Errorf("unfolded line = %q, want %q", unfolded, line)
+	}
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+// Policies for days of an import that are already in the journal
+const (
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+	conflictMerge     = "merge"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Imports days from CSV or iCalendar files",
+	Long: `The import command adds the days of a file to the journal. It reads:
+
+  - the CSV files written by 'workday export timesheet' and 'workday export breaks'
+  - CSV time entries of time trackers such as Toggl or Clockify, with Start Date,
+    Start Time, End Date and End Time columns; each entry is also tracked as a
+    time segment of its project and task
+  - iCalendar (.ics) files, such as those written with 'workday export --format ics';
+    events named "Break: <reason>" become breaks and the others work
+
+Imported days are validated like backfilled ones, and checked against the
+schedule and the labor rules. --conflict decides what happens to days that are
+already in the journal: skip leaves them alone, overwrite replaces them and
+merge adds the sessions, breaks, notes and segments they do not have yet. A
+breaks export has no work of its own, so it can only be merged into days that
+already exist.
+
+Examples:
+  workday import timesheet_20260401_120000.csv --dry-run
+  workday import toggl.csv --conflict merge
+  workday import calendar.ics --conflict overwrite`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+// importResult is what importing one day did.
+type importResult struct {
+	Day      journal.ImportedDay
+	Action   string // "added", "replaced", "merged", "skipped" or "failed"
+	Err      error
+	Findings []journal.Finding // of the day as imported
+}
+
+// importDays adds days to the store under the given conflict policy, in a
+// single write once every day is imported. Each day that is added or changed
+// is checked with validateEntry against the journal as the import leaves it,
+// so problems with the schedule or the rules, including those spanning
+// several imported days, are recorded as findings. A day that cannot be
+// imported is reported as failed without stopping the others. With dryRun set
+// the same checks are made and nothing is saved.
+func importDays(store journal.Store, days []journal.ImportedDay, policy string, dryRun bool) ([]importResult, error) {
+	batch := journal.NewBatch(store)
+	var results []importResult
+	for _, day := range days {
+		existing, err := batch.Get(day.ID())
+		if err != nil && !errors.Is(err, journal.ErrEntryNotFound) {
+			return nil, err
+		}
+
+		var entry *journal.JournalEntry
+		action := "added"
+		switch {
+		case existing == nil:
+			entry, err = journal.BuildImportedEntry(day)
+		case policy == conflictSkip:
+			results = append(results, importResult{Day: day, Action: "skipped"})
+			continue
+		case policy == conflictOverwrite:
+			action = "replaced"
+			entry, err = journal.BuildImportedEntry(day)
+		default:
+			action = "merged"
+			entry = existing
+			err = entry.MergeImported(day)
+		}
+		if err != nil {
+			results = append(results, importResult{Day: day, Action: "failed", Err: err})
+			continue
+		}
+
+		if err := validateEntry(batch, entry, currentTime()); err != nil {
+			return nil, err
+		}
+		if err := batch.Upsert(*entry); err != nil {
+			return nil, err
+		}
+		results = append(results, importResult{Day: day, Action: action, Findings: entry.ActiveFindings()})
+	}
+
+	if !dryRun {
+		if err := batch.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to save journal entries: %v", err)
+		}
+	}
+	return results, nil
+}
+
+// printImportResults lists what importDays did with each day, and how many
+// days it did it to.
+func printImportResults(results []importResult, dryRun bool) {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Action]++
+		date := result.Day.Date.Format("Mon, Jan 2 2006")
+		switch result.Action {
+		case "failed":
+			fmt.Println(styles.ErrorStyle.Render(fmt.Sprintf("❌ %s: %v", date, result.Err)))
+		case "skipped":
+			fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("💡 %s is already in the journal, skipped", date)))
+		default:
+			fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ %s %s", date, result.Action)))
+			for _, finding := range result.Findings {
+				fmt.Println("   " + findingStyle(finding))
+			}
+		}
+	}
+
+	fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("%d added, %d replaced, %d merged, %d skipped, %d failed",
+		counts["added"], counts["replaced"], counts["merged"], counts["skipped"], counts["failed"])))
+	if dryRun {
+		fmt.Println(styles.InfoBlueStyle.Render("Dry run, nothing was saved"))
+	}
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	policy, _ := cmd.Flags().GetString("conflict")
+	switch policy {
+	case conflictSkip, conflictOverwrite, conflictMerge:
+	default:
+		return fmt.Errorf("invalid conflict policy '%s'. Use skip, overwrite or merge", policy)
+	}
+
+	loc, err := configLocation()
+	if err != nil {
+		return err
+	}
+	days, err := journal.LoadImport(args[0], loc)
+	if err != nil {
+		return err
+	}
+	if len(days) == 0 {
+		fmt.Println(styles.InfoStyle.Render("No days found in " + args[0] + ", nothing to import"))
+		return nil
+	}
+
+	var results []importResult
+	err = withStore(func(store journal.Store) error {
+		results, err = importDays(store, days, policy, dryRun)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	printImportResults(results, dryRun)
+
+	for _, result := range results {
+		if result.Action == "failed" {
+			return fmt.Errorf("some days could not be imported")
+		}
+	}
+	return nil
+}
+
+func init() {
+	importCmd.Flags().Bool("dry-run", false, "Show what would be imported without saving anything")
+	importCmd.Flags().String("conflict", conflictSkip, "What to do with days already in the journal: skip, overwrite or merge")
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/spf13/viper"
+)
+
+func TestImportDays(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 4, day, hour, 0, 0, 0, time.UTC)
+	}
+	midnight := func(day int) time.Time { return at(day, 0) }
+	existing := journal.JournalEntry{ID: "20260407", StartTime: at(7, 9), EndTime: at(7, 12),
+		Sessions: []journal.Session{{StartTime: at(7, 9), EndTime: at(7, 12)}}}
+	days := []journal.ImportedDay{
+		{Date: midnight(6), Sessions: []journal.Session{{StartTime: at(6, 9), EndTime: at(6, 17)}}},
+		{Date: midnight(7), Sessions: []journal.Session{{StartTime: at(7, 13), EndTime: at(7, 18)}}},
+		{Date: midnight(8), Breaks: []journal.Break{{StartTime: at(8, 12), EndTime: at(8, 13), Reason: "lunch"}}},
+	}
+
+	tests := []struct {
+		name        string
+		policy      string
+		dryRun      bool
+		wantActions []string
+		wantEntries int
+		wantWorked  time.Duration // of 20260407 afterwards
+	}{
+		{name: "skip", policy: conflictSkip, wantActions: []string{"added", "skipped", "failed"}, wantEntries: 2, wantWorked: 3 * time.Hour},
+		{name: "overwrite", policy: conflictOverwrite, wantActions: []string{"added", "replaced", "failed"}, wantEntries: 2, wantWorked: 5 * time.Hour},
+		{name: "merge", policy: conflictMerge, wantActions: []string{"added", "merged", "failed"}, wantEntries: 2, wantWorked: 8 * time.Hour},
+		{name: "dry run", policy: conflictMerge, dryRun: true, wantActions: []string{"added", "merged", "failed"}, wantEntries: 1, wantWorked: 3 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTempJournal(t, []journal.JournalEntry{existing})
+			setBackfillViper(t, path, "8h", "1h", "10h")
+			store := journal.NewJSONStore(path, journal.BackupPolicy{})
+
+			results, err := importDays(store, days, tt.policy, tt.dryRun)
+			if err != nil {
+				t.Fatalf("importDays() error = %v", err)
+			}
+			if len(results) != len(tt.wantActions) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.wantActions))
+			}
+			for i, result := range results {
+				if result.Action != tt.wantActions[i] {
+					t.Errorf("day %d action = %q, want %q (%v)", i, result.Action, tt.wantActions[i], result.Err)
+				}
+			}
+
+			entries, err := store.All()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != tt.wantEntries {
+				t.Errorf("got %d entries, want %d", len(entries), tt.wantEntries)
+			}
+			entry, err := store.Get("20260407")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := entry.TotalWorkTime(); got != tt.wantWorked {
+				t.Errorf("20260407 worked %v, want %v", got, tt.wantWorked)
+			}
+		})
+	}
+}
+
+func TestImportDaysRecordsFindings(t *testing.T) {
+	path := writeTempJournal(t, []journal.JournalEntry{})
+	setBackfillViper(t, path, "8h", "1h", "10h")
+	store := journal.NewJSONStore(path, journal.BackupPolicy{})
+
+	short := journal.ImportedDay{
+		Date:     time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC),
+		Sessions: []journal.Session{{StartTime: time.Date(2026, 4, 6, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 4, 6, 11, 0, 0, 0, time.UTC)}},
+	}
+	if _, err := importDays(store, []journal.ImportedDay{short}, conflictSkip, false); err != nil {
+		t.Fatalf("importDays() error = %v", err)
+	}
+	entry, err := store.Get("20260406")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entry.Findings) == 0 {
+		t.Error("expected a finding for a day below the minimum")
+	}
+}
+
+func TestImportDaysChecksTheImportedWeek(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 4, day, hour, 0, 0, 0, time.UTC)
+	}
+	path := writeTempJournal(t, []journal.JournalEntry{})
+	setBackfillViper(t, path, "0h", "0h", "10h")
+	viper.Set("rules", []interface{}{map[string]interface{}{"type": "max_weekly", "max": "12h"}})
+	store := journal.NewJSONStore(path, journal.BackupPolicy{})
+
+	// Two days of the same week, over the weekly maximum only together
+	days := []journal.ImportedDay{
+		{Date: at(6, 0), Sessions: []journal.Session{{StartTime: at(6, 9), EndTime: at(6, 17)}}},
+		{Date: at(7, 0), Sessions: []journal.Session{{StartTime: at(7, 9), EndTime: at(7, 17)}}},
+	}
+	for _, dryRun := range []bool{true, false} {
+		results, err := importDays(store, days, conflictSkip, dryRun)
+		if err != nil {
+			t.Fatalf("importDays() error = %v", err)
+		}
+		if len(results[0].Findings) != 0 || len(results[1].Findings) != 1 || results[1].Findings[0].Code != "max_weekly" {
+			t.Errorf("dry run %v: findings = %v and %v, want one max_weekly on the second day", dryRun, results[0].Findings, results[1].Findings)
+		}
+	}
+
+	entry, err := store.Get("20260407")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entry.ActiveFindings()) != 1 {
+		t.Errorf("saved findings = %v, want one max_weekly", entry.Findings)
+	}
+}
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -17,8 +20,18 @@ Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		emptyJournal := make([]journal.JournalEntry, 0)
-		return journal.SaveEntries(emptyJournal, viper.GetString("journalPath"))
+		return withStore(func(store journal.Store) error {
+			// Other backends create their empty journal when opened
+			if backend := viper.GetString("storage.backend"); backend != "" && backend != journal.BackendJSON {
+				fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("The %s backend needs no initialisation, the journal is at %s", backend, store.Location())))
+				return nil
+			}
+			emptyJournal := make([]journal.JournalEntry, 0)
+			if err := saveJournal(emptyJournal, store.Location()); err != nil {
+				return err
+			}
+			return journal.RemoveSearchIndex(store.Location())
+		})
 	},
 }
 
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/spf13/viper"
+)
+
+// withJournalLock runs fn while holding the advisory lock on the journal at
+// journalPath. Every command that loads, changes and saves the journal must do
+// so inside fn, so concurrent workday processes cannot overwrite each other's
+// changes. It waits up to the lockTimeout config value for the lock.
+func withJournalLock(journalPath string, fn func() error) error {
+	timeout := journal.DefaultLockTimeout
+	if configured := viper.GetString("lockTimeout"); configured != "" {
+		parsed, err := journal.ValidateConfigDuration(configured, "lockTimeout")
+		if err != nil {
+			return err
+		}
+		timeout = parsed
+	}
+	return journal.WithLock(journalPath, timeout, fn)
+}
@@ -1,75 +1,145 @@
 package cmd
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"time"
+	"strings"
 
 	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-type TempJournalEntry struct {
-	ID        string
-	StartTime time.Time
-	EndTime   time.Time
-	Notes     []string
-}
-
-func loadTempEntries(journalPath string) ([]TempJournalEntry, error) {
-	file, err := os.Open(journalPath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrates the journal to the latest data storage format",
+	Long: `The migrate command brings a journal written by an older version of workday
+up to the current schema version, one version at a time. Before a journal file
+is rewritten, its original contents are copied next to it as
+<journal>.v<version>.bak.
 
-	var tempEntries []TempJournalEntry
-	err = json.NewDecoder(file).Decode(&tempEntries)
-	if err != nil {
-		return nil, err
-	}
+Journals are also migrated automatically the first time they are loaded; use
+--dry-run to see what a migration would change without writing anything. With
+the monthly backend every month file is migrated, and with the SQLite backend
+its database.
 
-	return tempEntries, nil
+Examples:
+  workday migrate --dry-run
+  workday migrate`,
+	Args: cobra.NoArgs,
+	RunE: migrateJournal,
 }
 
-var migrateCmd = &cobra.Command{
-	Use:   "migrate",
-	Short: "Migrates an existing journal.json to the latest data storage format.",
-	RunE:  migrateJournal,
+// fileMigration is the outcome of migrating one journal file.
+type fileMigration struct {
+	File   string
+	Report *journal.MigrationReport
 }
 
-func migrateJournal(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	tempEntries, err := loadTempEntries(journalPath)
-	if err != nil {
-		return err
+// journalFiles returns the files that make up the journal of backend: the
+// JSON files of the journal or its months, or the SQLite database.
+func journalFiles(backend string) ([]string, error) {
+	switch backend {
+	case journal.BackendMonthly:
+		return journal.NewMonthlyStore(storeLocation(backend)).MonthFiles()
+	default:
+		path := storeLocation(backend)
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return []string{path}, nil
 	}
+}
 
-	journalEntries := make([]journal.JournalEntry, len(tempEntries))
-	for i, tempEntry := range tempEntries {
-		journalEntries[i] = journal.JournalEntry{
-			ID:        tempEntry.ID,
-			StartTime: tempEntry.StartTime,
-			EndTime:   tempEntry.EndTime,
-			Notes:     make([]journal.Note, len(tempEntry.Notes)),
+// migrateJournalFiles migrates every file of the configured backend while
+// holding the journal lock. With dryRun set nothing is written.
+func migrateJournalFiles(dryRun bool) ([]fileMigration, error) {
+	backend := viper.GetString("storage.backend")
+	var results []fileMigration
+	err := withJournalLock(storeLocation(backend), func() error {
+		files, err := journalFiles(backend)
+		if err != nil {
+			return err
+		}
+		migrate := journal.MigrateFile
+		if backend == journal.BackendSQLite {
+			migrate = journal.MigrateSQLite
 		}
-		for j, tempNote := range tempEntry.Notes {
-			journalEntries[i].Notes[j] = journal.Note{
-				Contents: tempNote,
+		for _, file := range files {
+			report, err := migrate(file, dryRun)
+			if err != nil {
+				return fmt.Errorf("%s: %w", file, err)
 			}
+			results = append(results, fileMigration{File: file, Report: report})
+		}
+		return nil
+	})
+	return results, err
+}
+
+// renderMigration describes the migration of one file, listing every change
+// when it is a dry run.
+func renderMigration(result fileMigration, dryRun bool) string {
+	var content strings.Builder
+	report := result.Report
+
+	if !report.Migrated() {
+		content.WriteString(styles.SuccessStyle.Render(fmt.Sprintf("✅ %s is already at schema version %d", result.File, report.To)))
+		content.WriteString("\n")
+		return content.String()
+	}
+
+	if !dryRun {
+		content.WriteString(styles.SuccessStyle.Render(fmt.Sprintf("✅ Migrated %s from schema version %d to %d", result.File, report.From, report.To)))
+		content.WriteString("\n")
+		content.WriteString(styles.InfoStyle.Render(fmt.Sprintf("💾 Original kept in %s", report.Backup)))
+		content.WriteString("\n")
+		return content.String()
+	}
+
+	content.WriteString(styles.InfoStyle.Render(fmt.Sprintf("📋 %s would be migrated from schema version %d to %d", result.File, report.From, report.To)))
+	content.WriteString("\n")
+	for _, step := range report.Steps {
+		content.WriteString(styles.ValueStyle.Render(fmt.Sprintf("  v%d → v%d: %s", step.From, step.To, step.Description)))
+		content.WriteString("\n")
+		for _, change := range step.Changes {
+			content.WriteString(fmt.Sprintf("    - %s\n", change))
 		}
 	}
+	return content.String()
+}
+
+func migrateJournal(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-	err = journal.SaveEntries(journalEntries, journalPath)
+	results, err := migrateJournalFiles(dryRun)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Successfully migrated %s to latest format\n", journalPath)
+	if len(results) == 0 {
+		fmt.Println(styles.InfoStyle.Render("No journal files found, nothing to migrate"))
+		return nil
+	}
+	for _, result := range results {
+		fmt.Print(renderMigration(result, dryRun))
+	}
+	if dryRun {
+		fmt.Println(styles.HelpStyle.Render("Dry run, nothing was written"))
+	}
 	return nil
 }
 
+// reportLoadMigration tells the user about a journal that was migrated as it
+// was loaded by another command.
+func reportLoadMigration(location string, report *journal.MigrationReport) {
+	fmt.Fprintln(os.Stderr, styles.InfoStyle.Render(fmt.Sprintf("💾 Migrated %s from schema version %d to %d, the original is kept in %s",
+		location, report.From, report.To, report.Backup)))
+}
+
 func init() {
+	journal.OnMigrated = reportLoadMigration
+	migrateCmd.Flags().Bool("dry-run", false, "Show what would change without writing anything")
 	rootCmd.AddCommand(migrateCmd)
 }
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+func TestMigrateJournalFiles(t *testing.T) {
+	legacy := []byte(`[{"ID":"20231002","StartTime":"2023-10-02T09:00:00Z","EndTime":"2023-10-02T17:00:00Z","Notes":["Reviewed PRs"]}]`)
+	journalPath := filepath.Join(t.TempDir(), "journal.json")
+	if err := os.WriteFile(journalPath, legacy, 0644); err != nil {
+		t.Fatal(err)
+	}
+	setStorageViper(t, journalPath, "json", "")
+
+	results, err := migrateJournalFiles(true)
+	if err != nil {
+		t.Fatalf("migrateJournalFiles() dry run error = %v", err)
+	}
+	if len(results) != 1 || !results[0].Report.Migrated() {
+		t.Fatalf("expected one file to need migrating, got %+v", results)
+	}
+	if out := renderMigration(results[0], true); !strings.Contains(out, "1 plain-text notes") {
+		t.Errorf("dry run output does not list the note conversion:\n%s", out)
+	}
+	if data, _ := os.ReadFile(journalPath); !bytes.Equal(data, legacy) {
+		t.Error("dry run modified the journal")
+	}
+
+	results, err = migrateJournalFiles(false)
+	if err != nil {
+		t.Fatalf("migrateJournalFiles() error = %v", err)
+	}
+	if backup := results[0].Report.Backup; backup != journal.MigrationBackupFilename(journalPath, 0) {
+		t.Errorf("backup = %q, want the v0 migration backup", backup)
+	}
+
+	entries, err := loadEntries()
+	if err != nil {
+		t.Fatalf("loadEntries() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Notes[0].Contents != "Reviewed PRs" {
+		t.Errorf("unexpected entries after migration: %+v", entries)
+	}
+
+	results, err = migrateJournalFiles(false)
+	if err != nil || results[0].Report.Migrated() {
+		t.Errorf("expected the migrated journal to be current, got %+v, %v", results, err)
+	}
+}
+
+func TestMigrateJournalFilesMonthly(t *testing.T) {
+	dir := t.TempDir()
+	monthDir := filepath.Join(dir, "journal", "2023")
+	if err := os.MkdirAll(monthDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, month := range []string{"10", "11"} {
+		data := []byte(`[{"id":"2023` + month + `02","notes":[{"Contents":"work"}]}]`)
+		if err := os.WriteFile(filepath.Join(monthDir, month+".json"), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	setStorageViper(t, filepath.Join(dir, "journal.json"), "monthly", "")
+
+	results, err := migrateJournalFiles(false)
+	if err != nil {
+		t.Fatalf("migrateJournalFiles() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected both month files to be migrated, got %+v", results)
+	}
+	for _, result := range results {
+		if !result.Report.Migrated() {
+			t.Errorf("%s was not migrated", result.File)
+		}
+	}
+}
+
+func TestMigrateJournalFilesSQLite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.sqlite")
+	setStorageViper(t, filepath.Join(t.TempDir(), "journal.json"), "sqlite", path)
+	store, err := openStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	// Rewind the database to version 0 with a row in the legacy format
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`UPDATE meta SET value = '0' WHERE key = 'schema_version';
+		INSERT INTO entries (id, data) VALUES ('20231002', '{"ID":"20231002","Notes":["legacy"]}')`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := migrateJournalFiles(true)
+	if err != nil {
+		t.Fatalf("migrateJournalFiles() dry run error = %v", err)
+	}
+	if len(results) != 1 || !results[0].Report.Migrated() {
+		t.Fatalf("expected the database to need migrating, got %+v", results)
+	}
+	if out := renderMigration(results[0], true); !strings.Contains(out, "1 plain-text notes") {
+		t.Errorf("dry run output does not list the note conversion:\n%s", out)
+	}
+	if _, err := os.Stat(journal.MigrationBackupFilename(path, 0)); !os.IsNotExist(err) {
+		t.Fatalf("dry run migrated the database: %v", err)
+	}
+
+	results, err = migrateJournalFiles(false)
+	if err != nil || !results[0].Report.Migrated() {
+		t.Fatalf("migrateJournalFiles() = %+v, %v", results, err)
+	}
+	entries, err := loadEntries()
+	if err != nil {
+		t.Fatalf("loadEntries() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Notes[0].Contents != "legacy" {
+		t.Errorf("unexpected entries after migration: %+v", entries)
+	}
+}
+
+func TestCommandsMigrateOldJournals(t *testing.T) {
+	legacy := []byte(`{"version":1,"entries":[{"id":"20231002","start_time":"2023-10-02T09:00:00Z","end_time":"2023-10-02T17:00:00Z","notes":[{"Contents":"Reviewed PRs"}]}]}`)
+	journalPath := filepath.Join(t.TempDir(), "journal.json")
+	if err := os.WriteFile(journalPath, legacy, 0644); err != nil {
+		t.Fatal(err)
+	}
+	setStorageViper(t, journalPath, "json", "")
+
+	// Commands load the journal while holding its lock
+	err := withStore(func(store journal.Store) error {
+		entries, err := store.All()
+		if err != nil {
+			return err
+		}
+		if len(entries) != 1 || entries[0].Notes[0].Contents != "Reviewed PRs" {
+			t.Errorf("unexpected entries: %+v", entries)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withStore() error = %v", err)
+	}
+	if backup, err := os.ReadFile(journal.MigrationBackupFilename(journalPath, 1)); err != nil || !bytes.Equal(backup, legacy) {
+		t.Errorf("expected the v1 journal as backup, got %s, %v", backup, err)
+	}
+}
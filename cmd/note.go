@@ -9,7 +9,6 @@ import (
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // noteCmd represents the note command
@@ -32,15 +31,13 @@ using the --tags flag in quick mode.`,
 type noteModel struct {
 	textInput textinput.Model
 	entry     *journal.JournalEntry
-	entryIdx  int
-	entries   []journal.JournalEntry
-
-	journalPath string
-	width       int
-	height      int
-	quitting    bool
-	saved       bool
-	err         error
+	store     journal.Store
+
+	width    int
+	height   int
+	quitting bool
+	saved    bool
+	err      error
 }
 
 func (m noteModel) Init() tea.Cmd {
@@ -67,8 +64,7 @@ func (m noteModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			// Create note with tag parsing
-			note := journal.Note{Contents: m.textInput.Value()}
-			note.ParseContent()
+			note := journal.NewNote(m.textInput.Value(), currentTime())
 
 			// Validate note
 			if result := journal.ValidateNote(note); !result.IsValid {
@@ -76,11 +72,17 @@ func (m noteModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-			// Add note to entry
-			m.entries[m.entryIdx].Notes = append(m.entries[m.entryIdx].Notes, note)
-
-			// Save entries
-			if err := journal.SaveEntries(m.entries, m.journalPath); err != nil {
+			// Add note to a freshly loaded entry, since the journal may have
+			// changed while the input was open
+			err := withJournalLock(m.store.Location(), func() error {
+				entry, err := m.store.Get(m.entry.ID)
+				if err != nil {
+					return err
+				}
+				entry.Notes = append(entry.Notes, note)
+				return m.store.Upsert(*entry)
+			})
+			if err != nil {
 				m.err = err
 				return m, nil
 			}
@@ -150,14 +152,14 @@ func handleNoteCommand(cmd *cobra.Command, args []string) error {
 
 // runInteractiveNoteEntry starts the TUI for interactive note entry
 func runInteractiveNoteEntry() error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
+	store, err := openStore()
 	if err != nil {
 		return err
 	}
+	defer store.Close()
 
 	// Find current day entry
-	_, idx, err := journal.FindCurrentDayEntry(entries)
+	entry, err := journal.CurrentEntry(store, currentTime())
 	if err != nil {
 		fmt.Println("Please run `workday start` first to create a new entry.")
 		return err
@@ -172,11 +174,9 @@ func runInteractiveNoteEntry() error {
 
 	// Create model
 	model := noteModel{
-		textInput:   ti,
-		entry:       &entries[idx],
-		entryIdx:    idx,
-		entries:     entries,
-		journalPath: journalPath,
+		textInput: ti,
+		entry:     entry,
+		store:     store,
 	}
 
 	// Run TUI
@@ -186,58 +186,52 @@ func runInteractiveNoteEntry() error {
 }
 
 // addNoteToCurrentDay adds a note to the current workday entry.
-// It first loads the existing journal entries from the file.
+// It first looks up the entry for the current day in the configured store.
 // If there is no entry for the current day, it prints an error message and returns an error.
-// Otherwise, it adds the note to the current entry and saves the updated journal entries back to the file.
+// Otherwise, it adds the note to the current entry and saves it back to the store,
+// holding the journal lock throughout.
 func addNoteToCurrentDay(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
-	if err != nil {
-		return err
-	}
-
-	// Find current day entry using validation helper
-	_, idx, err := journal.FindCurrentDayEntry(entries)
-	if err != nil {
-		fmt.Println("Please run `workday start` first to create a new entry.")
-		return err
-	}
+	err := withStore(func(store journal.Store) error {
+		// Find current day entry
+		entry, err := journal.CurrentEntry(store, currentTime())
+		if err != nil {
+			fmt.Println("Please run `workday start` first to create a new entry.")
+			return err
+		}
 
-	// Create note with content and manual tags
-	note := journal.Note{Contents: args[0]}
-
-	// Parse hashtags from content
-	note.ParseContent()
-
-	// Add manual tags from flag if provided
-	if tags != "" {
-		tagList := strings.Split(tags, ",")
-		// Clean up tags and add them
-		for _, tag := range tagList {
-			tag = strings.TrimSpace(tag)
-			if tag != "" {
-				// Check for duplicates
-				exists := false
-				for _, existingTag := range note.Tags {
-					if existingTag == tag {
-						exists = true
-						break
+		// Create note with content, parsing its hashtags
+		note := journal.NewNote(args[0], currentTime())
+
+		// Add manual tags from flag if provided
+		if tags != "" {
+			tagList := strings.Split(tags, ",")
+			// Clean up tags and add them
+			for _, tag := range tagList {
+				tag = strings.TrimSpace(tag)
+				if tag != "" {
+					// Check for duplicates
+					exists := false
+					for _, existingTag := range note.Tags {
+						if existingTag == tag {
+							exists = true
+							break
+						}
+					}
+					if !exists {
+						note.Tags = append(note.Tags, tag)
 					}
-				}
-				if !exists {
-					note.Tags = append(note.Tags, tag)
 				}
 			}
 		}
-	}
 
-	// Validate note
-	if result := journal.ValidateNote(note); !result.IsValid {
-		return result.Error
-	}
+		// Validate note
+		if result := journal.ValidateNote(note); !result.IsValid {
+			return result.Error
+		}
 
-	entries[idx].Notes = append(entries[idx].Notes, note)
-	err = journal.SaveEntries(entries, journalPath)
+		entry.Notes = append(entry.Notes, note)
+		return store.Upsert(*entry)
+	})
 	if err != nil {
 		return err
 	}
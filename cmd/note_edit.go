@@ -2,12 +2,9 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // noteEditCmd represents the note edit command
@@ -15,49 +12,50 @@ var noteEditCmd = &cobra.Command{
 	Use:   "edit [index] [new note]",
 	Args:  cobra.ExactArgs(2),
 	Short: "Edits a note in the current workday entry",
-	Long: `The note edit command is used to edit a note in the current workday entry.
+	Long: `The note edit command is used to edit a note in the current workday entry, or in the entry of --date.
 
-It requires two arguments: the index of the note to be edited and the new note text. The index must be provided as a number.
-If there is no entry for the current day, the command will print an error message and return an error.
-Otherwise, it will edit the note at the specified index and save the updated journal entries back to the file`,
+It requires two arguments: the index of the note to be edited and the new note text. The index must be provided as a number,
+counting from 1 as 'workday report' lists the notes.
+If there is no entry for the day, the command will print an error message and return an error.
+Otherwise, it will edit the note at the specified index and save the updated journal entries back to the file.
+
+Hashtags in the new text are added to the tags the note already has. The previous
+text is kept as a revision of the note, see 'workday note history'.`,
 	RunE: editNoteInCurrentDay,
 }
 
 func editNoteInCurrentDay(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
+	dateFlag, _ := cmd.Flags().GetString("date")
+	var noteIdx int
+	var changed bool
+	err := withStore(func(store journal.Store) error {
+		entry, err := noteEntry(store, dateFlag, currentTime())
+		if err != nil {
+			return err
+		}
+		noteIdx, err = parseNoteIndex(args[0], entry)
+		if err != nil {
+			return err
+		}
+		newNote := args[1]
+
+		if changed = entry.Notes[noteIdx].Edit(newNote, currentTime()); !changed {
+			return nil
+		}
+		return store.Upsert(*entry)
+	})
 	if err != nil {
 		return err
 	}
-
-	noteIdx, err := strconv.Atoi(args[0])
-	if err != nil {
-		return fmt.Errorf("Invalid index for note: %s", args[0])
-	}
-	newNote := args[1]
-
-	now := time.Now()
-	dayId := now.Format("20060102")
-	_, idx := journal.FetchEntryByID(dayId, entries)
-	if idx == -1 {
-		fmt.Println("Please run `workday start` first to create a new entry.")
-		return fmt.Errorf("Could not find any entry for the current day.")
-	}
-
-	if noteIdx < 0 || noteIdx >= len(entries[idx].Notes) {
-		return fmt.Errorf("The index provided is not valid for the existing notes: %d", noteIdx)
-	}
-
-	entries[idx].Notes[noteIdx] = journal.Note{Contents: newNote}
-
-	err = journal.SaveEntries(entries, journalPath)
-	if err != nil {
-		return err
+	if !changed {
+		fmt.Printf("Note %d is unchanged.\n", noteIdx+1)
+		return nil
 	}
-	fmt.Printf("Successfully edited note %d from the current day.\n", noteIdx)
+	fmt.Printf("Successfully edited note %d.\n", noteIdx+1)
 	return nil
 }
 
 func init() {
+	noteEditCmd.Flags().StringP("date", "d", "", "Date of the entry holding the note (YYYY-MM-DD), default today")
 	noteCmd.AddCommand(noteEditCmd)
 }
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+// noteHistoryCmd represents the note history command
+var noteHistoryCmd = &cobra.Command{
+	Use:   "history [index]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Shows the earlier versions of a note in the current workday entry",
+	Long: `The note history command lists every version of a note in the current workday
+entry, or of the entry of --date, oldest first, with the time each one was
+written. The index is the same as for 'workday note edit'.
+
+Versions written before notes had times are shown without one.`,
+	RunE: showNoteHistory,
+}
+
+// describeNoteVersion renders one version of a note as
+// "2026-04-07 10:15 Reviewed the parser [review]", with blanks for an unknown
+// time. The date is always shown, as notes can be edited or moved to another
+// day.
+func describeNoteVersion(contents string, tags []string, at time.Time) string {
+	text := strings.Repeat(" ", len("2006-01-02 15:04"))
+	if !at.IsZero() {
+		text = at.Format("2006-01-02 15:04")
+	}
+	text += " " + contents
+	if len(tags) > 0 {
+		text += fmt.Sprintf(" [%s]", strings.Join(tags, ", "))
+	}
+	return text
+}
+
+func showNoteHistory(cmd *cobra.Command, args []string) error {
+	dateFlag, _ := cmd.Flags().GetString("date")
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	entry, err := noteEntry(store, dateFlag, currentTime())
+	if err != nil {
+		return err
+	}
+	noteIdx, err := parseNoteIndex(args[0], entry)
+	if err != nil {
+		return err
+	}
+
+	note := entry.Notes[noteIdx]
+	fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("📜 History of note %d", noteIdx+1)))
+	for _, revision := range note.Revisions {
+		fmt.Println(styles.InfoStyle.Render(describeNoteVersion(revision.Contents, revision.Tags, revision.Time)))
+	}
+	fmt.Println(styles.NoteStyle.Render(describeNoteVersion(note.Contents, note.Tags, note.WrittenAt()) + " (current)"))
+	return nil
+}
+
+func init() {
+	noteHistoryCmd.Flags().StringP("date", "d", "", "Date of the entry holding the note (YYYY-MM-DD), default today")
+	noteCmd.AddCommand(noteHistoryCmd)
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+var noteDeleteCmd = &cobra.Command{
+	Use:   "delete <index>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Deletes a note",
+	Long: `The note delete command deletes a note from the current workday entry, or from
+the entry of --date, after asking for confirmation. Notes are numbered from 1,
+as listed by 'workday report'.
+
+Examples:
+  workday note delete 2
+  workday note delete 1 --date 2026-04-07`,
+	RunE: deleteNote,
+}
+
+var noteMoveCmd = &cobra.Command{
+	Use:   "move <index> <date>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Moves a note to the entry of another day",
+	Long: `The note move command moves a note from the current workday entry, or from the
+entry of --date, to the end of the notes of the entry of date, in YYYY-MM-DD
+format. That entry must already exist. The note keeps its tags, the time it was
+written at and its revisions.
+
+Examples:
+  workday note move 3 2026-04-08
+  workday note move 1 2026-04-08 --date 2026-04-07`,
+	RunE: moveNoteCommand,
+}
+
+var noteTagCmd = &cobra.Command{
+	Use:   "tag <index> <tag>...",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Adds tags to a note",
+	Long: `The note tag command adds tags to a note of the current workday entry, or of the
+entry of --date. Tags may be given with or without '#'. The previous tags are
+kept as a revision of the note, see 'workday note history'.
+
+Examples:
+  workday note tag 2 review team
+  workday note tag 1 '#incident' --date 2026-04-07`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return retagNoteCommand(cmd, args, true)
+	},
+}
+
+var noteUntagCmd = &cobra.Command{
+	Use:   "untag <index> <tag>...",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Removes tags from a note",
+	Long: `The note untag command removes tags from a note of the current workday entry, or
+of the entry of --date, ignoring case. The previous tags are kept as a revision
+of the note, see 'workday note history'.
+
+Examples:
+  workday note untag 2 team
+  workday note untag 1 incident --date 2026-04-07`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return retagNoteCommand(cmd, args, false)
+	},
+}
+
+// noteEntry returns the entry the note commands work on: the entry of the
+// YYYY-MM-DD date in dateFlag, or the current one when dateFlag is empty.
+func noteEntry(store journal.Store, dateFlag string, now time.Time) (*journal.JournalEntry, error) {
+	if dateFlag == "" {
+		entry, err := journal.CurrentEntry(store, now)
+		if errors.Is(err, journal.ErrEntryNotFound) {
+			fmt.Println("Please run `workday start` first to create a new entry.")
+			return nil, fmt.Errorf("Could not find any entry for the current day.")
+		}
+		return entry, err
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", dateFlag, now.Location())
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+	}
+	entry, err := store.Get(date.Format("20060102"))
+	if errors.Is(err, journal.ErrEntryNotFound) {
+		return nil, fmt.Errorf("no entry found for %s", dateFlag)
+	}
+	return entry, err
+}
+
+// parseNoteIndex converts the number of a note, counted from 1 as the
+// reports list them, into its position among the notes of entry.
+func parseNoteIndex(arg string, entry *journal.JournalEntry) (int, error) {
+	number, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid index for note: %s", arg)
+	}
+	if number < 1 || number > len(entry.Notes) {
+		return 0, fmt.Errorf("The index provided is not valid for the existing notes: %d", number)
+	}
+	return number - 1, nil
+}
+
+// parseTagArgs validates tags given as arguments, with or without '#'.
+func parseTagArgs(args []string) ([]string, error) {
+	var tags []string
+	for _, arg := range args {
+		tag := strings.TrimPrefix(strings.TrimSpace(arg), "#")
+		if result := journal.ValidateTag(tag); !result.IsValid {
+			return nil, result.Error
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// sameNote reports whether a and b are the same version of a note, such as
+// a note read again after asking the user about it.
+func sameNote(a, b journal.Note) bool {
+	return a.Contents == b.Contents && strings.Join(a.Tags, " ") == strings.Join(b.Tags, " ") &&
+		a.CreatedAt.Equal(b.CreatedAt) && a.UpdatedAt.Equal(b.UpdatedAt)
+}
+
+func deleteNote(cmd *cobra.Command, args []string) error {
+	dateFlag, _ := cmd.Flags().GetString("date")
+	now := currentTime()
+
+	// Ask before taking the lock, so other commands are not kept waiting for
+	// the answer
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	entry, err := noteEntry(store, dateFlag, now)
+	store.Close()
+	if err != nil {
+		return err
+	}
+	index, err := parseNoteIndex(args[0], entry)
+	if err != nil {
+		return err
+	}
+	note := entry.Notes[index]
+	fmt.Printf("Delete note %d: %s? [y/N]: ", index+1, journal.FormatNoteWithTags(note.Contents, note.Tags))
+	if response, err := getUserInput(); err != nil || (response != "y" && response != "yes") {
+		fmt.Println("Deletion cancelled")
+		return nil
+	}
+
+	err = withStore(func(store journal.Store) error {
+		current, err := store.Get(entry.ID)
+		if err != nil {
+			return err
+		}
+		if index >= len(current.Notes) || !sameNote(current.Notes[index], note) {
+			return fmt.Errorf("note %d changed while waiting for confirmation, nothing was deleted", index+1)
+		}
+		current.RemoveNote(index)
+		return store.Upsert(*current)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Note %d deleted successfully\n", index+1)
+	return nil
+}
+
+// moveNote moves the note numbered arg of the entry of fromDate, or the
+// current entry, to the end of the notes of the entry of toDate, and returns
+// the entry it was moved to.
+func moveNote(store journal.Store, fromDate, toDate, arg string, now time.Time) (*journal.JournalEntry, error) {
+	from, err := noteEntry(store, fromDate, now)
+	if err != nil {
+		return nil, err
+	}
+	index, err := parseNoteIndex(arg, from)
+	if err != nil {
+		return nil, err
+	}
+	to, err := noteEntry(store, toDate, now)
+	if err != nil {
+		return nil, err
+	}
+	if to.ID == from.ID {
+		return nil, fmt.Errorf("the note is already on %s", to.StartTime.Format("2006-01-02"))
+	}
+
+	to.Notes = append(to.Notes, from.RemoveNote(index))
+	// Both days in one write, so a failure cannot lose or copy the note
+	if err := store.Write([]journal.Change{{Entry: *to}, {Entry: *from}}); err != nil {
+		return nil, err
+	}
+	return to, nil
+}
+
+func moveNoteCommand(cmd *cobra.Command, args []string) error {
+	dateFlag, _ := cmd.Flags().GetString("date")
+	var to *journal.JournalEntry
+	err := withStore(func(store journal.Store) error {
+		var err error
+		to, err = moveNote(store, dateFlag, args[1], args[0], currentTime())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ Note moved to %s as note %d", to.StartTime.Format("Mon, Jan 2 2006"), len(to.Notes))))
+	return nil
+}
+
+// retagNote adds tags to, or with add unset removes them from, the note
+// numbered arg of the entry of dateFlag, or the current entry. It returns the
+// note and whether it changed.
+func retagNote(store journal.Store, dateFlag, arg string, tags []string, add bool, now time.Time) (journal.Note, bool, error) {
+	entry, err := noteEntry(store, dateFlag, now)
+	if err != nil {
+		return journal.Note{}, false, err
+	}
+	index, err := parseNoteIndex(arg, entry)
+	if err != nil {
+		return journal.Note{}, false, err
+	}
+
+	note := &entry.Notes[index]
+	changed := false
+	if add {
+		changed = note.AddTags(tags, now)
+	} else {
+		changed = note.RemoveTags(tags, now)
+	}
+	if !changed {
+		return *note, false, nil
+	}
+	return *note, true, store.Upsert(*entry)
+}
+
+func retagNoteCommand(cmd *cobra.Command, args []string, add bool) error {
+	dateFlag, _ := cmd.Flags().GetString("date")
+	tags, err := parseTagArgs(args[1:])
+	if err != nil {
+		return err
+	}
+
+	var note journal.Note
+	var changed bool
+	err = withStore(func(store journal.Store) error {
+		var err error
+		note, changed, err = retagNote(store, dateFlag, args[0], tags, add, currentTime())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	tagList := "none"
+	if len(note.Tags) > 0 {
+		tagList = "#" + strings.Join(note.Tags, " #")
+	}
+	if !changed {
+		fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("Note %s is unchanged, its tags are: %s", args[0], tagList)))
+		return nil
+	}
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ Note %s is now tagged: %s", args[0], tagList)))
+	return nil
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{noteDeleteCmd, noteMoveCmd, noteTagCmd, noteUntagCmd} {
+		cmd.Flags().StringP("date", "d", "", "Date of the entry holding the note (YYYY-MM-DD), default today")
+		noteCmd.AddCommand(cmd)
+	}
+}
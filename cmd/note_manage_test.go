@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/google/go-cmp/cmp"
+)
+
+// noteJournal returns two days with notes, the second one today for now.
+func noteJournal(now time.Time) []journal.JournalEntry {
+	yesterday := now.AddDate(0, 0, -1)
+	return []journal.JournalEntry{
+		{ID: yesterday.Format("20060102"), StartTime: yesterday, EndTime: yesterday.Add(8 * time.Hour), Notes: []journal.Note{
+			{Contents: "Planned the sprint", Tags: []string{"planning"}},
+			{Contents: "Fixed the login bug", Tags: []string{"bug", "progress"}},
+		}},
+		{ID: now.Format("20060102"), StartTime: now, Notes: []journal.Note{
+			{Contents: "Standup", Tags: []string{"team"}},
+		}},
+	}
+}
+
+func noteContents(entry *journal.JournalEntry) []string {
+	var contents []string
+	for _, note := range entry.Notes {
+		contents = append(contents, note.Contents)
+	}
+	return contents
+}
+
+func TestParseNoteIndex(t *testing.T) {
+	entry := &journal.JournalEntry{Notes: []journal.Note{{Contents: "one"}, {Contents: "two"}}}
+
+	tests := []struct {
+		arg     string
+		want    int
+		wantErr bool
+	}{
+		{arg: "1", want: 0},
+		{arg: "2", want: 1},
+		{arg: "0", wantErr: true},
+		{arg: "3", wantErr: true},
+		{arg: "two", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := parseNoteIndex(tt.arg, entry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNoteIndex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseNoteIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoveNote(t *testing.T) {
+	now := time.Date(2026, 4, 8, 9, 0, 0, 0, time.Local)
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
+
+	tests := []struct {
+		name     string
+		fromDate string
+		toDate   string
+		arg      string
+		wantFrom []string
+		wantTo   []string
+		wantErr  bool
+	}{
+		{name: "to today", fromDate: yesterday, toDate: now.Format("2006-01-02"), arg: "2", wantFrom: []string{"Planned the sprint"}, wantTo: []string{"Standup", "Fixed the login bug"}},
+		{name: "from today", toDate: yesterday, arg: "1", wantFrom: nil, wantTo: []string{"Planned the sprint", "Fixed the login bug", "Standup"}},
+		{name: "same day", fromDate: yesterday, toDate: yesterday, arg: "1", wantErr: true},
+		{name: "missing day", toDate: "2026-01-01", arg: "1", wantErr: true},
+		{name: "bad index", fromDate: yesterday, toDate: now.Format("2006-01-02"), arg: "3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTempJournal(t, noteJournal(now))
+			store := journal.NewJSONStore(path, journal.BackupPolicy{})
+
+			_, err := moveNote(store, tt.fromDate, tt.toDate, tt.arg, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("moveNote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			from, _ := noteEntry(store, tt.fromDate, now)
+			to, _ := noteEntry(store, tt.toDate, now)
+			if diff := cmp.Diff(tt.wantFrom, noteContents(from)); diff != "" {
+				t.Errorf("notes left behind mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantTo, noteContents(to)); diff != "" {
+				t.Errorf("notes moved to mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRetagNote(t *testing.T) {
+	now := time.Date(2026, 4, 8, 9, 0, 0, 0, time.Local)
+	path := writeTempJournal(t, noteJournal(now))
+	store := journal.NewJSONStore(path, journal.BackupPolicy{})
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
+
+	note, changed, err := retagNote(store, yesterday, "2", []string{"Incident", "bug"}, true, now)
+	if err != nil || !changed {
+		t.Fatalf("retagNote() = %v, %v", changed, err)
+	}
+	if diff := cmp.Diff([]string{"bug", "progress", "Incident"}, note.Tags); diff != "" {
+		t.Errorf("tags after tagging mismatch (-want +got):\n%s", diff)
+	}
+
+	note, changed, err = retagNote(store, yesterday, "2", []string{"BUG", "progress"}, false, now)
+	if err != nil || !changed {
+		t.Fatalf("retagNote() = %v, %v", changed, err)
+	}
+	if diff := cmp.Diff([]string{"Incident"}, note.Tags); diff != "" {
+		t.Errorf("tags after untagging mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, changed, _ = retagNote(store, yesterday, "2", []string{"missing"}, false, now); changed {
+		t.Error("removing a tag the note does not have changed it")
+	}
+
+	// Both changes were saved, each keeping the tags before it
+	entry, err := noteEntry(store, yesterday, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := entry.Notes[1]
+	if len(saved.Revisions) != 2 || !saved.UpdatedAt.Equal(now) {
+		t.Errorf("saved note = %+v, want 2 revisions updated at %v", saved, now)
+	}
+}
+
+func TestParseTagArgs(t *testing.T) {
+	got, err := parseTagArgs([]string{"#team", " review ", "team-sync"})
+	if err != nil {
+		t.Fatalf("parseTagArgs() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"team", "review", "team-sync"}, got); diff != "" {
+		t.Errorf("parseTagArgs() mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := parseTagArgs([]string{"two words"}); err == nil {
+		t.Error("expected an error for a tag with a space")
+	}
+}
+
+func TestSameNote(t *testing.T) {
+	created := time.Date(2026, 4, 7, 10, 0, 0, 0, time.UTC)
+	note := journal.NewNote("Fixed the login bug #bug", created)
+
+	edited := note
+	edited.Edit("Fixed the logout bug", created.Add(time.Hour))
+	retagged := note
+	retagged.AddTags([]string{"team"}, created.Add(time.Hour))
+	moved := note
+	moved.CreatedAt = created.In(time.FixedZone("UTC+2", 2*60*60))
+
+	if !sameNote(note, moved) {
+		t.Error("the same note read in another zone is not the same")
+	}
+	if sameNote(note, edited) || sameNote(note, retagged) {
+		t.Error("an edited note is the same")
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+// offCmd represents the off command
+var offCmd = &cobra.Command{
+	Use:   "off <date|from..to> <holiday|pto|sick|work> [reason]",
+	Short: "Marks days as holidays, PTO or sick leave",
+	Long: `The off command marks a day, or a range of days, as a day off. Days off have
+no expected work time, so the week and month reports lower their targets
+accordingly and 'workday end' does not ask for a minimum on them.
+
+Dates use the YYYY-MM-DD format; a range is written as from..to and skips the
+days the schedule already has off, such as weekends. Marking a day as "work"
+turns it back into a normal work day. Work done on a day off is kept.
+
+Examples:
+  workday off 2026-12-24 holiday "Christmas Eve"
+  workday off 2026-08-03..2026-08-14 pto "Summer vacation"
+  workday off 2026-03-02 sick
+  workday off 2026-03-02 work`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: markDaysOff,
+}
+
+var offImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Marks the public holidays of a holiday list as days off",
+	Long: `The import command reads public holidays from an iCalendar (.ics) file or a
+YAML list and marks each of them as a holiday. Days that are already marked
+off, or that the schedule has off, are left alone.
+
+The YAML list has the holidays of one country; holidays that only apply to
+some regions list them, and are imported when --region names one of them:
+
+  country: DE
+  holidays:
+    - date: 2026-01-01
+      name: Neujahr
+    - date: 2026-01-06
+      name: Heilige Drei Könige
+      regions: [BW, BY, ST]
+
+Examples:
+  workday off import holidays-de.yaml --region BY
+  workday off import holidays.ics`,
+	Args: cobra.ExactArgs(1),
+	RunE: importHolidays,
+}
+
+// dayOff is one day to mark with a day type.
+type dayOff struct {
+	Date    time.Time
+	DayType journal.DayType
+	Reason  string
+}
+
+// offResult is what marking one day did.
+type offResult struct {
+	Date   time.Time
+	Action string // "marked", "cleared" or "skipped"
+}
+
+// parseOffDates parses a date or a from..to range of dates in the YYYY-MM-DD
+// format, in loc. Days of a range that the schedule has off are left out.
+func parseOffDates(arg string, schedule workSchedule, loc *time.Location) ([]time.Time, error) {
+	fromStr, toStr, isRange := strings.Cut(arg, "..")
+	from, err := time.ParseInLocation("2006-01-02", fromStr, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date '%s'. Use YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD", fromStr)
+	}
+	if !isRange {
+		return []time.Time{from}, nil
+	}
+
+	to, err := time.ParseInLocation("2006-01-02", toStr, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date '%s'. Use YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD", toStr)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("the range %s ends before it starts", arg)
+	}
+
+	var dates []time.Time
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if schedule.For(day).MinWorkTime > 0 {
+			dates = append(dates, day)
+		}
+	}
+	return dates, nil
+}
+
+// applyDaysOff marks each day in the store. A day that already has an entry
+// keeps its work and only gets its day type changed; with overwrite unset,
+// days that are already off are skipped. Marking a day as work clears its day
+// type and removes the entry if it held nothing but the day off. The days
+// are saved in a single write once all of them are marked.
+func applyDaysOff(store journal.Store, days []dayOff, overwrite bool) ([]offResult, error) {
+	batch := journal.NewBatch(store)
+	var results []offResult
+	for _, day := range days {
+		id := day.Date.Format("20060102")
+		entry, err := batch.Get(id)
+		if err != nil && !errors.Is(err, journal.ErrEntryNotFound) {
+			return nil, err
+		}
+
+		switch {
+		case entry == nil && !day.DayType.IsOff():
+			results = append(results, offResult{Date: day.Date, Action: "skipped"})
+			continue
+		case entry == nil:
+			entry, err = journal.NewDayOffEntry(day.Date, day.DayType, day.Reason)
+			if err != nil {
+				return nil, err
+			}
+		case entry.IsDayOff() && !overwrite:
+			results = append(results, offResult{Date: day.Date, Action: "skipped"})
+			continue
+		case !day.DayType.IsOff() && entry.IsDayOff() && len(entry.WorkSessions()) == 0 && len(entry.Notes) == 0 && len(entry.Adjustments) == 0:
+			if err := batch.Delete(id); err != nil {
+				return nil, err
+			}
+			results = append(results, offResult{Date: day.Date, Action: "cleared"})
+			continue
+		default:
+			entry.SetDayType(day.DayType, day.Reason)
+			// A day off has no minimum, which may settle findings
+			if err := validateEntry(batch, entry, currentTime()); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := batch.Upsert(*entry); err != nil {
+			return nil, err
+		}
+		action := "marked"
+		if !day.DayType.IsOff() {
+			action = "cleared"
+		}
+		results = append(results, offResult{Date: day.Date, Action: action})
+	}
+	if err := batch.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// dayOffLabel describes the day type of an entry for report tables, e.g.
+// "PTO: Summer vacation".
+func dayOffLabel(entry journal.JournalEntry) string {
+	label := entry.DayType.Label()
+	if entry.DayReason != "" {
+		label += ": " + entry.DayReason
+	}
+	return label
+}
+
+// printOffResults summarises what applyDaysOff did.
+func printOffResults(results []offResult, dayType journal.DayType) {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Action]++
+		switch result.Action {
+		case "marked":
+			fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ %s marked as %s", result.Date.Format("Mon, Jan 2 2006"), dayType.Label())))
+		case "cleared":
+			fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ %s is a work day again", result.Date.Format("Mon, Jan 2 2006"))))
+		}
+	}
+	if counts["skipped"] > 0 {
+		fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("💡 Skipped %d days that were already off or had nothing to clear", counts["skipped"])))
+	}
+	if len(results) == 0 {
+		fmt.Println(styles.InfoStyle.Render("No work days in the given dates, nothing to mark"))
+	}
+}
+
+func markDaysOff(cmd *cobra.Command, args []string) error {
+	dayType, err := journal.ParseDayType(args[1])
+	if err != nil {
+		return err
+	}
+	reason := strings.Join(args[2:], " ")
+
+	schedule, err := loadSchedule()
+	if err != nil {
+		return err
+	}
+	loc, err := configLocation()
+	if err != nil {
+		return err
+	}
+	dates, err := parseOffDates(args[0], schedule, loc)
+	if err != nil {
+		return err
+	}
+
+	days := make([]dayOff, len(dates))
+	for i, date := range dates {
+		days[i] = dayOff{Date: date, DayType: dayType, Reason: reason}
+	}
+
+	var results []offResult
+	err = withStore(func(store journal.Store) error {
+		results, err = applyDaysOff(store, days, true)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	printOffResults(results, dayType)
+	return nil
+}
+
+func importHolidays(cmd *cobra.Command, args []string) error {
+	region, _ := cmd.Flags().GetString("region")
+
+	schedule, err := loadSchedule()
+	if err != nil {
+		return err
+	}
+	loc, err := configLocation()
+	if err != nil {
+		return err
+	}
+	holidays, err := journal.LoadHolidays(args[0], region, loc)
+	if err != nil {
+		return err
+	}
+
+	var days []dayOff
+	for _, holiday := range holidays {
+		if schedule.For(holiday.Date).MinWorkTime == 0 {
+			continue
+		}
+		days = append(days, dayOff{Date: holiday.Date, DayType: journal.DayHoliday, Reason: holiday.Name})
+	}
+
+	var results []offResult
+	err = withStore(func(store journal.Store) error {
+		results, err = applyDaysOff(store, days, false)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	printOffResults(results, journal.DayHoliday)
+	return nil
+}
+
+func init() {
+	offImportCmd.Flags().String("region", "", "Region whose regional holidays are imported from a YAML list")
+	offCmd.AddCommand(offImportCmd)
+	rootCmd.AddCommand(offCmd)
+}
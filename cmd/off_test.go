@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+func TestParseOffDates(t *testing.T) {
+	var schedule workSchedule
+	for day := time.Monday; day <= time.Friday; day++ {
+		schedule[day].MinWorkTime = 8 * time.Hour
+	}
+
+	tests := []struct {
+		name    string
+		arg     string
+		want    []string
+		wantErr bool
+	}{
+		{name: "single day, even on a weekend", arg: "2026-04-11", want: []string{"20260411"}},
+		{name: "range skips the weekend", arg: "2026-04-09..2026-04-14", want: []string{"20260409", "20260410", "20260413", "20260414"}},
+		{name: "reversed range", arg: "2026-04-14..2026-04-09", wantErr: true},
+		{name: "malformed date", arg: "14/04/2026", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates, err := parseOffDates(tt.arg, schedule, time.UTC)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOffDates() error = %v", err)
+			}
+			if len(dates) != len(tt.want) {
+				t.Fatalf("got %d dates, want %v", len(dates), tt.want)
+			}
+			for i, date := range dates {
+				if date.Format("20060102") != tt.want[i] {
+					t.Errorf("date %d = %s, want %s", i, date.Format("20060102"), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestApplyDaysOff(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 4, day, hour, 0, 0, 0, time.UTC)
+	}
+	worked := journal.JournalEntry{ID: "20260407", StartTime: at(7, 9), EndTime: at(7, 12),
+		Sessions: []journal.Session{{StartTime: at(7, 9), EndTime: at(7, 12)}}}
+	path := writeTempJournal(t, []journal.JournalEntry{worked})
+	setBackfillViper(t, path, "8h", "1h", "10h")
+	store := journal.NewJSONStore(path, journal.BackupPolicy{})
+
+	results, err := applyDaysOff(store, []dayOff{
+		{Date: at(6, 0), DayType: journal.DayHoliday, Reason: "Easter Monday"},
+		{Date: at(7, 0), DayType: journal.DaySick},
+	}, true)
+	if err != nil {
+		t.Fatalf("applyDaysOff() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Action != "marked" || results[1].Action != "marked" {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	sick, err := store.Get("20260407")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sick.DayType != journal.DaySick || sick.TotalWorkTime() != 3*time.Hour {
+		t.Errorf("expected the worked day to be sick and keep its work, got %+v", sick)
+	}
+
+	// Importing does not overwrite days that are already off
+	results, err = applyDaysOff(store, []dayOff{{Date: at(7, 0), DayType: journal.DayHoliday}}, false)
+	if err != nil || results[0].Action != "skipped" {
+		t.Errorf("expected the sick day to be skipped, got %+v, %v", results, err)
+	}
+
+	// Marking a pure day off as work removes it
+	results, err = applyDaysOff(store, []dayOff{{Date: at(6, 0), DayType: journal.DayWork}}, true)
+	if err != nil || results[0].Action != "cleared" {
+		t.Fatalf("expected the holiday to be cleared, got %+v, %v", results, err)
+	}
+	entries, err := store.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ID != "20260407" {
+		t.Errorf("expected only the worked day to remain, got %+v", entries)
+	}
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+// Output formats of the --output flag
+const (
+	outputInteractive = "interactive" // Bubble Tea view that waits for 'q'
+	outputText        = "text"        // the same view, printed once
+	outputJSON        = "json"
+	outputNone        = "none"
+)
+
+var outputFormat string
+
+// resolveOutput returns the format reports are shown in. Without --output the
+// interactive view is used when stdout is a terminal, and plain text
+// otherwise, so reports can be piped and run from cron.
+func resolveOutput() (string, error) {
+	switch outputFormat {
+	case "":
+		if stdoutIsTerminal() {
+			return outputInteractive, nil
+		}
+		return outputText, nil
+	case outputInteractive, outputText, outputJSON, outputNone:
+		return outputFormat, nil
+	default:
+		return "", fmt.Errorf("invalid output format '%s', expected text, json or none", outputFormat)
+	}
+}
+
+// stdoutIsTerminal reports whether stdout is a terminal rather than a pipe or
+// a file.
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// renderOptions controls what the view of a report includes.
+type renderOptions struct {
+	Interactive bool // the view waits for a key, so it shows how to quit
+}
+
+// reportView is the Bubble Tea model of a report, whose View renders it
+// interactively and render with the given options.
+type reportView interface {
+	tea.Model
+	render(opts renderOptions) string
+}
+
+// showReport shows a report in the --output format: model runs as a Bubble Tea
+// program or has its view printed once, and data is printed as JSON.
+func showReport(model reportView, data interface{}) error {
+	format, err := resolveOutput()
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	case outputNone:
+		return nil
+	case outputText:
+		fmt.Println(strings.TrimRight(model.render(renderOptions{}), "\n"))
+		return nil
+	default:
+		p := tea.NewProgram(model)
+		_, err = p.Run()
+		return err
+	}
+}
+
+// The JSON output of the reports. Scripts depend on these names, so fields
+// may be added but must not be renamed or removed. Durations are whole
+// minutes, times are RFC 3339 and days are YYYY-MM-DD. Lists are never null.
+
+type intervalJSON struct {
+	Start string `json:"start"`
+	End   string `json:"end,omitempty"` // empty while ongoing
+}
+
+type breakJSON struct {
+	Start   string `json:"start"`
+	End     string `json:"end,omitempty"` // empty while ongoing
+	Reason  string `json:"reason"`
+	Minutes int    `json:"minutes"`
+}
+
+type noteJSON struct {
+	Contents  string   `json:"contents"`
+	Tags      []string `json:"tags"`
+	Created   string   `json:"created_at,omitempty"` // empty for notes without a time
+	Updated   string   `json:"updated_at,omitempty"` // empty unless edited
+	Revisions int      `json:"revisions"`            // earlier versions kept
+}
+
+type adjustmentJSON struct {
+	Minutes int    `json:"minutes"`
+	Reason  string `json:"reason"`
+}
+
+type findingJSON struct {
+	Code         string `json:"code"`
+	Severity     string `json:"severity"`
+	Message      string `json:"message"`
+	Acknowledged bool   `json:"acknowledged"`
+}
+
+type entryJSON struct {
+	ID              string           `json:"id"`
+	Date            string           `json:"date"`
+	DayType         string           `json:"day_type,omitempty"` // empty for a work day
+	DayReason       string           `json:"day_reason,omitempty"`
+	Start           string           `json:"start,omitempty"` // empty for a day without work
+	End             string           `json:"end,omitempty"`   // empty while the day is ongoing
+	WorkedMinutes   int              `json:"worked_minutes"`
+	TargetMinutes   int              `json:"target_minutes"`
+	AdjustedMinutes int              `json:"adjusted_minutes"`
+	Sessions        []intervalJSON   `json:"sessions"`
+	Breaks          []breakJSON      `json:"breaks"`
+	Notes           []noteJSON       `json:"notes"`
+	Adjustments     []adjustmentJSON `json:"adjustments"`
+	Findings        []findingJSON    `json:"findings"`
+}
+
+type periodJSON struct {
+	Period          string       `json:"period"`
+	From            string       `json:"from"`
+	To              string       `json:"to"`
+	WorkedMinutes   int          `json:"worked_minutes"`
+	TargetMinutes   int          `json:"target_minutes"`
+	AdjustedMinutes int          `json:"adjusted_minutes"`
+	BalanceMinutes  int          `json:"balance_minutes"`
+	Days            []entryJSON  `json:"days"`
+	Groups          []periodJSON `json:"groups,omitempty"` // only in range reports
+}
+
+type statusJSON struct {
+	Date             string    `json:"date"`
+	State            string    `json:"state"` // working, on_break or finished
+	WorkedMinutes    int       `json:"worked_minutes"`
+	TargetMinutes    int       `json:"target_minutes"`
+	ExpectedEnd      string    `json:"expected_end"`
+	RemainingMinutes int       `json:"remaining_minutes"`
+	LunchBreakTaken  bool      `json:"lunch_break_taken"`
+	Entry            entryJSON `json:"entry"`
+}
+
+type breakListJSON struct {
+	Date         string      `json:"date"`
+	TotalMinutes int         `json:"total_minutes"`
+	Breaks       []breakJSON `json:"breaks"`
+}
+
+type allocationJSON struct {
+	Client  string `json:"client"`
+	Project string `json:"project,omitempty"`
+	Task    string `json:"task,omitempty"`
+	Minutes int    `json:"minutes"`
+}
+
+type projectsJSON struct {
+	Period           string           `json:"period"`
+	TrackedMinutes   int              `json:"tracked_minutes"`
+	WorkedMinutes    int              `json:"worked_minutes"`
+	UntrackedMinutes int              `json:"untracked_minutes"`
+	Clients          []allocationJSON `json:"clients"`
+	Projects         []allocationJSON `json:"projects"`
+	Tasks            []allocationJSON `json:"tasks"`
+}
+
+type balanceWeekJSON struct {
+	From            string `json:"from"`
+	To              string `json:"to"`
+	WorkedMinutes   int    `json:"worked_minutes"`
+	TargetMinutes   int    `json:"target_minutes"`
+	AdjustedMinutes int    `json:"adjusted_minutes"`
+	BalanceMinutes  int    `json:"balance_minutes"`
+	RunningMinutes  int    `json:"running_minutes"`
+}
+
+type balanceJSON struct {
+	From           string            `json:"from"`
+	To             string            `json:"to"`
+	BalanceMinutes int               `json:"balance_minutes"`
+	Weeks          []balanceWeekJSON `json:"weeks"`
+}
+
+type tagWeekJSON struct {
+	Week  string `json:"week"` // ISO week, e.g. 2026-W14
+	Count int    `json:"count"`
+}
+
+type tagJSON struct {
+	Tag   string        `json:"tag"`
+	Count int           `json:"count"`
+	First string        `json:"first"`
+	Last  string        `json:"last"`
+	Weeks []tagWeekJSON `json:"weeks"` // weeks the tag was used in, oldest first
+}
+
+type tagsJSON struct {
+	Period string    `json:"period"`
+	Tags   []tagJSON `json:"tags"`
+}
+
+type noteMatchJSON struct {
+	ID       string   `json:"id"`
+	Date     string   `json:"date"`
+	Index    int      `json:"index"` // position of the note in its day, from 1
+	Contents string   `json:"contents"`
+	Tags     []string `json:"tags"`
+	Score    float64  `json:"score"` // relevance, 0 for --regex and tag searches
+}
+
+type searchJSON struct {
+	Period  string          `json:"period"`
+	Matches []noteMatchJSON `json:"matches"`
+}
+
+type backupJSON struct {
+	ID        string `json:"id"` // the timestamp backup restore takes
+	Created   string `json:"created"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+type backupListJSON struct {
+	Journal string       `json:"journal"`
+	Backups []backupJSON `json:"backups"` // newest first
+}
+
+// minutes converts d to whole minutes for the JSON output.
+func minutes(d time.Duration) int {
+	return int(d / time.Minute)
+}
+
+// formatJSONTime formats t for the JSON output, or returns "" when t is zero.
+func formatJSONTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// jsonTags returns tags for the JSON output, where lists are never null.
+func jsonTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func newBreakJSON(br journal.Break) breakJSON {
+	return breakJSON{
+		Start:   formatJSONTime(br.StartTime),
+		End:     formatJSONTime(br.EndTime),
+		Reason:  br.Reason,
+		Minutes: minutes(br.Duration()),
+	}
+}
+
+// newEntryJSON builds the JSON form of entry, with the target of the schedule.
+func newEntryJSON(entry journal.JournalEntry, schedule workSchedule) entryJSON {
+	data := entryJSON{
+		ID:              entry.ID,
+		Date:            entry.StartTime.Format("2006-01-02"),
+		DayType:         string(entry.DayType),
+		DayReason:       entry.DayReason,
+		WorkedMinutes:   minutes(entry.TotalWorkTime()),
+		TargetMinutes:   minutes(schedule.TargetFor(&entry)),
+		AdjustedMinutes: minutes(entry.AdjustmentTotal()),
+		Sessions:        []intervalJSON{},
+		Breaks:          []breakJSON{},
+		Notes:           []noteJSON{},
+		Adjustments:     []adjustmentJSON{},
+		Findings:        []findingJSON{},
+	}
+	if sessions := entry.WorkSessions(); len(sessions) > 0 {
+		data.Start = formatJSONTime(entry.StartTime)
+		data.End = formatJSONTime(entry.EndTime)
+		for _, session := range sessions {
+			data.Sessions = append(data.Sessions, intervalJSON{Start: formatJSONTime(session.StartTime), End: formatJSONTime(session.EndTime)})
+		}
+	}
+	for _, br := range entry.Breaks {
+		data.Breaks = append(data.Breaks, newBreakJSON(br))
+	}
+	for _, note := range entry.Notes {
+		data.Notes = append(data.Notes, noteJSON{
+			Contents:  note.Contents,
+			Tags:      jsonTags(note.Tags),
+			Created:   formatJSONTime(note.CreatedAt),
+			Updated:   formatJSONTime(note.UpdatedAt),
+			Revisions: len(note.Revisions),
+		})
+	}
+	for _, adjustment := range entry.Adjustments {
+		data.Adjustments = append(data.Adjustments, adjustmentJSON{Minutes: minutes(adjustment.Amount), Reason: adjustment.Reason})
+	}
+	for _, finding := range entry.Findings {
+		data.Findings = append(data.Findings, findingJSON{
+			Code:         finding.Code,
+			Severity:     string(finding.Severity),
+			Message:      finding.Message,
+			Acknowledged: finding.Acknowledged,
+		})
+	}
+	return data
+}
+
+// newPeriodJSON builds the JSON form of the days from first to last, where
+// worked and target are the totals of the report.
+func newPeriodJSON(period string, first, last time.Time, entries []journal.JournalEntry, worked, target time.Duration, schedule workSchedule) periodJSON {
+	adjusted := adjustmentTotal(entries)
+	data := periodJSON{
+		Period:          period,
+		From:            first.Format("2006-01-02"),
+		To:              last.Format("2006-01-02"),
+		WorkedMinutes:   minutes(worked),
+		TargetMinutes:   minutes(target),
+		AdjustedMinutes: minutes(adjusted),
+		BalanceMinutes:  minutes(worked - target + adjusted),
+		Days:            []entryJSON{},
+	}
+	for _, entry := range entries {
+		data.Days = append(data.Days, newEntryJSON(entry, schedule))
+	}
+	return data
+}
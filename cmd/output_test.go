@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+// captureStdout returns what fn prints to stdout.
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	if err := fn(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func setOutputFormat(t *testing.T, format string) {
+	t.Helper()
+	saved := outputFormat
+	outputFormat = format
+	t.Cleanup(func() { outputFormat = saved })
+}
+
+func TestResolveOutput(t *testing.T) {
+	tests := []struct {
+		flag    string
+		want    string
+		wantErr bool
+	}{
+		// go test does not run with a terminal on stdout
+		{flag: "", want: outputText},
+		{flag: "text", want: outputText},
+		{flag: "json", want: outputJSON},
+		{flag: "none", want: outputNone},
+		{flag: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			setOutputFormat(t, tt.flag)
+			got, err := resolveOutput()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("resolveOutput() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestShowReport(t *testing.T) {
+	var schedule workSchedule
+	schedule[time.Tuesday].MinWorkTime = 8 * time.Hour
+	start := time.Date(2026, 4, 7, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 4, 7, 17, 30, 0, 0, time.UTC)
+	entry := journal.JournalEntry{
+		ID:        "20260407",
+		StartTime: start,
+		EndTime:   end,
+		Sessions:  []journal.Session{{StartTime: start, EndTime: end}},
+		Breaks:    []journal.Break{{StartTime: start.Add(3 * time.Hour), EndTime: start.Add(3*time.Hour + 30*time.Minute), Reason: "lunch"}},
+	}
+	model := &reportModel{entry: &entry, date: start}
+
+	t.Run("json", func(t *testing.T) {
+		setOutputFormat(t, outputJSON)
+		out := captureStdout(t, func() error { return showReport(model, newEntryJSON(entry, schedule)) })
+
+		var got map[string]interface{}
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatalf("invalid JSON %q: %v", out, err)
+		}
+		if got["id"] != "20260407" || got["date"] != "2026-04-07" || got["start"] != "2026-04-07T09:00:00Z" {
+			t.Errorf("unexpected entry fields: %v", got)
+		}
+		if got["worked_minutes"] != float64(480) || got["target_minutes"] != float64(480) {
+			t.Errorf("worked %v, target %v, want 480 and 480", got["worked_minutes"], got["target_minutes"])
+		}
+		for _, key := range []string{"sessions", "breaks", "notes", "adjustments", "findings"} {
+			if _, ok := got[key].([]interface{}); !ok {
+				t.Errorf("expected %s to be a list, got %v", key, got[key])
+			}
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		setOutputFormat(t, outputText)
+		out := captureStdout(t, func() error { return showReport(model, nil) })
+		if !strings.Contains(out, "Workday Report - Tuesday, April 7, 2026") {
+			t.Errorf("expected the report in the output, got %q", out)
+		}
+		if strings.Contains(out, "Press 'q'") {
+			t.Errorf("expected no quit hint in text output, got %q", out)
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		setOutputFormat(t, outputNone)
+		if out := captureStdout(t, func() error { return showReport(model, entry) }); out != "" {
+			t.Errorf("expected no output, got %q", out)
+		}
+	})
+}
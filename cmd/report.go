@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,7 +10,6 @@ import (
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var reportDate string
@@ -59,8 +59,11 @@ func (m reportModel) View() string {
 	if m.quitting {
 		return ""
 	}
+	return m.render(renderOptions{Interactive: true})
+}
 
-
+// render renders the view, see renderOptions.
+func (m reportModel) render(opts renderOptions) string {
 	var content strings.Builder
 
 	// Title
@@ -68,6 +71,11 @@ func (m reportModel) View() string {
 	content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("Workday Report - %s", dateStr)))
 	content.WriteString("\n\n")
 
+	if m.entry.IsDayOff() {
+		content.WriteString(styles.LabelStyle.Render("Day off:") + " " + styles.InfoBlueStyle.Render(dayOffLabel(*m.entry)))
+		content.WriteString("\n\n")
+	}
+
 	// Work Hours Section
 	content.WriteString(styles.SectionStyle.Render("⏰ Work Hours"))
 	content.WriteString("\n")
@@ -78,23 +86,19 @@ func (m reportModel) View() string {
 
 	endTime := "Ongoing"
 	if !m.entry.EndTime.IsZero() {
-		endTime = m.entry.EndTime.Format("15:04")
+		endTime = journal.FormatClockTime(m.entry.EndTime, m.entry.StartTime)
 	}
 	content.WriteString(styles.LabelStyle.Render("End:") + " " + styles.ValueStyle.Render(endTime))
 	content.WriteString("\n")
 
-	// Calculate work duration
-	var duration time.Duration
-	if !m.entry.EndTime.IsZero() {
-		duration = m.entry.EndTime.Sub(m.entry.StartTime)
-
-		// Subtract break time
-		for _, br := range m.entry.Breaks {
-			if !br.EndTime.IsZero() {
-				duration -= br.EndTime.Sub(br.StartTime)
-			}
-		}
+	if sessions := m.entry.WorkSessions(); len(sessions) > 1 {
+		content.WriteString(styles.LabelStyle.Render("Sessions:") + " " + styles.ValueStyle.Render(formatSessions(sessions, m.entry.StartTime)))
+		content.WriteString("\n")
+	}
 
+	// Calculate work duration, summed over the sessions
+	if !m.entry.EndTime.IsZero() {
+		duration := m.entry.TotalWorkTime()
 		hours := int(duration.Hours())
 		minutes := int(duration.Minutes()) % 60
 		content.WriteString(styles.LabelStyle.Render("Duration:") + " " + styles.ValueStyle.Render(fmt.Sprintf("%dh %dm", hours, minutes)))
@@ -103,61 +107,65 @@ func (m reportModel) View() string {
 	}
 	content.WriteString("\n")
 
-	// Breaks Section
-	if len(m.entry.Breaks) > 0 {
+	// Timeline Section, the breaks and notes in the order they happened
+	if timeline := m.entry.Timeline(); len(timeline) > 0 {
 		content.WriteString("\n")
-		content.WriteString(styles.SectionStyle.Render("☕ Breaks"))
+		content.WriteString(styles.SectionStyle.Render("🕒 Timeline"))
 		content.WriteString("\n")
 
-		for i, br := range m.entry.Breaks {
-			startTime := br.StartTime.Format("15:04")
-			endTime := "Ongoing"
-			if !br.EndTime.IsZero() {
-				endTime = br.EndTime.Format("15:04")
+		for _, item := range timeline {
+			at := "     "
+			if !item.Time.IsZero() {
+				at = journal.FormatClockTime(item.Time, m.entry.StartTime)
 			}
 
-			breakText := fmt.Sprintf("%d. %s - %s", i+1, startTime, endTime)
-			if br.Reason != "" {
-				breakText += fmt.Sprintf(" (%s)", br.Reason)
+			if br := item.Break; br != nil {
+				endTime := "Ongoing"
+				if !br.EndTime.IsZero() {
+					endTime = journal.FormatClockTime(br.EndTime, m.entry.StartTime)
+				}
+				breakText := fmt.Sprintf("%s ☕ Break until %s", at, endTime)
+				if br.Reason != "" {
+					breakText += fmt.Sprintf(" (%s)", br.Reason)
+				}
+				content.WriteString(styles.BreakStyle.Render(breakText))
+				content.WriteString("\n")
+				continue
 			}
 
-			content.WriteString(styles.BreakStyle.Render(breakText))
-			content.WriteString("\n")
-		}
-	}
-
-	// Notes Section
-	if len(m.entry.Notes) > 0 {
-		content.WriteString("\n")
-		content.WriteString(styles.SectionStyle.Render("📝 Notes"))
-		content.WriteString("\n")
-
-		for i, note := range m.entry.Notes {
-			noteText := fmt.Sprintf("%d. %s", i+1, note.Contents)
+			note := item.Note
+			noteText := fmt.Sprintf("%s %d. %s", at, item.Index+1, note.Contents)
 			if len(note.Tags) > 0 {
 				noteText += fmt.Sprintf(" [%s]", strings.Join(note.Tags, ", "))
 			}
+			if !note.UpdatedAt.IsZero() {
+				noteText += " (edited)"
+			}
 			content.WriteString(styles.NoteStyle.Render(noteText))
 			content.WriteString("\n")
 		}
 	}
 
+	// Findings Section
+	if len(m.entry.Findings) > 0 {
+		content.WriteString("\n")
+		content.WriteString(styles.SectionStyle.Render("🔍 Findings"))
+		content.WriteString("\n")
+		content.WriteString(renderFindings(m.entry.Findings))
+	}
+
 	// Help
 	content.WriteString("\n")
-	content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
 
 	return content.String()
 }
 
 func reportWorkDay(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	journalEntries, err := journal.LoadEntries(journalPath)
-	if err != nil {
-		return err
-	}
-
-	var tgtEntry *journal.JournalEntry
-	tgtDay := time.Now()
+	var err error
+	tgtDay := currentTime()
 
 	if reportDate != "" {
 		tgtDay, err = time.Parse("2006-01-02", reportDate)
@@ -167,19 +175,60 @@ func reportWorkDay(cmd *cobra.Command, args []string) error {
 	}
 
 	tgtDayID := tgtDay.Format("20060102")
-	tgtEntry, _ = journal.FetchEntryByID(tgtDayID, journalEntries)
-	if tgtEntry == nil {
+	tgtEntry, err := loadEntry(tgtDayID)
+	if errors.Is(err, journal.ErrEntryNotFound) {
 		return fmt.Errorf("Could not find any entry for the date: %s", reportDate)
 	}
+	if err != nil {
+		return err
+	}
+
+	schedule, err := loadSchedule()
+	if err != nil {
+		return err
+	}
 
 	model := reportModel{
 		entry: tgtEntry,
 		date:  tgtDay,
 	}
 
-	p := tea.NewProgram(&model)
-	_, err = p.Run()
-	return err
+	return showReport(&model, newEntryJSON(*tgtEntry, schedule))
+}
+
+// formatSessions lists sessions as "08:00-12:00, 20:00-22:00", with the clock
+// times relative to day.
+func formatSessions(sessions []journal.Session, day time.Time) string {
+	parts := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		end := "ongoing"
+		if !session.IsOpen() {
+			end = journal.FormatClockTime(session.EndTime, day)
+		}
+		parts = append(parts, fmt.Sprintf("%s-%s", journal.FormatClockTime(session.StartTime, day), end))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// periodWorkTime sums the work time of entries that falls on the days from
+// first to last, inclusive, so a shift that runs past midnight counts towards
+// each day it was worked on. Finished entries that started in the period and
+// have no recorded breaks have the lunch time of their weekday taken off.
+func periodWorkTime(entries []journal.JournalEntry, first, last time.Time, schedule workSchedule) time.Duration {
+	from := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, first.Location())
+	to := time.Date(last.Year(), last.Month(), last.Day()+1, 0, 0, 0, 0, last.Location())
+
+	var total time.Duration
+	for _, entry := range entries {
+		total += entry.WorkTimeBetween(from, to)
+
+		// If no breaks were recorded, subtract the scheduled lunch time
+		started := entry.StartTime
+		if len(entry.Breaks) == 0 && !entry.EndTime.IsZero() && len(entry.WorkSessions()) > 0 && !started.Before(from) && started.Before(to) {
+			total -= schedule.For(started).LunchTime
+		}
+	}
+	return total
 }
 
 func init() {
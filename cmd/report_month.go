@@ -10,7 +10,6 @@ import (
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // reportMonthCmd represents the report command for generating a report for the current month.
@@ -28,6 +27,9 @@ type reportMonthModel struct {
 	entries       []journal.JournalEntry
 	month         time.Time
 	totalWorkTime time.Duration
+	schedule      workSchedule
+	target        time.Duration
+	adjusted      time.Duration
 	width         int
 	height        int
 	quitting      bool
@@ -58,8 +60,11 @@ func (m reportMonthModel) View() string {
 	if m.quitting {
 		return ""
 	}
+	return m.render(renderOptions{Interactive: true})
+}
 
-
+// render renders the view, see renderOptions.
+func (m reportMonthModel) render(opts renderOptions) string {
 	var content strings.Builder
 
 	// Title
@@ -68,7 +73,7 @@ func (m reportMonthModel) View() string {
 	content.WriteString("\n\n")
 
 	// Create table data
-	headers := []string{"Date", "Start", "End", "Duration", "Breaks"}
+	headers := []string{"Date", "Start", "End", "Duration", "Target", "Breaks"}
 	rows := [][]string{}
 
 	// Add entries to rows
@@ -80,25 +85,31 @@ func (m reportMonthModel) View() string {
 		endTime := "Ongoing"
 		duration := "In progress"
 		if !entry.EndTime.IsZero() {
-			endTime = entry.EndTime.Format("15:04")
-			
-			// Calculate work duration
-			workDuration := entry.EndTime.Sub(entry.StartTime)
-			
-			// Subtract break time
-			var totalBreakTime time.Duration
-			for _, br := range entry.Breaks {
-				if !br.EndTime.IsZero() {
-					totalBreakTime += br.EndTime.Sub(br.StartTime)
-				}
-			}
+			endTime = journal.FormatClockTime(entry.EndTime, entry.StartTime)
 			
-			workDuration -= totalBreakTime
+			// Calculate work duration, summed over the sessions
+			workDuration := entry.TotalWorkTime()
 			hours := int(workDuration.Hours())
 			minutes := int(workDuration.Minutes()) % 60
 			duration = fmt.Sprintf("%dh %dm", hours, minutes)
+			if sessions := len(entry.WorkSessions()); sessions > 1 {
+				duration += fmt.Sprintf(" (%d sessions)", sessions)
+			}
 		}
 		
+		// A day off shows its type, instead of times when nothing was worked
+		if len(entry.WorkSessions()) == 0 {
+			startTime, endTime, duration = "--", "--", "--"
+			if entry.IsDayOff() {
+				duration = dayOffLabel(entry)
+			}
+		} else if entry.IsDayOff() {
+			duration += " · " + entry.DayType.Label()
+		}
+		if adjusted := entry.AdjustmentTotal(); adjusted != 0 {
+			duration += fmt.Sprintf(" (%s adjusted)", formatSignedDuration(adjusted))
+		}
+
 		// Format breaks (simplified for monthly view)
 		breakInfo := "--"
 		if len(entry.Breaks) > 0 {
@@ -109,7 +120,7 @@ func (m reportMonthModel) View() string {
 			}
 		}
 		
-		rows = append(rows, []string{date, startTime, endTime, duration, breakInfo})
+		rows = append(rows, []string{date, startTime, endTime, duration, formatTarget(m.schedule.TargetFor(&entry)), breakInfo})
 	}
 
 	// Calculate column widths
@@ -188,7 +199,7 @@ func (m reportMonthModel) View() string {
 	// Summary Section
 	workDays := 0
 	for _, entry := range m.entries {
-		if !entry.EndTime.IsZero() {
+		if !entry.EndTime.IsZero() && len(entry.WorkSessions()) > 0 {
 			workDays++
 		}
 	}
@@ -196,30 +207,43 @@ func (m reportMonthModel) View() string {
 	content.WriteString(styles.SummaryStyle.Render(fmt.Sprintf("📊 Total work time: %v across %d days",
 		m.totalWorkTime, workDays)))
 	content.WriteString("\n")
+	targetLine := fmt.Sprintf("🎯 Target: %s, %s", formatDuration(m.target), formatBalance(m.totalWorkTime-m.target+m.adjusted))
+	if m.adjusted != 0 {
+		targetLine += fmt.Sprintf(" (including %s of adjustments)", formatSignedDuration(m.adjusted))
+	}
+	content.WriteString(styles.SummaryStyle.Render(targetLine))
+	content.WriteString("\n")
+	if line := findingsSummary(m.entries); line != "" {
+		content.WriteString(styles.ErrorStyle.Render(line))
+		content.WriteString("\n")
+	}
 
-	// Help
-	content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
 
 	return content.String()
 }
 
 func reportMonth(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
-	if err != nil {
-		return err
-	}
-
 	// Check if the month flag has been set
 	monthFlag, _ := cmd.Flags().GetString("month")
-	monthFilter := time.Now()
+	monthFilter := currentTime()
 	if monthFlag != "" {
-		monthFilter, err = time.Parse("2006-01", monthFlag)
+		var err error
+		monthFilter, err = time.ParseInLocation("2006-01", monthFlag, time.Local)
 		if err != nil {
 			return errors.New("invalid month format, expected YYYY-MM")
 		}
 	}
 
+	first, last := journal.MonthBounds(monthFilter)
+	// Include the day before the month, whose night shift may run into it
+	entries, err := loadEntriesInRange(first.AddDate(0, 0, -1), last)
+	if err != nil {
+		return err
+	}
+
 	currMonth, err := journal.FetchEntriesByMonthDate(entries, monthFilter)
 	if err != nil {
 		return err
@@ -229,43 +253,24 @@ func reportMonth(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no entries found for %s", monthFilter.Format("January 2006"))
 	}
 
-	lunchTime, err := time.ParseDuration(viper.GetString("lunchTime"))
+	schedule, err := loadSchedule()
 	if err != nil {
 		return err
 	}
 
-	var totalWorkTime time.Duration
-	for _, entry := range currMonth {
-		if !entry.EndTime.IsZero() {
-			dayWorkTime := entry.EndTime.Sub(entry.StartTime)
-			var totalBreakTime time.Duration
-
-			// Calculate total breaktime
-			for _, br := range entry.Breaks {
-				if !br.EndTime.IsZero() {
-					totalBreakTime += br.EndTime.Sub(br.StartTime)
-				}
-			}
-
-			// If no breaks were recorded, subtract default lunchTime
-			if len(entry.Breaks) == 0 {
-				totalBreakTime = lunchTime
-			}
-
-			dayWorkTime -= totalBreakTime
-			totalWorkTime += dayWorkTime
-		}
-	}
+	totalWorkTime := periodWorkTime(entries, first, last, schedule)
 
 	model := reportMonthModel{
 		entries:       currMonth,
 		month:         monthFilter,
 		totalWorkTime: totalWorkTime,
+		schedule:      schedule,
+		target:        schedule.Target(first, last, currMonth),
+		adjusted:      adjustmentTotal(currMonth),
 	}
 
-	p := tea.NewProgram(&model)
-	_, err = p.Run()
-	return err
+	data := newPeriodJSON(monthFilter.Format("2006-01"), first, last, currMonth, model.totalWorkTime, model.target, schedule)
+	return showReport(&model, data)
 }
 
 func init() {
@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+// reportProjectsCmd represents the report command for tracked time per client, project and task.
+var reportProjectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Generates a report of tracked time per client, project and task",
+	Long: `The projects command aggregates the time segments recorded with 'workday track'
+by client, project and task, showing each one's share of the total tracked time.
+
+Work time that is not covered by any time segment is reported as untracked.
+Without flags the report covers the current day.
+
+Examples:
+  workday report projects
+  workday report projects --week
+  workday report projects --month
+  workday report projects --from 2026-01-01 --to 2026-03-31`,
+	RunE: reportProjects,
+}
+
+type reportProjectsModel struct {
+	period   string
+	summary  journal.TrackedTimeSummary
+	width    int
+	height   int
+	quitting bool
+}
+
+func (m reportProjectsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m reportProjectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m reportProjectsModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	return m.render(renderOptions{Interactive: true})
+}
+
+// render renders the view, see renderOptions.
+func (m reportProjectsModel) render(opts renderOptions) string {
+	var content strings.Builder
+
+	// Title
+	content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("🗂️  Project Report - %s", m.period)))
+	content.WriteString("\n\n")
+
+	if len(m.summary.Tasks) == 0 {
+		content.WriteString(styles.InfoStyle.Render("No time segments tracked in this period"))
+		content.WriteString("\n")
+	} else {
+		share := func(d time.Duration) string {
+			return fmt.Sprintf("%.1f%%", m.summary.Share(d))
+		}
+
+		// By Client
+		content.WriteString(styles.SectionStyle.Render("🏢 By Client"))
+		content.WriteString("\n")
+		var rows [][]string
+		for _, a := range m.summary.ByClient() {
+			rows = append(rows, []string{a.Client, formatDuration(a.Duration), share(a.Duration)})
+		}
+		content.WriteString(renderTable([]string{"Client", "Time", "Share"}, rows))
+
+		// By Project
+		content.WriteString(styles.SectionStyle.Render("📁 By Project"))
+		content.WriteString("\n")
+		rows = nil
+		for _, a := range m.summary.ByProject() {
+			rows = append(rows, []string{a.Client, a.Project, formatDuration(a.Duration), share(a.Duration)})
+		}
+		content.WriteString(renderTable([]string{"Client", "Project", "Time", "Share"}, rows))
+
+		// By Task
+		content.WriteString(styles.SectionStyle.Render("📌 By Task"))
+		content.WriteString("\n")
+		rows = nil
+		for _, a := range m.summary.Tasks {
+			rows = append(rows, []string{a.Client, a.Project, a.Task, formatDuration(a.Duration), share(a.Duration)})
+		}
+		content.WriteString(renderTable([]string{"Client", "Project", "Task", "Time", "Share"}, rows))
+	}
+
+	// Summary Section
+	content.WriteString(styles.SummaryStyle.Render(fmt.Sprintf("📊 Tracked %s of %s work time",
+		formatDuration(m.summary.Tracked), formatDuration(m.summary.WorkTime))))
+	content.WriteString("\n")
+
+	if m.summary.Untracked > 0 {
+		content.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("⚠️  Untracked work time: %s", formatDuration(m.summary.Untracked))))
+	} else {
+		content.WriteString(styles.SuccessStyle.Render("✅ All work time is tracked"))
+	}
+	content.WriteString("\n")
+
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
+
+	return content.String()
+}
+
+// renderTable draws a bordered table using the shared header and cell styles,
+// with every column sized to its widest value.
+func renderTable(headers []string, rows [][]string) string {
+	// Calculate column widths, in cells rather than bytes
+	colWidths := make([]int, len(headers))
+	for i, header := range headers {
+		colWidths[i] = lipgloss.Width(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if width := lipgloss.Width(cell); width > colWidths[i] {
+				colWidths[i] = width
+			}
+		}
+	}
+
+	// Add padding to column widths
+	for i := range colWidths {
+		colWidths[i] += 2
+	}
+
+	border := func(left, mid, right string) string {
+		var line strings.Builder
+		line.WriteString(left)
+		for i, width := range colWidths {
+			line.WriteString(strings.Repeat("─", width))
+			if i < len(colWidths)-1 {
+				line.WriteString(mid)
+			}
+		}
+		line.WriteString(right + "\n")
+		return line.String()
+	}
+
+	var table strings.Builder
+	table.WriteString(border("┌", "┬", "┐"))
+
+	// Header row
+	table.WriteString("│")
+	for i, header := range headers {
+		table.WriteString(styles.HeaderStyle.Width(colWidths[i]).Render(header))
+		table.WriteString("│")
+	}
+	table.WriteString("\n")
+	table.WriteString(border("├", "┼", "┤"))
+
+	// Data rows
+	for _, row := range rows {
+		table.WriteString("│")
+		for i, cell := range row {
+			table.WriteString(styles.CellStyle.Width(colWidths[i]).Render(cell))
+			table.WriteString("│")
+		}
+		table.WriteString("\n")
+	}
+
+	table.WriteString(border("└", "┴", "┘"))
+	return table.String()
+}
+
+// resolveReportPeriod turns the --week, --month and --from/--to flags into an
+// inclusive date range and a human readable label. With no flags set the range
+// is the day of now. The flags are mutually exclusive, and --from/--to must be
+// given together.
+func resolveReportPeriod(week, month bool, fromStr, toStr string, now time.Time) (time.Time, time.Time, string, error) {
+	rangeSet := fromStr != "" || toStr != ""
+	selected := 0
+	for _, set := range []bool{week, month, rangeSet} {
+		if set {
+			selected++
+		}
+	}
+	if selected > 1 {
+		return time.Time{}, time.Time{}, "", fmt.Errorf("--week, --month and --from/--to cannot be combined")
+	}
+
+	switch {
+	case week:
+		from, to := journal.WeekBounds(now)
+		return from, to, fmt.Sprintf("%s - %s", from.Format("Jan 2"), to.Format("Jan 2, 2006")), nil
+	case month:
+		from, to := journal.MonthBounds(now)
+		return from, to, now.Format("January 2006"), nil
+	case rangeSet:
+		if fromStr == "" || toStr == "" {
+			return time.Time{}, time.Time{}, "", fmt.Errorf("--from and --to must be used together")
+		}
+		from, err := time.ParseInLocation("2006-01-02", fromStr, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, "", fmt.Errorf("invalid --from date format. Use YYYY-MM-DD")
+		}
+		to, err := time.ParseInLocation("2006-01-02", toStr, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, "", fmt.Errorf("invalid --to date format. Use YYYY-MM-DD")
+		}
+		if from.After(to) {
+			return time.Time{}, time.Time{}, "", fmt.Errorf("--from date must not be after --to date")
+		}
+		return from, to, fmt.Sprintf("%s - %s", from.Format("Jan 2, 2006"), to.Format("Jan 2, 2006")), nil
+	default:
+		return now, now, now.Format("Monday, January 2, 2006"), nil
+	}
+}
+
+func reportProjects(cmd *cobra.Command, args []string) error {
+	week, _ := cmd.Flags().GetBool("week")
+	month, _ := cmd.Flags().GetBool("month")
+	fromStr, _ := cmd.Flags().GetString("from")
+	toStr, _ := cmd.Flags().GetString("to")
+
+	from, to, period, err := resolveReportPeriod(week, month, fromStr, toStr, currentTime())
+	if err != nil {
+		return err
+	}
+
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	periodEntries, err := store.Range(from, to)
+	if err != nil {
+		return err
+	}
+
+	model := reportProjectsModel{
+		period:  period,
+		summary: journal.SummarizeTimeSegments(periodEntries),
+	}
+
+	data := projectsJSON{
+		Period:           period,
+		TrackedMinutes:   minutes(model.summary.Tracked),
+		WorkedMinutes:    minutes(model.summary.WorkTime),
+		UntrackedMinutes: minutes(model.summary.Untracked),
+		Clients:          []allocationJSON{},
+		Projects:         []allocationJSON{},
+		Tasks:            []allocationJSON{},
+	}
+	for _, a := range model.summary.ByClient() {
+		data.Clients = append(data.Clients, allocationJSON{Client: a.Client, Minutes: minutes(a.Duration)})
+	}
+	for _, a := range model.summary.ByProject() {
+		data.Projects = append(data.Projects, allocationJSON{Client: a.Client, Project: a.Project, Minutes: minutes(a.Duration)})
+	}
+	for _, a := range model.summary.Tasks {
+		data.Tasks = append(data.Tasks, allocationJSON{Client: a.Client, Project: a.Project, Task: a.Task, Minutes: minutes(a.Duration)})
+	}
+	return showReport(&model, data)
+}
+
+func init() {
+	reportProjectsCmd.Flags().Bool("week", false, "Report on the current week")
+	reportProjectsCmd.Flags().Bool("month", false, "Report on the current month")
+	reportProjectsCmd.Flags().String("from", "", "Start of the report range in YYYY-MM-DD format")
+	reportProjectsCmd.Flags().String("to", "", "End of the report range in YYYY-MM-DD format")
+	reportCmd.AddCommand(reportProjectsCmd)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveReportPeriod(t *testing.T) {
+	// Wednesday, 2026-04-01
+	now := time.Date(2026, 4, 1, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		week     bool
+		month    bool
+		from     string
+		to       string
+		wantFrom string
+		wantTo   string
+		wantErr  bool
+	}{
+		{name: "defaults to today", wantFrom: "2026-04-01", wantTo: "2026-04-01"},
+		{name: "week starts on Monday", week: true, wantFrom: "2026-03-30", wantTo: "2026-04-05"},
+		{name: "month covers every day", month: true, wantFrom: "2026-04-01", wantTo: "2026-04-30"},
+		{name: "explicit range", from: "2026-01-01", to: "2026-03-31", wantFrom: "2026-01-01", wantTo: "2026-03-31"},
+		{name: "from without to", from: "2026-01-01", wantErr: true},
+		{name: "from after to", from: "2026-03-31", to: "2026-01-01", wantErr: true},
+		{name: "invalid date", from: "2026/01/01", to: "2026-03-31", wantErr: true},
+		{name: "week and month combined", week: true, month: true, wantErr: true},
+		{name: "month and range combined", month: true, from: "2026-01-01", to: "2026-03-31", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, _, err := resolveReportPeriod(tt.week, tt.month, tt.from, tt.to, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := from.Format("2006-01-02"); got != tt.wantFrom {
+				t.Errorf("from = %s, want %s", got, tt.wantFrom)
+			}
+			if got := to.Format("2006-01-02"); got != tt.wantTo {
+				t.Errorf("to = %s, want %s", got, tt.wantTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+// reportRangeCmd represents the report command for an arbitrary range of days.
+var reportRangeCmd = &cobra.Command{
+	Use:   "range",
+	Short: "Generates a report for a range of days",
+	Long: `The range command generates a report for the days from --from to --to,
+both inclusive, such as a quarter or a whole year.
+
+The days are grouped with --group: by day (the default), by ISO week from
+Monday to Sunday, or by month. Every group shows the time worked, the target
+of the schedule and the resulting balance.
+
+Examples:
+  workday report range --from 2026-01-01 --to 2026-03-31
+  workday report range --from 2026-01-01 --to 2026-12-31 --group month`,
+	RunE: reportRange,
+}
+
+// reportGroup is one day, week or month of a range report.
+type reportGroup struct {
+	Label       string
+	First, Last time.Time              // days of the group within the range
+	Entries     []journal.JournalEntry // entries that start in the group
+	Worked      time.Duration          // time worked, lunch deducted as in the other reports
+	Target      time.Duration          // target of the schedule
+	Adjusted    time.Duration          // sum of the manual adjustments
+}
+
+// Balance returns the difference between the time worked and the target,
+// including the adjustments.
+func (g reportGroup) Balance() time.Duration {
+	return g.Worked - g.Target + g.Adjusted
+}
+
+// groupEnd returns the last day of the day, week or month group that starts
+// on day.
+func groupEnd(day time.Time, by string) (time.Time, error) {
+	switch by {
+	case "day":
+		return day, nil
+	case "week":
+		_, sunday := journal.WeekBounds(day)
+		return sunday, nil
+	case "month":
+		_, last := journal.MonthBounds(day)
+		return last, nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid grouping '%s', expected day, week or month", by)
+	}
+}
+
+// groupLabel names the group of days from first to last.
+func groupLabel(first, last time.Time, by string) string {
+	switch by {
+	case "week":
+		year, week := first.ISOWeek()
+		return fmt.Sprintf("%d-W%02d (%s - %s)", year, week, first.Format("Jan 2"), last.Format("Jan 2"))
+	case "month":
+		return first.Format("January 2006")
+	default:
+		return first.Format("Mon, Jan 2 2006")
+	}
+}
+
+// groupReportRange splits the days from first to last, inclusive, into groups
+// by day, week or month and sums the work of each. Groups at the edges are
+// cut to the range. With grouping by day, days without an entry and without a
+// target are left out.
+func groupReportRange(entries []journal.JournalEntry, first, last time.Time, by string, schedule workSchedule) ([]reportGroup, error) {
+	var groups []reportGroup
+	for from := first; !from.After(last); {
+		to, err := groupEnd(from, by)
+		if err != nil {
+			return nil, err
+		}
+		if to.After(last) {
+			to = last
+		}
+
+		// An error only means the group has no entries
+		groupEntries, _ := journal.FetchEntriesByRange(entries, from, to)
+		group := reportGroup{
+			Label:    groupLabel(from, to, by),
+			First:    from,
+			Last:     to,
+			Entries:  groupEntries,
+			Worked:   periodWorkTime(entries, from, to, schedule),
+			Target:   schedule.Target(from, to, groupEntries),
+			Adjusted: adjustmentTotal(groupEntries),
+		}
+		if by != "day" || len(groupEntries) > 0 || group.Target > 0 {
+			groups = append(groups, group)
+		}
+		from = to.AddDate(0, 0, 1)
+	}
+	return groups, nil
+}
+
+type reportRangeModel struct {
+	first, last time.Time
+	by          string
+	groups      []reportGroup
+	entries     []journal.JournalEntry // entries that start in the range
+	width       int
+	height      int
+	quitting    bool
+}
+
+func (m reportRangeModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m reportRangeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m reportRangeModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	return m.render(renderOptions{Interactive: true})
+}
+
+// render renders the view, see renderOptions.
+func (m reportRangeModel) render(opts renderOptions) string {
+	var content strings.Builder
+
+	// Title
+	content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("📈 Range Report - %s - %s",
+		m.first.Format("Jan 2, 2006"), m.last.Format("Jan 2, 2006"))))
+	content.WriteString("\n\n")
+
+	// Groups
+	var total reportGroup
+	var rows [][]string
+	for _, group := range m.groups {
+		worked := formatDuration(group.Worked)
+		if m.by == "day" && len(group.Entries) == 1 && len(group.Entries[0].WorkSessions()) == 0 {
+			worked = "--"
+			if group.Entries[0].IsDayOff() {
+				worked = dayOffLabel(group.Entries[0])
+			}
+		}
+		adjusted := "--"
+		if group.Adjusted != 0 {
+			adjusted = formatSignedDuration(group.Adjusted)
+		}
+		rows = append(rows, []string{group.Label, worked, formatTarget(group.Target), adjusted, formatBalance(group.Balance())})
+
+		total.Worked += group.Worked
+		total.Target += group.Target
+		total.Adjusted += group.Adjusted
+	}
+	header := strings.ToUpper(m.by[:1]) + m.by[1:]
+	content.WriteString(renderTable([]string{header, "Worked", "Target", "Adjusted", "Balance"}, rows))
+
+	// Summary Section
+	workDays := 0
+	for _, entry := range m.entries {
+		if !entry.EndTime.IsZero() && len(entry.WorkSessions()) > 0 {
+			workDays++
+		}
+	}
+	content.WriteString(styles.SummaryStyle.Render(fmt.Sprintf("📊 Total work time: %v across %d days",
+		total.Worked, workDays)))
+	content.WriteString("\n")
+	targetLine := fmt.Sprintf("🎯 Target: %s, %s", formatDuration(total.Target), formatBalance(total.Balance()))
+	if total.Adjusted != 0 {
+		targetLine += fmt.Sprintf(" (including %s of adjustments)", formatSignedDuration(total.Adjusted))
+	}
+	content.WriteString(styles.SummaryStyle.Render(targetLine))
+	content.WriteString("\n")
+	if line := findingsSummary(m.entries); line != "" {
+		content.WriteString(styles.ErrorStyle.Render(line))
+		content.WriteString("\n")
+	}
+
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
+
+	return content.String()
+}
+
+func reportRange(cmd *cobra.Command, args []string) error {
+	fromStr, _ := cmd.Flags().GetString("from")
+	toStr, _ := cmd.Flags().GetString("to")
+	by, _ := cmd.Flags().GetString("group")
+	if fromStr == "" || toStr == "" {
+		return fmt.Errorf("both --from and --to are required")
+	}
+
+	first, last, _, err := resolveReportPeriod(false, false, fromStr, toStr, currentTime())
+	if err != nil {
+		return err
+	}
+	if _, err := groupEnd(first, by); err != nil {
+		return err
+	}
+
+	// Include the day before the range, whose night shift may run into it
+	entries, err := loadEntriesInRange(first.AddDate(0, 0, -1), last)
+	if err != nil {
+		return err
+	}
+	rangeEntries, err := journal.FetchEntriesByRange(entries, first, last)
+	if err != nil {
+		return err
+	}
+
+	schedule, err := loadSchedule()
+	if err != nil {
+		return err
+	}
+	groups, err := groupReportRange(entries, first, last, by, schedule)
+	if err != nil {
+		return err
+	}
+
+	model := reportRangeModel{
+		first:   first,
+		last:    last,
+		by:      by,
+		groups:  groups,
+		entries: rangeEntries,
+	}
+
+	var worked, target time.Duration
+	groupsJSON := make([]periodJSON, 0, len(groups))
+	for _, group := range groups {
+		worked += group.Worked
+		target += group.Target
+		groupsJSON = append(groupsJSON, newPeriodJSON(group.Label, group.First, group.Last, group.Entries, group.Worked, group.Target, schedule))
+	}
+	data := newPeriodJSON(fmt.Sprintf("%s/%s", fromStr, toStr), first, last, rangeEntries, worked, target, schedule)
+	data.Groups = groupsJSON
+	return showReport(&model, data)
+}
+
+func init() {
+	reportRangeCmd.Flags().String("from", "", "Start of the report range in YYYY-MM-DD format")
+	reportRangeCmd.Flags().String("to", "", "End of the report range in YYYY-MM-DD format")
+	reportRangeCmd.Flags().StringP("group", "g", "day", "Group the days by day, week or month")
+	reportCmd.AddCommand(reportRangeCmd)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+func TestGroupReportRange(t *testing.T) {
+	var schedule workSchedule
+	for day := time.Monday; day <= time.Friday; day++ {
+		schedule[day].MinWorkTime = 8 * time.Hour
+	}
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	worked := func(month time.Month, day, hours int) journal.JournalEntry {
+		start := at(month, day, 9)
+		end := start.Add(time.Duration(hours) * time.Hour)
+		return journal.JournalEntry{
+			ID:        start.Format("20060102"),
+			StartTime: start,
+			EndTime:   end,
+			Sessions:  []journal.Session{{StartTime: start, EndTime: end}},
+		}
+	}
+	// Tuesday and Thursday of the last week of March, Wednesday of the next
+	entries := []journal.JournalEntry{worked(3, 24, 9), worked(3, 26, 7), worked(4, 1, 8)}
+	first, last := at(3, 24, 0), at(4, 1, 0)
+
+	tests := []struct {
+		by      string
+		labels  []string
+		worked  []time.Duration
+		targets []time.Duration
+		wantErr bool
+	}{
+		{
+			by:      "day",
+			labels:  []string{"Tue, Mar 24 2026", "Wed, Mar 25 2026", "Thu, Mar 26 2026", "Fri, Mar 27 2026", "Mon, Mar 30 2026", "Tue, Mar 31 2026", "Wed, Apr 1 2026"},
+			worked:  []time.Duration{9 * time.Hour, 0, 7 * time.Hour, 0, 0, 0, 8 * time.Hour},
+			targets: []time.Duration{8 * time.Hour, 8 * time.Hour, 8 * time.Hour, 8 * time.Hour, 8 * time.Hour, 8 * time.Hour, 8 * time.Hour},
+		},
+		{
+			by:      "week",
+			labels:  []string{"2026-W13 (Mar 24 - Mar 29)", "2026-W14 (Mar 30 - Apr 1)"},
+			worked:  []time.Duration{16 * time.Hour, 8 * time.Hour},
+			targets: []time.Duration{32 * time.Hour, 24 * time.Hour},
+		},
+		{
+			by:      "month",
+			labels:  []string{"March 2026", "April 2026"},
+			worked:  []time.Duration{16 * time.Hour, 8 * time.Hour},
+			targets: []time.Duration{48 * time.Hour, 8 * time.Hour},
+		},
+		{by: "year", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			groups, err := groupReportRange(entries, first, last, tt.by, schedule)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(groups) != len(tt.labels) {
+				t.Fatalf("got %d groups, want %d", len(groups), len(tt.labels))
+			}
+			for i, group := range groups {
+				if group.Label != tt.labels[i] || group.Worked != tt.worked[i] || group.Target != tt.targets[i] {
+					t.Errorf("group %d = %q worked %v target %v, want %q worked %v target %v",
+						i, group.Label, group.Worked, group.Target, tt.labels[i], tt.worked[i], tt.targets[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+func TestPeriodWorkTime(t *testing.T) {
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	entries := []journal.JournalEntry{
+		// Night shift running from March into April, with a break after midnight
+		{
+			ID:        "20260331",
+			StartTime: at(3, 31, 22),
+			EndTime:   at(4, 1, 6),
+			Breaks:    []journal.Break{{StartTime: at(4, 1, 2), EndTime: at(4, 1, 3), Reason: "meal"}},
+		},
+		// Day shift without breaks, which gets the default lunch taken off
+		{ID: "20260402", StartTime: at(4, 2, 9), EndTime: at(4, 2, 18)},
+		// Ongoing entries do not count yet
+		{ID: "20260403", StartTime: at(4, 3, 9)},
+	}
+	var schedule workSchedule
+	for day := range schedule {
+		schedule[day].LunchTime = time.Hour
+	}
+
+	tests := []struct {
+		name        string
+		first, last time.Time
+		want        time.Duration
+	}{
+		{name: "March keeps the hours before midnight", first: at(3, 1, 0), last: at(3, 31, 0), want: 2 * time.Hour},
+		{name: "April gets the hours after midnight", first: at(4, 1, 0), last: at(4, 30, 0), want: 5*time.Hour + 8*time.Hour},
+		{name: "single day", first: at(4, 2, 12), last: at(4, 2, 12), want: 8 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := periodWorkTime(entries, tt.first, tt.last, schedule); got != tt.want {
+				t.Errorf("periodWorkTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,17 +9,21 @@ import (
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // reportCmd represents the report command
 var reportWeekCmd = &cobra.Command{
 	Use:   "week",
 	Short: "Generates a report for the current week",
-	Long: `The week command generates a report for the current week.
-It loads the existing journal entries from the file and fetches the entries for the current week.
-If there are no entries for the current week, it returns an error.
-Otherwise, it prints out the entries.`,
+	Long: `The week command generates a report for the current week, or for the ISO
+week given with --week, from Monday to Sunday.
+It loads the existing journal entries from the file and fetches the entries for the week.
+If there are no entries for the week, it returns an error.
+Otherwise, it prints out the entries.
+
+Examples:
+  workday report week
+  workday report week --week 2026-W14`,
 	RunE: reportWeek,
 }
 
@@ -27,6 +31,9 @@ type reportWeekModel struct {
 	entries       []journal.JournalEntry
 	week          time.Time
 	totalWorkTime time.Duration
+	schedule      workSchedule
+	target        time.Duration
+	adjusted      time.Duration
 	width         int
 	height        int
 	quitting      bool
@@ -57,27 +64,30 @@ func (m reportWeekModel) View() string {
 	if m.quitting {
 		return ""
 	}
+	return m.render(renderOptions{Interactive: true})
+}
 
-
+// render renders the view, see renderOptions.
+func (m reportWeekModel) render(opts renderOptions) string {
 	var content strings.Builder
 
 	// Title
-	weekStart := m.week.AddDate(0, 0, -int(m.week.Weekday()))
-	weekEnd := weekStart.AddDate(0, 0, 6)
-	weekStr := fmt.Sprintf("%s - %s", 
-		weekStart.Format("Jan 2"), 
+	weekStart, weekEnd := journal.WeekBounds(m.week)
+	year, week := m.week.ISOWeek()
+	weekStr := fmt.Sprintf("%d-W%02d, %s - %s", year, week,
+		weekStart.Format("Jan 2"),
 		weekEnd.Format("Jan 2, 2006"))
 	content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("📅 Weekly Report - %s", weekStr)))
 	content.WriteString("\n\n")
 
 	// Create table data
-	headers := []string{"Date", "Start", "End", "Duration", "Breaks"}
+	headers := []string{"Date", "Start", "End", "Duration", "Target", "Breaks"}
 	rows := [][]string{}
 
 	// Generate all days of the week
 	for i := 0; i < 7; i++ {
 		currentDay := weekStart.AddDate(0, 0, i)
-		
+
 		// Find entry for this day
 		var entry *journal.JournalEntry
 		for _, e := range m.entries {
@@ -94,45 +104,52 @@ func (m reportWeekModel) View() string {
 				"--",
 				"--",
 				"--",
+				formatTarget(m.schedule.For(currentDay).MinWorkTime),
 				"--",
 			})
 		} else {
 			// Format entry data
 			date := entry.StartTime.Format("Mon, Jan 2")
 			startTime := entry.StartTime.Format("15:04")
-			
+
 			endTime := "Ongoing"
 			duration := "In progress"
 			if !entry.EndTime.IsZero() {
-				endTime = entry.EndTime.Format("15:04")
-				
-				// Calculate work duration
-				workDuration := entry.EndTime.Sub(entry.StartTime)
-				
-				// Subtract break time
-				var totalBreakTime time.Duration
-				for _, br := range entry.Breaks {
-					if !br.EndTime.IsZero() {
-						totalBreakTime += br.EndTime.Sub(br.StartTime)
-					}
-				}
-				
-				workDuration -= totalBreakTime
+				endTime = journal.FormatClockTime(entry.EndTime, entry.StartTime)
+
+				// Calculate work duration, summed over the sessions
+				workDuration := entry.TotalWorkTime()
 				hours := int(workDuration.Hours())
 				minutes := int(workDuration.Minutes()) % 60
 				duration = fmt.Sprintf("%dh %dm", hours, minutes)
+				if sessions := len(entry.WorkSessions()); sessions > 1 {
+					duration += fmt.Sprintf(" (%d sessions)", sessions)
+				}
+			}
+
+			// A day off shows its type, instead of times when nothing was worked
+			if len(entry.WorkSessions()) == 0 {
+				startTime, endTime, duration = "--", "--", "--"
+				if entry.IsDayOff() {
+					duration = dayOffLabel(*entry)
+				}
+			} else if entry.IsDayOff() {
+				duration += " · " + entry.DayType.Label()
 			}
-			
+			if adjusted := entry.AdjustmentTotal(); adjusted != 0 {
+				duration += fmt.Sprintf(" (%s adjusted)", formatSignedDuration(adjusted))
+			}
+
 			// Format breaks
 			breakInfo := "--"
 			if len(entry.Breaks) > 0 {
 				var breakTimes []string
 				for _, br := range entry.Breaks {
 					if !br.EndTime.IsZero() {
-						breakTime := fmt.Sprintf("%s-%s", br.StartTime.Format("15:04"), br.EndTime.Format("15:04"))
+						breakTime := fmt.Sprintf("%s-%s", journal.FormatClockTime(br.StartTime, entry.StartTime), journal.FormatClockTime(br.EndTime, entry.StartTime))
 						breakTimes = append(breakTimes, breakTime)
 					} else {
-						breakTime := fmt.Sprintf("%s-ongoing", br.StartTime.Format("15:04"))
+						breakTime := fmt.Sprintf("%s-ongoing", journal.FormatClockTime(br.StartTime, entry.StartTime))
 						breakTimes = append(breakTimes, breakTime)
 					}
 				}
@@ -140,8 +157,8 @@ func (m reportWeekModel) View() string {
 					breakInfo = strings.Join(breakTimes, ", ")
 				}
 			}
-			
-			rows = append(rows, []string{date, startTime, endTime, duration, breakInfo})
+
+			rows = append(rows, []string{date, startTime, endTime, duration, formatTarget(m.schedule.TargetFor(entry)), breakInfo})
 		}
 	}
 
@@ -165,7 +182,7 @@ func (m reportWeekModel) View() string {
 
 	// Create table
 	var table strings.Builder
-	
+
 	// Top border
 	table.WriteString("┌")
 	for i, width := range colWidths {
@@ -221,76 +238,80 @@ func (m reportWeekModel) View() string {
 	// Summary Section
 	workDays := 0
 	for _, entry := range m.entries {
-		if !entry.EndTime.IsZero() {
+		if !entry.EndTime.IsZero() && len(entry.WorkSessions()) > 0 {
 			workDays++
 		}
 	}
-	
+
 	content.WriteString(styles.SummaryStyle.Render(fmt.Sprintf("📊 Total work time: %v across %d days",
 		m.totalWorkTime, workDays)))
 	content.WriteString("\n")
+	targetLine := fmt.Sprintf("🎯 Target: %s, %s", formatDuration(m.target), formatBalance(m.totalWorkTime-m.target+m.adjusted))
+	if m.adjusted != 0 {
+		targetLine += fmt.Sprintf(" (including %s of adjustments)", formatSignedDuration(m.adjusted))
+	}
+	content.WriteString(styles.SummaryStyle.Render(targetLine))
+	content.WriteString("\n")
+	if line := findingsSummary(m.entries); line != "" {
+		content.WriteString(styles.ErrorStyle.Render(line))
+		content.WriteString("\n")
+	}
 
-	// Help
-	content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
 
 	return content.String()
 }
 
-// reportWeek reports the workday entries for the current week.
+// reportWeek reports the workday entries for the current week, or for the
+// week of the --week flag.
 // It first loads the existing journal entries from the file.
-// If there are no entries for the current week, it returns an error.
+// If there are no entries for the week, it returns an error.
 // Otherwise, it displays the entries using Bubble Tea.
 func reportWeek(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	journalEntries, err := journal.LoadEntries(journalPath)
+	now := currentTime()
+	if weekFlag, _ := cmd.Flags().GetString("week"); weekFlag != "" {
+		monday, err := journal.ParseISOWeek(weekFlag, now.Location())
+		if err != nil {
+			return err
+		}
+		now = monday
+	}
+	first, last := journal.WeekBounds(now)
+	// Include the day before the week, whose night shift may run into Monday
+	journalEntries, err := loadEntriesInRange(first.AddDate(0, 0, -1), last)
 	if err != nil {
 		return err
 	}
-	now := time.Now()
 	currentWeek, err := journal.FetchEntriesByWeekDate(journalEntries, now)
 	if err != nil {
-		return err
+		year, week := now.ISOWeek()
+		return fmt.Errorf("no entries found for %d-W%02d", year, week)
 	}
 
-	lunchTime, err := time.ParseDuration(viper.GetString("lunchTime"))
+	schedule, err := loadSchedule()
 	if err != nil {
 		return err
 	}
 
-	var totalWorkTime time.Duration
-	for _, entry := range currentWeek {
-		if !entry.EndTime.IsZero() {
-			dayWorkTime := entry.EndTime.Sub(entry.StartTime)
-			var totalBreakTime time.Duration
-
-			// Calculate total breaktime
-			for _, br := range entry.Breaks {
-				if !br.EndTime.IsZero() {
-					totalBreakTime += br.EndTime.Sub(br.StartTime)
-				}
-			}
-
-			// If no breaks were recorded, subtract default lunchTime
-			if len(entry.Breaks) == 0 {
-				totalBreakTime = lunchTime
-			}
-
-			dayWorkTime -= totalBreakTime
-			totalWorkTime += dayWorkTime
-		}
-	}
+	totalWorkTime := periodWorkTime(journalEntries, first, last, schedule)
 
 	model := reportWeekModel{
 		entries:       currentWeek,
 		week:          now,
 		totalWorkTime: totalWorkTime,
+		schedule:      schedule,
+		target:        schedule.Target(first, last, currentWeek),
+		adjusted:      adjustmentTotal(currentWeek),
 	}
 
-	p := tea.NewProgram(&model)
-	_, err = p.Run()
-	return err
+	year, week := now.ISOWeek()
+	data := newPeriodJSON(fmt.Sprintf("%d-W%02d", year, week), first, last, currentWeek, model.totalWorkTime, model.target, schedule)
+	return showReport(&model, data)
 }
 
 func init() {
+	reportWeekCmd.Flags().StringP("week", "w", "", "Specify the ISO week in the format YYYY-Www, e.g. 2026-W14")
 	reportCmd.AddCommand(reportWeekCmd)
 }
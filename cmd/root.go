@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -60,6 +61,7 @@ func init() {
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.workday.yaml)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "", "output of reports and status: text, json or none (default interactive on a terminal, text otherwise)")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -89,6 +91,11 @@ func initConfig() {
 	viper.SetDefault("lunchTime", "1h")
 	viper.SetDefault("minWorkTime", "8h")
 	viper.SetDefault("maxWorkTime", "10h")
+	viper.SetDefault("lockTimeout", "5s")
+	viper.SetDefault("backup.count", journal.DefaultBackupCount)
+	viper.SetDefault("backup.dir", "")
+	viper.SetDefault("storage.backend", journal.BackendJSON)
+	viper.SetDefault("timezone", "")
 
 	viper.AutomaticEnv() // read in environment variables that match
 
@@ -96,4 +103,9 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	// Entries are dated in the configured zone, so refuse to run with one
+	// that does not exist
+	_, err = configLocation()
+	cobra.CheckErr(err)
 }
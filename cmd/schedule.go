@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/spf13/viper"
+)
+
+// daySchedule is the work expected on one day of the week.
+type daySchedule struct {
+	MinWorkTime time.Duration      // target work time, zero on days off
+	MaxWorkTime time.Duration      // most work time allowed
+	LunchTime   time.Duration      // length of the lunch break
+	Start       *journal.ClockTime // expected start, nil when not configured
+}
+
+// workSchedule holds the schedule of every day of the week, indexed by
+// time.Weekday.
+type workSchedule [7]daySchedule
+
+// For returns the schedule of the weekday of t.
+func (s workSchedule) For(t time.Time) daySchedule {
+	return s[t.Weekday()]
+}
+
+// Target returns the sum of the target work times of the days from first to
+// last, inclusive. Days that entries mark as days off have no target.
+func (s workSchedule) Target(first, last time.Time, entries []journal.JournalEntry) time.Duration {
+	off := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDayOff() {
+			off[entry.ID] = true
+		}
+	}
+
+	var target time.Duration
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !off[day.Format("20060102")] {
+			target += s.For(day).MinWorkTime
+		}
+	}
+	return target
+}
+
+// loadSchedule builds the work schedule from the config. Every weekday can
+// override minWorkTime, maxWorkTime and lunchTime and set an expected start
+// in a schedule section:
+//
+//	schedule:
+//	  friday:
+//	    minWorkTime: 6h
+//	    start: "08:00"
+//
+// Values a weekday does not set come from the global settings, so a day off
+// every week, such as Sunday, is set with a minWorkTime of 0.
+func loadSchedule() (workSchedule, error) {
+	minWorkTime, err := time.ParseDuration(viper.GetString("minWorkTime"))
+	if err != nil {
+		return workSchedule{}, fmt.Errorf("invalid minimum work time format in config: %v", err)
+	}
+	lunchTime, err := time.ParseDuration(viper.GetString("lunchTime"))
+	if err != nil {
+		return workSchedule{}, fmt.Errorf("invalid lunch time format in config: %v", err)
+	}
+	maxWorkTime, err := time.ParseDuration(viper.GetString("maxWorkTime"))
+	if err != nil {
+		return workSchedule{}, fmt.Errorf("invalid maximum work time format in config: %v", err)
+	}
+
+	var schedule workSchedule
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		section := "schedule." + strings.ToLower(day.String())
+		ds := daySchedule{MinWorkTime: minWorkTime, MaxWorkTime: maxWorkTime, LunchTime: lunchTime}
+
+		for _, setting := range []struct {
+			key   string
+			value *time.Duration
+		}{
+			{"minWorkTime", &ds.MinWorkTime},
+			{"maxWorkTime", &ds.MaxWorkTime},
+			{"lunchTime", &ds.LunchTime},
+		} {
+			key := section + "." + setting.key
+			if !viper.IsSet(key) {
+				continue
+			}
+			parsed, err := journal.ValidateConfigDuration(viper.GetString(key), key)
+			if err != nil {
+				return workSchedule{}, err
+			}
+			*setting.value = parsed
+		}
+
+		if start := viper.GetString(section + ".start"); start != "" {
+			parsed, err := journal.ParseClockTime(start)
+			if err != nil {
+				return workSchedule{}, fmt.Errorf("invalid %s.start in config: %w", section, err)
+			}
+			ds.Start = &parsed
+		}
+
+		schedule[day] = ds
+	}
+	return schedule, nil
+}
+
+// TargetFor returns the target work time of the day of entry, which is zero
+// when the entry marks a day off.
+func (s workSchedule) TargetFor(entry *journal.JournalEntry) time.Duration {
+	if entry.IsDayOff() {
+		return 0
+	}
+	return s.For(entry.StartTime).MinWorkTime
+}
+
+// formatTarget renders the target work time of a day for report tables, with
+// "--" for days off.
+func formatTarget(target time.Duration) string {
+	if target == 0 {
+		return "--"
+	}
+	return formatDuration(target)
+}
+
+// formatBalance renders the difference between the time worked and the
+// target, e.g. "1h 30m over" or "45m short".
+func formatBalance(diff time.Duration) string {
+	switch {
+	case diff > 0:
+		return formatDuration(diff) + " over"
+	case diff < 0:
+		return formatDuration(-diff) + " short"
+	default:
+		return "on target"
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/spf13/viper"
+)
+
+func TestLoadSchedule(t *testing.T) {
+	setBackfillViper(t, t.TempDir()+"/journal.json", "8h", "1h", "10h")
+	viper.Set("schedule.friday.minWorkTime", "6h")
+	viper.Set("schedule.friday.lunchTime", "30m")
+	viper.Set("schedule.friday.start", "08:00")
+	viper.Set("schedule.saturday.minWorkTime", "4h")
+	viper.Set("schedule.sunday.minWorkTime", "0")
+
+	schedule, err := loadSchedule()
+	if err != nil {
+		t.Fatalf("loadSchedule() error = %v", err)
+	}
+
+	tests := []struct {
+		day     time.Weekday
+		minWork time.Duration
+		lunch   time.Duration
+		maxWork time.Duration
+		start   string
+	}{
+		{day: time.Monday, minWork: 8 * time.Hour, lunch: time.Hour, maxWork: 10 * time.Hour},
+		{day: time.Wednesday, minWork: 8 * time.Hour, lunch: time.Hour, maxWork: 10 * time.Hour},
+		{day: time.Friday, minWork: 6 * time.Hour, lunch: 30 * time.Minute, maxWork: 10 * time.Hour, start: "08:00"},
+		{day: time.Saturday, minWork: 4 * time.Hour, lunch: time.Hour, maxWork: 10 * time.Hour},
+		{day: time.Sunday, minWork: 0, lunch: time.Hour, maxWork: 10 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.day.String(), func(t *testing.T) {
+			got := schedule[tt.day]
+			if got.MinWorkTime != tt.minWork || got.LunchTime != tt.lunch || got.MaxWorkTime != tt.maxWork {
+				t.Errorf("schedule = %+v, want min %v, lunch %v, max %v", got, tt.minWork, tt.lunch, tt.maxWork)
+			}
+			start := ""
+			if got.Start != nil {
+				start = got.Start.String()
+			}
+			if start != tt.start {
+				t.Errorf("start = %q, want %q", start, tt.start)
+			}
+		})
+	}
+
+	// Monday 6 April to Sunday 12 April 2026: four 8h days, a 6h Friday
+	// and a 4h Saturday
+	first := time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC)
+	if got, want := schedule.Target(first, first.AddDate(0, 0, 6), nil), 42*time.Hour; got != want {
+		t.Errorf("Target() = %v, want %v", got, want)
+	}
+
+	// A holiday on the Monday takes its 8h off the target
+	holiday, _ := journal.NewDayOffEntry(first, journal.DayHoliday, "Easter Monday")
+	if got, want := schedule.Target(first, first.AddDate(0, 0, 6), []journal.JournalEntry{*holiday}), 34*time.Hour; got != want {
+		t.Errorf("Target() with a holiday = %v, want %v", got, want)
+	}
+}
+
+func TestLoadScheduleRejectsInvalidValues(t *testing.T) {
+	setBackfillViper(t, t.TempDir()+"/journal.json", "8h", "1h", "10h")
+	viper.Set("schedule.monday.minWorkTime", "eight hours")
+	if _, err := loadSchedule(); err == nil {
+		t.Error("expected an error for an invalid duration")
+	}
+
+	viper.Set("schedule.monday.minWorkTime", "8h")
+	viper.Set("schedule.monday.start", "9am")
+	if _, err := loadSchedule(); err == nil {
+		t.Error("expected an error for an invalid start time")
+	}
+}
+
+func TestScheduleViolationsUseWeekdaySchedule(t *testing.T) {
+	setBackfillViper(t, t.TempDir()+"/journal.json", "8h", "1h", "10h")
+	viper.Set("schedule.friday.minWorkTime", "5h")
+	viper.Set("schedule.friday.lunchTime", "30m")
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 4, day, hour, minute, 0, 0, time.UTC)
+	}
+	entry := func(day int) *journal.JournalEntry {
+		return &journal.JournalEntry{
+			ID:        at(day, 0, 0).Format("20060102"),
+			StartTime: at(day, 9, 0),
+			EndTime:   at(day, 14, 30),
+			Breaks:    []journal.Break{{StartTime: at(day, 12, 0), EndTime: at(day, 12, 30), Reason: "lunch"}},
+		}
+	}
+
+	schedule, err := loadSchedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func(day int) []journal.Violation {
+		e := entry(day)
+		return scheduleViolations(schedule.For(e.StartTime), e)
+	}
+
+	// Five hours with a half-hour lunch is a full short Friday...
+	if violations := check(10); len(violations) != 0 {
+		t.Errorf("scheduleViolations() on Friday = %v", violations)
+	}
+	// ...but falls short on a Thursday, which also wants a longer lunch
+	if violations := check(9); len(violations) != 2 || violations[0].Rule != "min_work_time" || violations[1].Rule != "lunch_break" {
+		t.Errorf("expected min_work_time and lunch_break on Thursday, got %v", violations)
+	}
+	// Saturday keeps the global minimum unless the schedule sets its own...
+	if violations := check(11); len(violations) != 2 || violations[0].Rule != "min_work_time" {
+		t.Errorf("expected min_work_time and lunch_break on Saturday, got %v", violations)
+	}
+	// ...such as a day off
+	viper.Set("schedule.saturday.minWorkTime", "0")
+	viper.Set("schedule.saturday.lunchTime", "0")
+	if schedule, err = loadSchedule(); err != nil {
+		t.Fatal(err)
+	}
+	if violations := check(11); len(violations) != 0 {
+		t.Errorf("scheduleViolations() on a Saturday off = %v", violations)
+	}
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search [text]",
+	Short: "Searches the notes of the journal by text and tags",
+	Long: `The search command lists the notes that contain every word of text, ignoring
+case, and carry the tags given with --tag, together with the day they were
+written on. Words in double quotes must appear together, as a phrase. The best
+matches come first: notes where the words are rarer in the journal, more
+frequent in the note, and the note shorter rank higher.
+
+A note must carry every --tag, or one of them with --any. With --regex the
+text is a regular expression matched against the note instead, such as
+'(?i)^fixed' for notes starting with "fixed" in any case, and the notes are
+listed in the order of the journal. Without --from the search starts with the
+first day of the journal, and without --to it ends today.
+
+Searches use an index of the notes kept next to the journal, which is built
+by the first search and updated whenever a day changes. --reindex rebuilds it
+from scratch, e.g. after the journal file was edited by hand.
+
+Examples:
+  workday search deploy
+  workday search '"code review" parser'
+  workday search --tag progress --tag team
+  workday search --tag bug --tag incident --any --from 2026-01-01
+  workday search '^(fixed|closed) #?[0-9]+' --regex
+  workday search --reindex`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: searchNotes,
+}
+
+type searchModel struct {
+	period   string
+	query    string
+	matches  []journal.NoteMatch
+	width    int
+	height   int
+	quitting bool
+}
+
+func (m searchModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m searchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m searchModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	return m.render(renderOptions{Interactive: true})
+}
+
+// render renders the view, see renderOptions.
+func (m searchModel) render(opts renderOptions) string {
+	var content strings.Builder
+
+	// Title
+	content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("🔍 Search - %s", m.period)))
+	content.WriteString("\n\n")
+
+	if len(m.matches) == 0 {
+		content.WriteString(styles.InfoStyle.Render("No notes match " + m.query))
+		content.WriteString("\n")
+	}
+
+	// A section for every run of notes of the same day
+	for i, match := range m.matches {
+		if i == 0 || match.EntryID != m.matches[i-1].EntryID {
+			content.WriteString(styles.SectionStyle.Render("📅 " + match.Date.Format("Mon, Jan 2 2006")))
+			content.WriteString("\n")
+		}
+		noteText := fmt.Sprintf("%d. %s", match.Index+1, match.Note.Contents)
+		if len(match.Note.Tags) > 0 {
+			noteText += fmt.Sprintf(" [%s]", strings.Join(match.Note.Tags, ", "))
+		}
+		content.WriteString(styles.NoteStyle.Render(noteText))
+		content.WriteString("\n")
+	}
+
+	// Summary Section
+	content.WriteString(styles.SummaryStyle.Render(fmt.Sprintf("📊 %d notes found", len(m.matches))))
+	content.WriteString("\n")
+
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
+
+	return content.String()
+}
+
+// resolveDateRange parses the --from and --to dates, both optional and in
+// the YYYY-MM-DD format, and names the range. An open start is returned as a
+// zero time, reaching back to the first entry, and an open end as now.
+func resolveDateRange(fromStr, toStr string, now time.Time) (time.Time, time.Time, string, error) {
+	var from time.Time
+	to := now
+	var err error
+	if fromStr != "" {
+		if from, err = time.ParseInLocation("2006-01-02", fromStr, now.Location()); err != nil {
+			return time.Time{}, time.Time{}, "", fmt.Errorf("invalid --from date format. Use YYYY-MM-DD")
+		}
+	}
+	if toStr != "" {
+		if to, err = time.ParseInLocation("2006-01-02", toStr, now.Location()); err != nil {
+			return time.Time{}, time.Time{}, "", fmt.Errorf("invalid --to date format. Use YYYY-MM-DD")
+		}
+	}
+
+	switch {
+	case fromStr != "" && from.After(to):
+		return time.Time{}, time.Time{}, "", fmt.Errorf("--from date must not be after --to date")
+	case fromStr != "":
+		return from, to, fmt.Sprintf("%s - %s", from.Format("Jan 2, 2006"), to.Format("Jan 2, 2006")), nil
+	case toStr != "":
+		return from, to, "Until " + to.Format("Jan 2, 2006"), nil
+	default:
+		return from, to, "All time", nil
+	}
+}
+
+// loadEntriesBetween returns the entries from from to to, inclusive. A zero
+// from reads the whole journal rather than every month since year one.
+func loadEntriesBetween(from, to time.Time) ([]journal.JournalEntry, error) {
+	if !from.IsZero() {
+		return loadEntriesInRange(from, to)
+	}
+	entries, err := loadEntries()
+	if err != nil {
+		return nil, err
+	}
+	var until []journal.JournalEntry
+	for _, entry := range entries {
+		if entry.ID <= to.Format("20060102") {
+			until = append(until, entry)
+		}
+	}
+	return until, nil
+}
+
+// buildNoteQuery builds the query of the search flags for reading the
+// journal, which --regex needs. The text of other searches goes to the index,
+// so it is only compiled here when useRegex is set.
+func buildNoteQuery(text string, tags []string, anyTag, useRegex bool) (journal.NoteQuery, error) {
+	query := journal.NoteQuery{AnyTag: anyTag}
+	for _, tag := range tags {
+		if tag = strings.TrimPrefix(strings.TrimSpace(tag), "#"); tag != "" {
+			query.Tags = append(query.Tags, tag)
+		}
+	}
+	if !useRegex {
+		return query, nil
+	}
+	pattern, err := regexp.Compile(text)
+	if err != nil {
+		return journal.NoteQuery{}, fmt.Errorf("invalid regular expression '%s': %v", text, err)
+	}
+	query.Pattern = pattern
+	return query, nil
+}
+
+// describeNoteQuery renders the query for the message shown when nothing
+// matches, e.g. `"deploy" tagged #progress and #team`.
+func describeNoteQuery(text string, query journal.NoteQuery) string {
+	var parts []string
+	if text != "" {
+		parts = append(parts, fmt.Sprintf("%q", text))
+	}
+	if len(query.Tags) > 0 {
+		tags := make([]string, len(query.Tags))
+		for i, tag := range query.Tags {
+			tags[i] = "#" + tag
+		}
+		join := " and "
+		if query.AnyTag {
+			join = " or "
+		}
+		parts = append(parts, "tagged "+strings.Join(tags, join))
+	}
+	if len(parts) == 0 {
+		return "the search"
+	}
+	return strings.Join(parts, " ")
+}
+
+func searchNotes(cmd *cobra.Command, args []string) error {
+	tags, _ := cmd.Flags().GetStringSlice("tag")
+	anyTag, _ := cmd.Flags().GetBool("any")
+	useRegex, _ := cmd.Flags().GetBool("regex")
+	reindex, _ := cmd.Flags().GetBool("reindex")
+	fromStr, _ := cmd.Flags().GetString("from")
+	toStr, _ := cmd.Flags().GetString("to")
+
+	var text string
+	if len(args) > 0 {
+		text = args[0]
+	}
+	if text == "" && len(tags) == 0 && !reindex {
+		return fmt.Errorf("nothing to search for. Give a text, one or more --tag, or both")
+	}
+	query, err := buildNoteQuery(text, tags, anyTag, useRegex)
+	if err != nil {
+		return err
+	}
+	from, to, period, err := resolveDateRange(fromStr, toStr, currentTime())
+	if err != nil {
+		return err
+	}
+
+	var matches []journal.NoteMatch
+	err = withStore(func(store journal.Store) error {
+		index, err := journal.OpenSearchIndex(store, reindex)
+		if err != nil {
+			return err
+		}
+		if reindex {
+			fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ Rebuilt the search index: %d notes on %d days", index.NoteCount(), len(index.Entries))))
+		}
+		if !useRegex {
+			matches = index.Search(journal.IndexQuery{Text: text, Tags: query.Tags, AnyTag: anyTag, From: from, To: to})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if text == "" && len(tags) == 0 {
+		return nil
+	}
+
+	// Regular expressions cannot use the index, so they read the journal
+	if useRegex {
+		entries, err := loadEntriesBetween(from, to)
+		if err != nil {
+			return err
+		}
+		matches = journal.SearchNotes(entries, query)
+	}
+
+	model := searchModel{
+		period:  period,
+		query:   describeNoteQuery(text, query),
+		matches: matches,
+	}
+
+	data := searchJSON{Period: period, Matches: []noteMatchJSON{}}
+	for _, match := range model.matches {
+		data.Matches = append(data.Matches, noteMatchJSON{
+			ID:       match.EntryID,
+			Date:     match.Date.Format("2006-01-02"),
+			Index:    match.Index + 1,
+			Contents: match.Note.Contents,
+			Tags:     jsonTags(match.Note.Tags),
+			Score:    match.Score,
+		})
+	}
+	return showReport(&model, data)
+}
+
+func init() {
+	searchCmd.Flags().StringSliceP("tag", "t", nil, "Tag the notes must carry, repeat for several")
+	searchCmd.Flags().Bool("any", false, "Match notes carrying any of the tags instead of all of them")
+	searchCmd.Flags().BoolP("regex", "r", false, "Treat the text as a regular expression")
+	searchCmd.Flags().Bool("reindex", false, "Rebuild the search index from the whole journal first")
+	searchCmd.Flags().String("from", "", "Start of the search range in YYYY-MM-DD format")
+	searchCmd.Flags().String("to", "", "End of the search range in YYYY-MM-DD format")
+	rootCmd.AddCommand(searchCmd)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+func TestBuildNoteQuery(t *testing.T) {
+	notes := []journal.Note{
+		{Contents: "Fixed 42", Tags: []string{"bug"}},
+		{Contents: "fixed the docs", Tags: []string{"docs", "bug"}},
+		{Contents: "Wrote tests"},
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		tags     []string
+		anyTag   bool
+		useRegex bool
+		want     int
+		wantErr  bool
+	}{
+		{name: "text is left to the index", text: "FIXED", want: 3},
+		{name: "tags with a hash", tags: []string{"#bug", " docs "}, want: 1},
+		{name: "any tag", tags: []string{"docs", "bug"}, anyTag: true, want: 2},
+		{name: "regex", text: `^Fixed [0-9]+$`, useRegex: true, want: 1},
+		{name: "invalid regex", text: `(`, useRegex: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := buildNoteQuery(tt.text, tt.tags, tt.anyTag, tt.useRegex)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildNoteQuery() error = %v", err)
+			}
+			got := 0
+			for _, note := range notes {
+				if query.Matches(note) {
+					got++
+				}
+			}
+			if got != tt.want {
+				t.Errorf("matched %d notes, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEntriesBetween(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 4, d, 9, 0, 0, 0, time.UTC) }
+	var entries []journal.JournalEntry
+	for _, d := range []int{1, 7, 14} {
+		entries = append(entries, journal.JournalEntry{ID: day(d).Format("20060102"), StartTime: day(d), EndTime: day(d).Add(time.Hour)})
+	}
+	path := writeTempJournal(t, entries)
+	setBackfillViper(t, path, "8h", "1h", "10h")
+	now := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		from, to   string
+		wantIDs    []string
+		wantPeriod string
+		wantErr    bool
+	}{
+		{name: "all time ends now", wantIDs: []string{"20260401", "20260407"}, wantPeriod: "All time"},
+		{name: "from only ends now", from: "2026-04-02", wantIDs: []string{"20260407"}, wantPeriod: "Apr 2, 2026 - Apr 10, 2026"},
+		{name: "to only", to: "2026-04-07", wantIDs: []string{"20260401", "20260407"}, wantPeriod: "Until Apr 7, 2026"},
+		{name: "both", from: "2026-04-07", to: "2026-04-30", wantIDs: []string{"20260407", "20260414"}, wantPeriod: "Apr 7, 2026 - Apr 30, 2026"},
+		{name: "reversed", from: "2026-04-30", to: "2026-04-01", wantErr: true},
+		{name: "malformed", from: "04/01/2026", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, period, err := resolveDateRange(tt.from, tt.to, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveDateRange() error = %v", err)
+			}
+			got, err := loadEntriesBetween(from, to)
+			if err != nil {
+				t.Fatalf("loadEntriesBetween() error = %v", err)
+			}
+			if period != tt.wantPeriod {
+				t.Errorf("period = %q, want %q", period, tt.wantPeriod)
+			}
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("got %d entries, want %v", len(got), tt.wantIDs)
+			}
+			for i, entry := range got {
+				if entry.ID != tt.wantIDs[i] {
+					t.Errorf("entry %d = %s, want %s", i, entry.ID, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
@@ -9,24 +9,28 @@ import (
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // startCmd represents the start command
 var startCmd = &cobra.Command{
 	Use:   "start",
-	Short: "Starts a new workday entry",
+	Short: "Starts a new workday entry or a new work session",
 	Long: `The start command is used to begin a new workday entry in the journal.
 
 It creates a new JournalEntry with the current date and time as the start time,
 appends it to the existing journal entries, and saves the updated journal entries to the file.
-After running this command, you can begin adding notes to the new workday entry.`,
+After running this command, you can begin adding notes to the new workday entry.
+
+If today's entry already exists and its last session has ended, a new work
+session is started instead, e.g. when coming back in the evening after
+"workday end" in the afternoon. The day's work time is the sum of its sessions.`,
 	RunE: startWorkDay,
 }
 
 type startModel struct {
 	startTime       time.Time
 	isNewEntry      bool
+	session         int
 	previousEndTime *time.Time
 	width           int
 	height          int
@@ -59,7 +63,6 @@ func (m startModel) View() string {
 		return ""
 	}
 
-
 	var content strings.Builder
 
 	// Title
@@ -67,7 +70,7 @@ func (m startModel) View() string {
 	if m.isNewEntry {
 		content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("🚀 Workday Started - %s", dateStr)))
 	} else {
-		content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("🔄 Workday Restarted - %s", dateStr)))
+		content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("🔄 Workday Resumed - %s", dateStr)))
 	}
 	content.WriteString("\n\n")
 
@@ -82,7 +85,7 @@ func (m startModel) View() string {
 	if m.isNewEntry {
 		content.WriteString(styles.LabelStyle.Render("Status:") + " " + styles.SuccessStyle.Render("New workday entry created"))
 	} else {
-		content.WriteString(styles.LabelStyle.Render("Status:") + " " + styles.InfoBlueStyle.Render("Existing entry overwritten"))
+		content.WriteString(styles.LabelStyle.Render("Status:") + " " + styles.InfoBlueStyle.Render(fmt.Sprintf("Session %d started", m.session)))
 	}
 	content.WriteString("\n")
 
@@ -91,7 +94,7 @@ func (m startModel) View() string {
 		content.WriteString("\n")
 		content.WriteString(styles.SectionStyle.Render("📝 Previous Entry"))
 		content.WriteString("\n")
-		
+
 		prevEndTime := m.previousEndTime.Format("15:04 on Jan 2")
 		content.WriteString(styles.LabelStyle.Render("End Time:") + " " + styles.ValueStyle.Render(fmt.Sprintf("Updated to %s", prevEndTime)))
 		content.WriteString("\n")
@@ -117,32 +120,26 @@ func (m startModel) View() string {
 // startWorkDay starts a new workday entry in the journal.
 // It first loads the existing journal entries from the file.
 // If the last entry on the journal was not closed properly (misisng EndTime) it will offer to update that first
-// If there is already an entry for the current day, it asks the user if they want to override it.
-// If the user agrees, it overwrites the existing entry with a new one.
-// If the user does not agree, it does nothing and returns nil.
+// If there is already an entry for the current day, it starts a new session in it, unless a session is already open.
 // If there is no entry for the current day, it creates a new JournalEntry with the current date and time as the start time,
 // appends the new entry to the journal entries, and saves the updated journal entries back to the file.
 // It then prints a message indicating that a new JournalEntry has been added for the current day.
 func startWorkDay(cmd *cobra.Command, args []string) error {
-	journalPath := viper.GetString("journalPath")
-	entries, err := journal.LoadEntries(journalPath)
-	if err != nil {
-		return err
-	}
-
-	now := time.Now()
+	now := currentTime()
 	currentDayId := now.Format("20060102")
-	var lastEntry *journal.JournalEntry
 	var previousEndTime *time.Time
+	isNewEntry := true
+	session := 1
 
-	for i := len(entries) - 1; i >= 0; i-- {
-		if entries[i].ID[:8] != currentDayId {
-			lastEntry = &entries[i]
-			break
-		}
+	// Ask about an unfinished previous entry before taking the lock, so other
+	// commands are not kept waiting for the answers
+	entries, err := loadEntries()
+	if err != nil {
+		return err
 	}
-	// If the last entry has no EndTime set (considering it is not the entry for the current day)
-	if lastEntry != nil && lastEntry.EndTime.IsZero() {
+	var closeEntry string
+	var closeAt journal.ClockTime
+	if lastEntry := lastEntryBefore(entries, currentDayId); lastEntry != nil && lastEntry.EndTime.IsZero() {
 		fmt.Println("Warning: Last entry has no EndTime set.")
 		fmt.Printf("Do you want to set the EndTime for the last entry? (y/N): ")
 		userInput, err := getUserInput()
@@ -150,65 +147,62 @@ func startWorkDay(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		if userInput == "y" {
-			fmt.Printf("Please type the EndTime in HH:MM format: ")
+			fmt.Printf("Please type the EndTime in HH:MM format (HH:MM+1 for the next day): ")
 			endTimeStr, err := getUserInput()
 			if err != nil {
 				return err
 			}
-			endTime, err := journal.ValidateTimeFormat(endTimeStr)
-			if err != nil {
+			if closeAt, err = journal.ParseClockTime(endTimeStr); err != nil {
 				return err
 			}
-			finalEndTime := time.Date(
-				lastEntry.StartTime.Year(),
-				lastEntry.StartTime.Month(),
-				lastEntry.StartTime.Day(),
-				endTime.Hour(),
-				endTime.Minute(),
-				0,
-				0,
-				lastEntry.StartTime.Location())
-			lastEntry.EndTime = finalEndTime
-			previousEndTime = &finalEndTime
-
-			err = journal.SaveEntries(entries, journalPath)
-			if err != nil {
-				return err
-			}
-
-			fmt.Println("Endtime set for the last entry.")
+			closeEntry = lastEntry.ID
 		}
 	}
 
-	dateStr := now.Format("2006-01-02")
-	_, idx := journal.FetchEntryByID(currentDayId, entries)
-	isNewEntry := idx == -1
-
-	if idx != -1 {
-		fmt.Printf("There is already an entry for %s. Do you want to override it? (y/N): ", dateStr)
-		userInput, err := getUserInput()
+	err = withStore(func(store journal.Store) error {
+		entries, err := store.All()
 		if err != nil {
 			return err
 		}
-		if userInput != "y" {
-			fmt.Println("No changes made...")
-			return nil
+
+		var changes []journal.Change
+		if closeEntry != "" {
+			// The answers only hold if the entry is still the unfinished one
+			lastEntry := lastEntryBefore(entries, currentDayId)
+			if lastEntry == nil || lastEntry.ID != closeEntry || !lastEntry.EndTime.IsZero() {
+				return fmt.Errorf("the last entry changed while waiting for an answer, nothing was changed")
+			}
+			finalEndTime := closeAt.On(lastEntry.StartTime)
+			lastEntry.SetEndTime(finalEndTime)
+			previousEndTime = &finalEndTime
+			changes = append(changes, journal.Change{Entry: *lastEntry})
 		}
-		entries[idx] = *journal.NewJournalEntry()
-	} else {
-		newEntry := journal.NewJournalEntry()
-		entries = append(entries, *newEntry)
-	}
 
-	err = journal.SaveEntries(entries, journalPath)
+		current, idx := journal.FetchEntryByID(currentDayId, entries)
+		isNewEntry = idx == -1
+		if isNewEntry {
+			changes = append(changes, journal.Change{Entry: *journal.NewJournalEntryIn(now.Location())})
+		} else {
+			if err := current.StartSession(now); err != nil {
+				return err
+			}
+			session = len(current.Sessions)
+			changes = append(changes, journal.Change{Entry: *current})
+		}
+		return store.Write(changes)
+	})
 	if err != nil {
 		return err
 	}
+	if previousEndTime != nil {
+		fmt.Println("Endtime set for the last entry.")
+	}
 
 	// Create and run the Bubble Tea program for styled confirmation
 	model := startModel{
 		startTime:       now,
 		isNewEntry:      isNewEntry,
+		session:         session,
 		previousEndTime: previousEndTime,
 	}
 
@@ -217,6 +211,18 @@ func startWorkDay(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+// lastEntryBefore returns the most recent entry of entries, sorted by ID,
+// that is not the entry of the day with ID currentDayId, or nil if there is
+// none.
+func lastEntryBefore(entries []journal.JournalEntry, currentDayId string) *journal.JournalEntry {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].ID != currentDayId {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
 func getUserInput() (string, error) {
 
 	var userInput string
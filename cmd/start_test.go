@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+func TestLastEntryBefore(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []journal.JournalEntry
+		today   string
+		wantID  string
+	}{
+		{name: "no entries", today: "20260603"},
+		{name: "only today", entries: []journal.JournalEntry{{ID: "20260603"}}, today: "20260603"},
+		{name: "latest entry before today", entries: []journal.JournalEntry{{ID: "20260601"}, {ID: "20260602"}}, today: "20260603", wantID: "20260602"},
+		{name: "today is skipped", entries: []journal.JournalEntry{{ID: "20260602"}, {ID: "20260603"}}, today: "20260603", wantID: "20260602"},
+		{name: "short IDs do not panic", entries: []journal.JournalEntry{{ID: "2026"}, {ID: "20260603"}}, today: "20260603", wantID: "2026"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lastEntryBefore(tt.entries, tt.today)
+			if tt.wantID == "" {
+				if got != nil {
+					t.Fatalf("lastEntryBefore() = %q, want nil", got.ID)
+				}
+				return
+			}
+			if got == nil || got.ID != tt.wantID {
+				t.Fatalf("lastEntryBefore() = %v, want entry %q", got, tt.wantID)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,7 +10,6 @@ import (
 	"github.com/deadpyxel/workday/internal/journal"
 	"github.com/deadpyxel/workday/internal/styles"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // statusCmd represents the status command
@@ -35,6 +35,7 @@ type statusModel struct {
 	currentWorkTime  time.Duration
 	hasLunchBreak    bool
 	lunchBreakNeeded bool
+	schedule         daySchedule
 	width            int
 	height           int
 	quitting         bool
@@ -65,7 +66,11 @@ func (m statusModel) View() string {
 	if m.quitting {
 		return ""
 	}
+	return m.render(renderOptions{Interactive: true})
+}
 
+// render renders the view, see renderOptions.
+func (m statusModel) render(opts renderOptions) string {
 	var content strings.Builder
 
 	// Title
@@ -73,15 +78,28 @@ func (m statusModel) View() string {
 	content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("📊 Workday Status - %s", dateStr)))
 	content.WriteString("\n\n")
 
+	if m.entry.IsDayOff() {
+		content.WriteString(styles.LabelStyle.Render("Day off:") + " " + styles.InfoBlueStyle.Render(dayOffLabel(*m.entry)))
+		content.WriteString("\n\n")
+	}
+
 	// Current Time Section
 	content.WriteString(styles.SectionStyle.Render("🕐 Current Progress"))
 	content.WriteString("\n")
 
 	startTime := m.entry.StartTime.Format("15:04")
 	content.WriteString(styles.LabelStyle.Render("Started:") + " " + styles.ValueStyle.Render(startTime))
+	if m.schedule.Start != nil {
+		content.WriteString(" " + styles.HelpStyle.Render(describeStart(m.entry.StartTime, *m.schedule.Start)))
+	}
 	content.WriteString("\n")
 
-	currentTime := time.Now().Format("15:04")
+	if sessions := m.entry.WorkSessions(); len(sessions) > 1 {
+		content.WriteString(styles.LabelStyle.Render("Sessions:") + " " + styles.ValueStyle.Render(formatSessions(sessions, m.entry.StartTime)))
+		content.WriteString("\n")
+	}
+
+	currentTime := currentTime().Format("15:04")
 	content.WriteString(styles.LabelStyle.Render("Current:") + " " + styles.ValueStyle.Render(currentTime))
 	content.WriteString("\n")
 
@@ -97,6 +115,9 @@ func (m statusModel) View() string {
 	content.WriteString(styles.SectionStyle.Render("🎯 Expected End Time"))
 	content.WriteString("\n")
 
+	content.WriteString(styles.LabelStyle.Render("Target:") + " " + styles.ValueStyle.Render(formatDuration(m.schedule.MinWorkTime)))
+	content.WriteString("\n")
+
 	expectedEndStr := m.expectedEndTime.Format("15:04")
 	content.WriteString(styles.LabelStyle.Render("Expected End:") + " " + styles.ValueStyle.Render(expectedEndStr))
 	content.WriteString("\n")
@@ -112,50 +133,58 @@ func (m statusModel) View() string {
 	}
 	content.WriteString("\n")
 
-	// Lunch Break Status
-	content.WriteString("\n")
-	content.WriteString(styles.SectionStyle.Render("🍽️ Lunch Break"))
-	content.WriteString("\n")
+	// Lunch Break Status, not needed on a day off
+	if !m.entry.IsDayOff() {
+		content.WriteString("\n")
+		content.WriteString(styles.SectionStyle.Render("🍽️ Lunch Break"))
+		content.WriteString("\n")
 
-	if m.hasLunchBreak {
-		content.WriteString(styles.SuccessStyle.Render("✅ Lunch break completed"))
-	} else {
-		content.WriteString(styles.ErrorStyle.Render("⚠️  Lunch break needed (1h minimum)"))
+		if m.hasLunchBreak {
+			content.WriteString(styles.SuccessStyle.Render("✅ Lunch break completed"))
+		} else {
+			content.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("⚠️  Lunch break needed (%s minimum)", formatDuration(m.schedule.LunchTime))))
+		}
+		content.WriteString("\n")
+	}
+
+	// Findings from earlier sessions of the day
+	if findings := m.entry.ActiveFindings(); len(findings) > 0 {
+		content.WriteString("\n")
+		content.WriteString(styles.SectionStyle.Render("🔍 Findings"))
+		content.WriteString("\n")
+		content.WriteString(renderFindings(findings))
 	}
-	content.WriteString("\n")
 
 	// Help
 	content.WriteString("\n")
-	content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
 
 	return content.String()
 }
 
 func showWorkdayStatus(cmd *cobra.Command, args []string) error {
 	// Load configuration
-	journalPath := viper.GetString("journalPath")
-	minWorkTime, err := time.ParseDuration(viper.GetString("minWorkTime"))
-	if err != nil {
-		return fmt.Errorf("invalid minimum work time format in config: %v", err)
-	}
-	lunchTime, err := time.ParseDuration(viper.GetString("lunchTime"))
+	schedule, err := loadSchedule()
 	if err != nil {
-		return fmt.Errorf("invalid lunch time format in config: %v", err)
+		return err
 	}
 
 	// Load journal entries
-	entries, err := journal.LoadEntries(journalPath)
+	// Find current day entry
+	now := currentTime()
+	entry, err := loadCurrentEntry(now)
+	if errors.Is(err, journal.ErrEntryNotFound) {
+		return fmt.Errorf("no entry found for today. Start your workday first with 'workday start'")
+	}
 	if err != nil {
 		return err
 	}
 
-	// Find current day entry
-	now := time.Now()
-	currentDayId := now.Format("20060102")
-	entry, _ := journal.FetchEntryByID(currentDayId, entries)
-	if entry == nil {
-		return fmt.Errorf("no entry found for today. Start your workday first with 'workday start'")
-	}
+	// The targets of the weekday the entry started on
+	day := statusSchedule(schedule, entry)
+	minWorkTime, lunchTime := day.MinWorkTime, day.LunchTime
 
 	// Calculate expected end time
 	expectedEndTime, timeRemaining, currentWorkTime := calculateExpectedEndTime(entry, minWorkTime, lunchTime, now)
@@ -178,22 +207,63 @@ func showWorkdayStatus(cmd *cobra.Command, args []string) error {
 		currentWorkTime:  currentWorkTime,
 		hasLunchBreak:    hasLunchBreak,
 		lunchBreakNeeded: !hasLunchBreak,
+		schedule:         day,
 	}
 
-	p := tea.NewProgram(&model)
-	_, err = p.Run()
-	return err
+	state := "working"
+	if entry.OpenSession() == nil {
+		state = "finished"
+	} else if len(entry.Breaks) > 0 && entry.Breaks[len(entry.Breaks)-1].EndTime.IsZero() {
+		state = "on_break"
+	}
+	data := statusJSON{
+		Date:             entry.StartTime.Format("2006-01-02"),
+		State:            state,
+		WorkedMinutes:    minutes(currentWorkTime),
+		TargetMinutes:    minutes(minWorkTime),
+		ExpectedEnd:      formatJSONTime(expectedEndTime),
+		RemainingMinutes: minutes(timeRemaining),
+		LunchBreakTaken:  hasLunchBreak,
+		Entry:            newEntryJSON(*entry, schedule),
+	}
+	return showReport(&model, data)
 }
 
-// calculateExpectedEndTime calculates when the workday should end based on minimum work requirements
+// statusSchedule returns the schedule of the day of entry. A day off has no
+// target work time and needs no lunch break.
+func statusSchedule(schedule workSchedule, entry *journal.JournalEntry) daySchedule {
+	day := schedule.For(entry.StartTime)
+	day.MinWorkTime = schedule.TargetFor(entry)
+	if entry.IsDayOff() {
+		day.LunchTime = 0
+	}
+	return day
+}
+
+// calculateExpectedEndTime calculates when the workday should end based on minimum work requirements.
+// Work from sessions that already ended counts towards the minimum, so the
+// expected end is measured from the start of the open session. With no open
+// session it is measured from now.
 func calculateExpectedEndTime(entry *journal.JournalEntry, minWorkTime, lunchTime time.Duration, now time.Time) (time.Time, time.Duration, time.Duration) {
+	// Work time of the sessions that already ended, breaks excluded
+	closedWorkTime := entry.TotalWorkTime()
+
+	sessionStart := now
+	if open := entry.OpenSession(); open != nil {
+		sessionStart = open.StartTime
+	}
+
 	// Calculate current work time (excluding breaks)
-	currentWorkTime := now.Sub(entry.StartTime)
-	
-	// Subtract completed breaks and handle ongoing breaks
+	currentWorkTime := now.Sub(sessionStart)
+
+	// Subtract completed breaks of the open session and handle ongoing breaks
 	var totalBreakTime time.Duration
 	var ongoingBreakStart time.Time
 	for _, br := range entry.Breaks {
+		if br.StartTime.Before(sessionStart) {
+			// Taken during an earlier session, already accounted for
+			continue
+		}
 		if !br.EndTime.IsZero() {
 			// Completed break
 			totalBreakTime += br.Duration()
@@ -202,14 +272,15 @@ func calculateExpectedEndTime(entry *journal.JournalEntry, minWorkTime, lunchTim
 			ongoingBreakStart = br.StartTime
 		}
 	}
-	
+
 	// If there's an ongoing break, calculate work time up to the break start
 	if !ongoingBreakStart.IsZero() {
-		currentWorkTime = ongoingBreakStart.Sub(entry.StartTime)
+		currentWorkTime = ongoingBreakStart.Sub(sessionStart)
 	}
-	
+
 	// Subtract completed breaks from current work time
 	currentWorkTime -= totalBreakTime
+	currentWorkTime += closedWorkTime
 
 	// Check if we need to account for lunch break
 	hasLunchBreak := false
@@ -221,9 +292,9 @@ func calculateExpectedEndTime(entry *journal.JournalEntry, minWorkTime, lunchTim
 	}
 
 	// Calculate expected end time
-	expectedEndTime := entry.StartTime.Add(minWorkTime)
+	expectedEndTime := sessionStart.Add(minWorkTime - closedWorkTime)
 	expectedEndTime = expectedEndTime.Add(totalBreakTime)
-	
+
 	// Add lunch break time if not taken yet
 	if !hasLunchBreak {
 		expectedEndTime = expectedEndTime.Add(lunchTime)
@@ -238,6 +309,20 @@ func calculateExpectedEndTime(entry *journal.JournalEntry, minWorkTime, lunchTim
 	return expectedEndTime, timeRemaining, currentWorkTime
 }
 
+// describeStart compares the start of the day with the scheduled start, e.g.
+// "(scheduled 09:00, 15m late)".
+func describeStart(started time.Time, scheduled journal.ClockTime) string {
+	diff := started.Sub(scheduled.On(started)).Round(time.Minute)
+	switch {
+	case diff > 0:
+		return fmt.Sprintf("(scheduled %s, %s late)", scheduled, formatDuration(diff))
+	case diff < 0:
+		return fmt.Sprintf("(scheduled %s, %s early)", scheduled, formatDuration(-diff))
+	default:
+		return fmt.Sprintf("(scheduled %s)", scheduled)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(statusCmd)
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -97,6 +98,29 @@ func TestCalculateExpectedEndTime(t *testing.T) {
 			expectedEndTime:  time.Date(2024, 1, 1, 18, 50, 0, 0, time.UTC), // 9:00 + 8h20m + 30m breaks + 1h lunch
 			expectedWorkTime: 6*time.Hour + 30*time.Minute,                  // 7 hours total - 30m breaks
 		},
+		{
+			name: "Second session counts work from the first",
+			entry: &journal.JournalEntry{
+				ID:        "20240101",
+				StartTime: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+				Sessions: []journal.Session{
+					{StartTime: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+					{StartTime: time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC)},
+				},
+				Breaks: []journal.Break{
+					{
+						StartTime: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+						EndTime:   time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+						Reason:    "lunch",
+					},
+				},
+			},
+			minWorkTime:      8*time.Hour + 20*time.Minute,
+			lunchTime:        1 * time.Hour,
+			now:              time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC),
+			expectedEndTime:  time.Date(2024, 1, 1, 19, 20, 0, 0, time.UTC), // 14:00 + 8h20m - 3h already worked
+			expectedWorkTime: 4 * time.Hour,                                  // 3h in the first session + 1h in the second
+		},
 	}
 
 	for _, tt := range tests {
@@ -163,4 +187,37 @@ func TestCalculateExpectedEndTimeWithOngoingBreak(t *testing.T) {
 	if timeRemaining <= 0 {
 		t.Errorf("calculateExpectedEndTime() timeRemaining = %v, should be positive", timeRemaining)
 	}
-}
\ No newline at end of file
+}
+func TestStatusOnDayOff(t *testing.T) {
+	var schedule workSchedule
+	for day := range schedule {
+		schedule[day] = daySchedule{MinWorkTime: 8 * time.Hour, MaxWorkTime: 10 * time.Hour, LunchTime: time.Hour}
+	}
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		dayType   journal.DayType
+		want      daySchedule
+		wantLunch bool
+	}{
+		{name: "work day keeps the schedule", want: schedule[time.Monday], wantLunch: true},
+		{name: "day off has no target and no lunch", dayType: journal.DayHoliday, want: daySchedule{MaxWorkTime: 10 * time.Hour}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &journal.JournalEntry{ID: "20240101", StartTime: start, DayType: tt.dayType}
+			day := statusSchedule(schedule, entry)
+			if day != tt.want {
+				t.Fatalf("statusSchedule() = %+v, want %+v", day, tt.want)
+			}
+
+			model := statusModel{entry: entry, date: start, expectedEndTime: start, schedule: day}
+			view := model.render(renderOptions{})
+			if got := strings.Contains(view, "Lunch break needed"); got != tt.wantLunch {
+				t.Errorf("render() shows the lunch break warning = %v, want %v:\n%s", got, tt.wantLunch, view)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// storageCmd represents the storage command
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Manage the storage backend of the journal",
+	Long: `The journal can be kept in a single JSON file (the default), in a SQLite
+database, or split into one JSON file per month (YYYY/MM.json under a journal
+directory). The backend is selected with the storage.backend config key:
+
+  storage:
+    backend: sqlite          # json, sqlite or monthly
+    path: /path/to/journal.sqlite
+
+The JSON backend always uses journalPath. When storage.path is not set, the
+monthly backend uses journalPath without its extension as the directory, and
+the SQLite backend uses journalPath with the extension replaced by .sqlite.`,
+}
+
+var storageConvertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Copy the journal from one storage backend to another",
+	Long: `The convert command copies every entry from one backend to another. Entries
+already in the destination with the same date are replaced; the source is left
+untouched.
+
+After converting, set storage.backend to the new backend to start using it.
+
+Examples:
+  workday storage convert --to sqlite
+  workday storage convert --to monthly
+  workday storage convert --from sqlite --to json`,
+	Args: cobra.NoArgs,
+	RunE: convertStorage,
+}
+
+// convertStore copies every entry of the from backend into the to backend,
+// holding the lock of both locations, and returns how many entries were
+// copied.
+func convertStore(from, to string) (int, error) {
+	if from == to {
+		return 0, fmt.Errorf("source and destination backends are both %s", from)
+	}
+
+	src, err := journal.OpenStore(from, storeLocation(from), backupPolicy())
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	dst, err := journal.OpenStore(to, storeLocation(to), backupPolicy())
+	if err != nil {
+		return 0, err
+	}
+	defer dst.Close()
+
+	var copied int
+	err = withJournalLock(src.Location(), func() error {
+		return withJournalLock(dst.Location(), func() error {
+			var err error
+			copied, err = journal.CopyEntries(src, dst)
+			if err != nil {
+				return err
+			}
+			// A search index left from using the destination before is stale
+			return journal.RemoveSearchIndex(dst.Location())
+		})
+	})
+	return copied, err
+}
+
+func convertStorage(cmd *cobra.Command, args []string) error {
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	if from == "" {
+		from = viper.GetString("storage.backend")
+		if from == "" {
+			from = journal.BackendJSON
+		}
+	}
+
+	copied, err := convertStore(from, to)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ Copied %d entries from %s (%s) to %s (%s)",
+		copied, from, storeLocation(from), to, storeLocation(to))))
+	if viper.GetString("storage.backend") != to {
+		fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("💡 Set storage.backend to %s in your config to use it", to)))
+	}
+	return nil
+}
+
+func init() {
+	storageConvertCmd.Flags().String("from", "", "Backend to copy from (json, sqlite or monthly, defaults to storage.backend)")
+	storageConvertCmd.Flags().String("to", "", "Backend to copy to (json, sqlite or monthly)")
+	storageConvertCmd.MarkFlagRequired("to")
+	storageCmd.AddCommand(storageConvertCmd)
+	rootCmd.AddCommand(storageCmd)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/spf13/viper"
+)
+
+func setStorageViper(t *testing.T, journalPath, backend, path string) {
+	t.Helper()
+	original := viper.AllSettings()
+	t.Cleanup(func() {
+		viper.Reset()
+		for k, v := range original {
+			viper.Set(k, v)
+		}
+	})
+	viper.Set("journalPath", journalPath)
+	viper.Set("storage.backend", backend)
+	viper.Set("storage.path", path)
+}
+
+func TestStoreLocation(t *testing.T) {
+	setStorageViper(t, "/data/journal.json", "", "")
+
+	if got := storeLocation("json"); got != "/data/journal.json" {
+		t.Errorf("storeLocation(json) = %q, want the journal path", got)
+	}
+	if got := storeLocation("sqlite"); got != "/data/journal.sqlite" {
+		t.Errorf("storeLocation(sqlite) = %q, want %q", got, "/data/journal.sqlite")
+	}
+	if got := storeLocation("monthly"); got != "/data/journal" {
+		t.Errorf("storeLocation(monthly) = %q, want %q", got, "/data/journal")
+	}
+
+	viper.Set("storage.path", "/db/workday.db")
+	if got := storeLocation("sqlite"); got != "/db/workday.db" {
+		t.Errorf("storeLocation(sqlite) = %q, want storage.path", got)
+	}
+}
+
+func TestConvertStore(t *testing.T) {
+	dir := t.TempDir()
+	journalPath := bootstrapBreakJournal(t, []journal.JournalEntry{
+		{ID: "20260401", Notes: []journal.Note{{Contents: "first"}}},
+		{ID: "20260402", Notes: []journal.Note{{Contents: "second"}}},
+	})
+	setStorageViper(t, journalPath, "json", filepath.Join(dir, "journal.sqlite"))
+
+	if _, err := convertStore("json", "json"); err == nil {
+		t.Error("expected converting a backend to itself to fail")
+	}
+
+	copied, err := convertStore("json", "sqlite")
+	if err != nil {
+		t.Fatalf("convertStore() error = %v", err)
+	}
+	if copied != 2 {
+		t.Errorf("copied %d entries, want 2", copied)
+	}
+
+	// Switching the backend makes every command read the converted data
+	viper.Set("storage.backend", "sqlite")
+	entries, err := loadEntries()
+	if err != nil {
+		t.Fatalf("loadEntries() error = %v", err)
+	}
+	if len(entries) != 2 || entries[1].Notes[0].Contents != "second" {
+		t.Errorf("unexpected entries after conversion: %+v", entries)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/spf13/viper"
+)
+
+// storeLocation returns where the given backend keeps the journal. The JSON
+// backend uses journalPath; other backends use storage.path, defaulting to
+// journalPath without its extension: a directory for the monthly backend, and
+// a file named after the backend otherwise (e.g. journal.sqlite).
+func storeLocation(backend string) string {
+	journalPath := viper.GetString("journalPath")
+	if backend == "" || backend == journal.BackendJSON {
+		return journalPath
+	}
+	if path := viper.GetString("storage.path"); path != "" {
+		return path
+	}
+	base := strings.TrimSuffix(journalPath, filepath.Ext(journalPath))
+	if backend == journal.BackendMonthly {
+		return base
+	}
+	return base + "." + backend
+}
+
+// openStore opens the store selected by the storage.backend config value,
+// keeping the search index of the journal up to date with every change.
+// Callers must Close it when done.
+func openStore() (journal.Store, error) {
+	backend := viper.GetString("storage.backend")
+	store, err := journal.OpenStore(backend, storeLocation(backend), backupPolicy())
+	if err != nil {
+		return nil, err
+	}
+	return journal.NewIndexedStore(store), nil
+}
+
+// withStore opens the configured store and runs fn while holding the lock on
+// its location, so every read-modify-write cycle done inside fn is safe
+// against other workday processes.
+func withStore(fn func(store journal.Store) error) error {
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	return withJournalLock(store.Location(), func() error {
+		return fn(store)
+	})
+}
+
+// loadEntries returns every entry of the configured store, for commands that
+// only read the journal.
+func loadEntries() ([]journal.JournalEntry, error) {
+	store, err := openStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	return store.All()
+}
+
+// loadEntriesInRange returns the entries of the configured store between from
+// and to, inclusive. Backends that split the journal only read the part the
+// range covers.
+func loadEntriesInRange(from, to time.Time) ([]journal.JournalEntry, error) {
+	store, err := openStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	return store.Range(from, to)
+}
+
+// loadEntry returns the entry with the given ID from the configured store.
+func loadEntry(id string) (*journal.JournalEntry, error) {
+	store, err := openStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	return store.Get(id)
+}
+
+// loadCurrentEntry returns the entry that work done at now belongs to, see
+// journal.CurrentEntry.
+func loadCurrentEntry(now time.Time) (*journal.JournalEntry, error) {
+	store, err := openStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	return journal.CurrentEntry(store, now)
+}
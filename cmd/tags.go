@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+// tagTrendWeeks is how many weeks the trend column of the tags command covers.
+const tagTrendWeeks = 8
+
+// tagsCmd represents the tags command
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Lists the tags used in notes",
+	Long: `The tags command lists every tag used in the notes of the journal, most
+used first, with the number of notes carrying it, the first and the last day
+it was used on, and a trend of its use over the last 8 weeks of the range.
+
+Tags are counted ignoring case, so #Progress and #progress are one tag.
+Without --from the list starts with the first day of the journal, and without
+--to it ends today. Use 'workday search --tag' to see the notes of a tag.
+
+Examples:
+  workday tags
+  workday tags --from 2026-01-01 --to 2026-03-31
+  workday tags --output json | jq -r '.tags[].tag'`,
+	Args: cobra.NoArgs,
+	RunE: listTags,
+}
+
+// tagsRenameCmd represents the tags rename command
+var tagsRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Renames a tag on every note of the journal",
+	Long: `The tags rename command replaces the tag old with new on every note of the
+journal. Tags are matched ignoring case, so this also fixes the case of a tag,
+and notes that already carry new just lose old, which merges two tags.
+
+With --dry-run the notes that would change are listed and nothing is saved.
+
+Examples:
+  workday tags rename prog progress --dry-run
+  workday tags rename Meeting meeting`,
+	RunE: renameTagCommand,
+}
+
+type tagsModel struct {
+	period   string
+	stats    []journal.TagStat
+	until    time.Time // end of the trend
+	width    int
+	height   int
+	quitting bool
+}
+
+func (m tagsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m tagsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m tagsModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	return m.render(renderOptions{Interactive: true})
+}
+
+// render renders the view, see renderOptions.
+func (m tagsModel) render(opts renderOptions) string {
+	var content strings.Builder
+
+	// Title
+	content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("🏷️  Tags - %s", m.period)))
+	content.WriteString("\n\n")
+
+	if len(m.stats) == 0 {
+		content.WriteString(styles.InfoStyle.Render("No tagged notes in this period"))
+		content.WriteString("\n")
+	} else {
+		var rows [][]string
+		notes := 0
+		for _, stat := range m.stats {
+			rows = append(rows, []string{
+				"#" + stat.Tag,
+				fmt.Sprintf("%d", stat.Count),
+				stat.First.Format("Jan 2, 2006"),
+				stat.Last.Format("Jan 2, 2006"),
+				sparkline(stat.Trend(m.until, tagTrendWeeks)),
+			})
+			notes += stat.Count
+		}
+		content.WriteString(renderTable([]string{"Tag", "Notes", "First", "Last", fmt.Sprintf("Last %d weeks", tagTrendWeeks)}, rows))
+
+		// Summary Section
+		content.WriteString(styles.SummaryStyle.Render(fmt.Sprintf("📊 %d tags on %d tagged notes", len(m.stats), notes)))
+		content.WriteString("\n")
+	}
+
+	if opts.Interactive {
+		content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+	}
+
+	return content.String()
+}
+
+// sparkline draws counts as a row of bars scaled to the largest count, with
+// a dot for zero, e.g. "·▂▄█".
+func sparkline(counts []int) string {
+	bars := []rune("▁▂▃▄▅▆▇█")
+	highest := 0
+	for _, count := range counts {
+		if count > highest {
+			highest = count
+		}
+	}
+
+	var line strings.Builder
+	for _, count := range counts {
+		if count == 0 {
+			line.WriteRune('·')
+			continue
+		}
+		line.WriteRune(bars[(count*len(bars)-1)/highest])
+	}
+	return line.String()
+}
+
+func newTagJSON(stat journal.TagStat) tagJSON {
+	data := tagJSON{
+		Tag:   stat.Tag,
+		Count: stat.Count,
+		First: stat.First.Format("2006-01-02"),
+		Last:  stat.Last.Format("2006-01-02"),
+		Weeks: []tagWeekJSON{},
+	}
+	for week, count := range stat.Weekly {
+		data.Weeks = append(data.Weeks, tagWeekJSON{Week: week, Count: count})
+	}
+	// ISO weeks sort by their name
+	sort.Slice(data.Weeks, func(i, j int) bool { return data.Weeks[i].Week < data.Weeks[j].Week })
+	return data
+}
+
+func listTags(cmd *cobra.Command, args []string) error {
+	fromStr, _ := cmd.Flags().GetString("from")
+	toStr, _ := cmd.Flags().GetString("to")
+
+	from, to, period, err := resolveDateRange(fromStr, toStr, currentTime())
+	if err != nil {
+		return err
+	}
+	entries, err := loadEntriesBetween(from, to)
+	if err != nil {
+		return err
+	}
+
+	model := tagsModel{
+		period: period,
+		stats:  journal.SummarizeTags(entries),
+		until:  to,
+	}
+
+	data := tagsJSON{Period: period, Tags: []tagJSON{}}
+	for _, stat := range model.stats {
+		data.Tags = append(data.Tags, newTagJSON(stat))
+	}
+	return showReport(&model, data)
+}
+
+// tagRename is a note whose tags are changed by renaming a tag.
+type tagRename struct {
+	Date          time.Time
+	Index         int // position of the note in its entry
+	Contents      string
+	Before, After []string
+}
+
+// renameTag renames the tag old to new on every note of store, saving the
+// entries it changed in a single write, and returns the notes it changed, in
+// the order of the journal. With dryRun set nothing is saved.
+func renameTag(store journal.Store, old, new string, dryRun bool) ([]tagRename, error) {
+	entries, err := store.All()
+	if err != nil {
+		return nil, err
+	}
+
+	var renames []tagRename
+	var changes []journal.Change
+	for _, entry := range entries {
+		before := make([][]string, len(entry.Notes))
+		for i, note := range entry.Notes {
+			before[i] = note.Tags
+		}
+		renamed := entry.RenameTag(old, new)
+		for _, i := range renamed {
+			renames = append(renames, tagRename{
+				Date:     entry.StartTime,
+				Index:    i,
+				Contents: entry.Notes[i].Contents,
+				Before:   before[i],
+				After:    entry.Notes[i].Tags,
+			})
+		}
+		if len(renamed) > 0 {
+			changes = append(changes, journal.Change{Entry: entry})
+		}
+	}
+
+	if !dryRun {
+		if err := store.Write(changes); err != nil {
+			return nil, err
+		}
+	}
+	return renames, nil
+}
+
+func renameTagCommand(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	// Only the new tag must be valid, so tags added by hand can be fixed
+	old := strings.TrimPrefix(strings.TrimSpace(args[0]), "#")
+	tags, err := parseTagArgs(args[1:])
+	if err != nil {
+		return err
+	}
+	new := tags[0]
+	if old == new {
+		return fmt.Errorf("the old and the new tag are the same")
+	}
+
+	var renames []tagRename
+	err = withStore(func(store journal.Store) error {
+		var err error
+		renames, err = renameTag(store, old, new, dryRun)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(renames) == 0 {
+		fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("No notes are tagged #%s, nothing to rename", old)))
+		return nil
+	}
+	for _, rename := range renames {
+		fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("✅ %s note %d: %s [%s] → [%s]",
+			rename.Date.Format("Mon, Jan 2 2006"), rename.Index+1, rename.Contents,
+			strings.Join(rename.Before, ", "), strings.Join(rename.After, ", "))))
+	}
+	fmt.Println(styles.InfoStyle.Render(fmt.Sprintf("Renamed #%s to #%s on %d notes", old, new, len(renames))))
+	if dryRun {
+		fmt.Println(styles.InfoBlueStyle.Render("Dry run, nothing was saved"))
+	}
+	return nil
+}
+
+func init() {
+	tagsCmd.Flags().String("from", "", "Start of the range in YYYY-MM-DD format")
+	tagsCmd.Flags().String("to", "", "End of the range in YYYY-MM-DD format")
+	tagsRenameCmd.Flags().Bool("dry-run", false, "Show the notes that would change without saving anything")
+	tagsCmd.AddCommand(tagsRenameCmd)
+	rootCmd.AddCommand(tagsCmd)
+}
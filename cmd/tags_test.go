@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		counts []int
+		want   string
+	}{
+		{counts: []int{0, 0, 0}, want: "···"},
+		{counts: []int{0, 1, 2, 4, 8}, want: "·▁▂▄█"},
+		{counts: []int{3, 3}, want: "██"},
+	}
+	for _, tt := range tests {
+		if got := sparkline(tt.counts); got != tt.want {
+			t.Errorf("sparkline(%v) = %q, want %q", tt.counts, got, tt.want)
+		}
+	}
+}
+
+func TestNewTagJSON(t *testing.T) {
+	stat := journal.TagStat{
+		Tag:    "progress",
+		Count:  3,
+		First:  time.Date(2025, 12, 30, 9, 0, 0, 0, time.UTC),
+		Last:   time.Date(2026, 4, 8, 9, 0, 0, 0, time.UTC),
+		Weekly: map[string]int{"2026-W15": 2, "2026-W01": 1},
+	}
+	want := tagJSON{
+		Tag:   "progress",
+		Count: 3,
+		First: "2025-12-30",
+		Last:  "2026-04-08",
+		Weeks: []tagWeekJSON{{Week: "2026-W01", Count: 1}, {Week: "2026-W15", Count: 2}},
+	}
+	if diff := cmp.Diff(want, newTagJSON(stat)); diff != "" {
+		t.Errorf("newTagJSON() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenameTag(t *testing.T) {
+	now := time.Date(2026, 4, 8, 9, 0, 0, 0, time.Local)
+	entries := noteJournal(now)
+	entries[1].Notes = append(entries[1].Notes, journal.Note{Contents: "Retro", Tags: []string{"Team", "crew"}})
+	path := writeTempJournal(t, entries)
+	store := journal.NewJSONStore(path, journal.BackupPolicy{})
+
+	tagsOf := func() [][]string {
+		all, err := store.All()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var tags [][]string
+		for _, entry := range all {
+			for _, note := range entry.Notes {
+				tags = append(tags, note.Tags)
+			}
+		}
+		return tags
+	}
+	before := tagsOf()
+
+	renames, err := renameTag(store, "team", "crew", true)
+	if err != nil {
+		t.Fatalf("renameTag() error = %v", err)
+	}
+	var got [][]string
+	for _, rename := range renames {
+		got = append(got, rename.After)
+	}
+	if diff := cmp.Diff([][]string{{"crew"}, {"crew"}}, got); diff != "" {
+		t.Errorf("renamed tags mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(before, tagsOf()); diff != "" {
+		t.Errorf("dry run changed the journal (-want +got):\n%s", diff)
+	}
+
+	if _, err := renameTag(store, "team", "crew", false); err != nil {
+		t.Fatalf("renameTag() error = %v", err)
+	}
+	want := [][]string{{"planning"}, {"bug", "progress"}, {"crew"}, {"crew"}}
+	if diff := cmp.Diff(want, tagsOf()); diff != "" {
+		t.Errorf("journal after renaming mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/spf13/viper"
+)
+
+// configLocation returns the zone named by the timezone config value, or the
+// local zone when it is not set.
+func configLocation() (*time.Location, error) {
+	return journal.LoadTimezone(viper.GetString("timezone"))
+}
+
+// currentTime returns the current time in the configured zone, which decides
+// the day new entries belong to. initConfig rejects an unknown timezone, so
+// falling back to the local zone here only happens in tests.
+func currentTime() time.Time {
+	loc, err := configLocation()
+	if err != nil {
+		loc = time.Local
+	}
+	return time.Now().In(loc)
+}
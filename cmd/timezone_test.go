@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestCurrentTimeUsesConfiguredZone(t *testing.T) {
+	setStorageViper(t, t.TempDir()+"/journal.json", "json", "")
+
+	viper.Set("timezone", "Asia/Tokyo")
+	if got := currentTime().Location().String(); got != "Asia/Tokyo" {
+		t.Errorf("currentTime() zone = %s, want Asia/Tokyo", got)
+	}
+
+	viper.Set("timezone", "Nowhere/Special")
+	if _, err := configLocation(); err == nil {
+		t.Error("expected an error for an unknown timezone")
+	}
+}
@@ -0,0 +1,521 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/deadpyxel/workday/internal/journal"
+	"github.com/deadpyxel/workday/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+var trackCmd = &cobra.Command{
+	Use:   "track",
+	Short: "Tracks time spent on clients, projects and tasks",
+	Long: `The track command records time segments for the current workday.
+
+Each segment belongs to a client, a project and a task. The client is optional
+and defaults to "general" when only project/task is given.
+
+Examples:
+  workday track start acme/website/landing-page "Hero section"
+  workday track start website/bugfixes
+  workday track switch acme/website/review
+  workday track stop
+  workday track list 2024-05-27`,
+}
+
+var trackStartCmd = &cobra.Command{
+	Use:   "start <client>/<project>/<task> [description]",
+	Short: "Starts tracking time on a task",
+	Long: `Starts a new time segment on the current workday entry.
+
+Only one segment can be active at a time. Use 'workday track switch' to stop the
+active segment and start a new one in a single step.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: startTracking,
+}
+
+var trackStopCmd = &cobra.Command{
+	Use:   "stop [id]",
+	Short: "Stops tracking time",
+	Long:  "Stops the time segment with the given ID, or every active segment when no ID is given.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  stopTracking,
+}
+
+var trackSwitchCmd = &cobra.Command{
+	Use:   "switch <client>/<project>/<task> [description]",
+	Short: "Stops the active segment and starts tracking a new task",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE:  switchTracking,
+}
+
+var trackListCmd = &cobra.Command{
+	Use:   "list [date]",
+	Short: "List time segments for a specific date",
+	Long:  "Lists all time segments for today or a specific date (format: YYYY-MM-DD)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  listTracking,
+}
+
+// parseSegmentSpec splits a "client/project/task" or "project/task" spec into
+// its parts. The client is left empty when omitted so TimeSegment.GetClient
+// can apply its default.
+func parseSegmentSpec(spec string) (string, string, string, error) {
+	parts := strings.Split(spec, "/")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	switch len(parts) {
+	case 2:
+		if parts[0] == "" || parts[1] == "" {
+			break
+		}
+		return "", parts[0], parts[1], nil
+	case 3:
+		if parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			break
+		}
+		return parts[0], parts[1], parts[2], nil
+	}
+	return "", "", "", fmt.Errorf("invalid task '%s'. Use <client>/<project>/<task> or <project>/<task>", spec)
+}
+
+// startSegmentInJournal finds the entry for the day of now in store
+// and starts a new time segment described by spec. When switchActive is true
+// every active segment is stopped at now first; otherwise an active segment
+// makes the call fail. It returns the updated entry, the started segment and
+// the segments that were stopped, after persisting the change.
+func startSegmentInJournal(store journal.Store, now time.Time, spec, description string, switchActive bool) (*journal.JournalEntry, journal.TimeSegment, []journal.TimeSegment, error) {
+	client, project, task, err := parseSegmentSpec(spec)
+	if err != nil {
+		return nil, journal.TimeSegment{}, nil, err
+	}
+
+	var updated *journal.JournalEntry
+	var startedSegment journal.TimeSegment
+	var stoppedSegments []journal.TimeSegment
+	err = withJournalLock(store.Location(), func() error {
+		entry, err := journal.CurrentEntry(store, now)
+		if err != nil {
+			return err
+		}
+
+		active := entry.GetActiveTimeSegments()
+		if len(active) > 0 && !switchActive {
+			return fmt.Errorf("segment %s is already active (%s/%s/%s). Use 'workday track switch' or 'workday track stop'",
+				active[0].ID, active[0].GetClient(), active[0].Project, active[0].Task)
+		}
+
+		var stopped []journal.TimeSegment
+		for _, segment := range active {
+			if err := entry.StopTimeSegmentAt(segment.ID, now); err != nil {
+				return err
+			}
+			segment.EndTime = now
+			stopped = append(stopped, segment)
+		}
+
+		newSegment := journal.TimeSegment{
+			StartTime:   now,
+			Client:      client,
+			Project:     project,
+			Task:        task,
+			Description: description,
+		}
+		if err := entry.AddTimeSegment(newSegment); err != nil {
+			return err
+		}
+		started := entry.TimeSegments[len(entry.TimeSegments)-1]
+
+		if err := store.Upsert(*entry); err != nil {
+			return err
+		}
+
+		updated, startedSegment, stoppedSegments = entry, started, stopped
+		return nil
+	})
+	if err != nil {
+		return nil, journal.TimeSegment{}, nil, err
+	}
+	return updated, startedSegment, stoppedSegments, nil
+}
+
+// stopSegmentInJournal finds the entry for the day of now in store and stops the segment with segmentID at now. An empty segmentID stops every
+// active segment. It returns the updated entry and the stopped segments after
+// persisting the change.
+func stopSegmentInJournal(store journal.Store, now time.Time, segmentID string) (*journal.JournalEntry, []journal.TimeSegment, error) {
+	var updated *journal.JournalEntry
+	var stoppedSegments []journal.TimeSegment
+	err := withJournalLock(store.Location(), func() error {
+		entry, err := journal.CurrentEntry(store, now)
+		if err != nil {
+			return err
+		}
+
+		var targets []journal.TimeSegment
+		if segmentID == "" {
+			targets = entry.GetActiveTimeSegments()
+			if len(targets) == 0 {
+				return fmt.Errorf("no active time segment for the current day")
+			}
+		} else {
+			for _, segment := range entry.TimeSegments {
+				if segment.ID == segmentID {
+					targets = append(targets, segment)
+				}
+			}
+			if len(targets) == 0 {
+				return fmt.Errorf("time segment %s not found. Use 'workday track list' to see available segments", segmentID)
+			}
+		}
+
+		stopped := make([]journal.TimeSegment, 0, len(targets))
+		for _, segment := range targets {
+			if err := entry.StopTimeSegmentAt(segment.ID, now); err != nil {
+				return err
+			}
+			segment.EndTime = now
+			stopped = append(stopped, segment)
+		}
+
+		if err := store.Upsert(*entry); err != nil {
+			return err
+		}
+
+		updated, stoppedSegments = entry, stopped
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, stoppedSegments, nil
+}
+
+func startTracking(cmd *cobra.Command, args []string) error {
+	return runTrackStart(args, false)
+}
+
+func switchTracking(cmd *cobra.Command, args []string) error {
+	return runTrackStart(args, true)
+}
+
+func runTrackStart(args []string, switchActive bool) error {
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	description := ""
+	if len(args) > 1 {
+		description = args[1]
+	}
+
+	entry, started, stopped, err := startSegmentInJournal(store, currentTime(), args[0], description, switchActive)
+	if err != nil {
+		return err
+	}
+
+	model := trackModel{
+		isStarting:   true,
+		segment:      started,
+		stopped:      stopped,
+		totalTracked: trackedTime(entry.TimeSegments),
+	}
+
+	p := tea.NewProgram(&model)
+	_, err = p.Run()
+	return err
+}
+
+func stopTracking(cmd *cobra.Command, args []string) error {
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	segmentID := ""
+	if len(args) > 0 {
+		segmentID = args[0]
+	}
+
+	entry, stopped, err := stopSegmentInJournal(store, currentTime(), segmentID)
+	if err != nil {
+		return err
+	}
+
+	model := trackModel{
+		isStarting:   false,
+		segment:      stopped[len(stopped)-1],
+		stopped:      stopped[:len(stopped)-1],
+		totalTracked: trackedTime(entry.TimeSegments),
+	}
+
+	p := tea.NewProgram(&model)
+	_, err = p.Run()
+	return err
+}
+
+// trackedTime sums the duration of every completed segment.
+func trackedTime(segments []journal.TimeSegment) time.Duration {
+	var total time.Duration
+	for _, segment := range segments {
+		total += segment.Duration()
+	}
+	return total
+}
+
+// formatDuration renders a duration as "Xh Ym", or "Ym" when under an hour.
+func formatDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
+
+type trackModel struct {
+	isStarting   bool
+	segment      journal.TimeSegment
+	stopped      []journal.TimeSegment
+	totalTracked time.Duration
+	width        int
+	height       int
+	quitting     bool
+}
+
+func (m trackModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m trackModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m trackModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var content strings.Builder
+
+	// Title based on action
+	if m.isStarting {
+		content.WriteString(styles.TitleStyle.Render("⏱️  Tracking Started"))
+	} else {
+		content.WriteString(styles.TitleStyle.Render("✅ Tracking Stopped"))
+	}
+	content.WriteString("\n\n")
+
+	// Segment Details Section
+	content.WriteString(styles.SectionStyle.Render("📌 Segment Details"))
+	content.WriteString("\n")
+
+	content.WriteString(styles.LabelStyle.Render("ID:") + " " + styles.ValueStyle.Render(m.segment.ID))
+	content.WriteString("\n")
+	content.WriteString(styles.LabelStyle.Render("Client:") + " " + styles.ValueStyle.Render(m.segment.GetClient()))
+	content.WriteString("\n")
+	content.WriteString(styles.LabelStyle.Render("Project:") + " " + styles.ValueStyle.Render(m.segment.Project))
+	content.WriteString("\n")
+	content.WriteString(styles.LabelStyle.Render("Task:") + " " + styles.ValueStyle.Render(m.segment.Task))
+	content.WriteString("\n")
+
+	if m.segment.Description != "" {
+		content.WriteString(styles.LabelStyle.Render("Description:") + " " + styles.ValueStyle.Render(m.segment.Description))
+		content.WriteString("\n")
+	}
+
+	content.WriteString(styles.LabelStyle.Render("Started:") + " " + styles.ValueStyle.Render(m.segment.StartTime.Format("15:04")))
+	content.WriteString("\n")
+
+	if !m.segment.IsActive() {
+		content.WriteString(styles.LabelStyle.Render("Ended:") + " " + styles.ValueStyle.Render(m.segment.EndTime.Format("15:04")))
+		content.WriteString("\n")
+		content.WriteString(styles.LabelStyle.Render("Duration:") + " " + styles.SuccessStyle.Render(formatDuration(m.segment.Duration())))
+		content.WriteString("\n")
+	}
+
+	// Segments stopped along the way (switch, or stop without an ID)
+	if len(m.stopped) > 0 {
+		content.WriteString("\n")
+		content.WriteString(styles.SectionStyle.Render("⏹️  Also Stopped"))
+		content.WriteString("\n")
+		for _, segment := range m.stopped {
+			content.WriteString(styles.BreakStyle.Render(fmt.Sprintf("%s. %s/%s/%s (%s)",
+				segment.ID, segment.GetClient(), segment.Project, segment.Task, formatDuration(segment.Duration()))))
+			content.WriteString("\n")
+		}
+	}
+
+	// Daily Summary Section
+	content.WriteString("\n")
+	content.WriteString(styles.SectionStyle.Render("📊 Today's Summary"))
+	content.WriteString("\n")
+	content.WriteString(styles.LabelStyle.Render("Tracked:") + " " + styles.ValueStyle.Render(formatDuration(m.totalTracked)))
+	content.WriteString("\n")
+
+	// Action guidance
+	content.WriteString("\n")
+	if m.isStarting {
+		content.WriteString(styles.InfoStyle.Render("💡 Stop tracking with: workday track stop"))
+	} else {
+		content.WriteString(styles.InfoStyle.Render("💡 Review today's segments with: workday track list"))
+	}
+	content.WriteString("\n")
+
+	// Help
+	content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+
+	return content.String()
+}
+
+func listTracking(cmd *cobra.Command, args []string) error {
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	// Determine target date
+	targetDate := currentTime()
+	if len(args) > 0 {
+		targetDate, err = time.ParseInLocation("2006-01-02", args[0], targetDate.Location())
+		if err != nil {
+			return fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+		}
+	}
+
+	entry, err := store.Get(journal.DayID(targetDate, targetDate.Location()))
+	if err != nil {
+		return err
+	}
+
+	if len(entry.TimeSegments) == 0 {
+		fmt.Printf("No time segments found for %s\n", targetDate.Format("2006-01-02"))
+		return nil
+	}
+
+	model := trackListModel{
+		targetDate: targetDate,
+		segments:   entry.TimeSegments,
+	}
+
+	p := tea.NewProgram(&model)
+	_, err = p.Run()
+	return err
+}
+
+// Track list TUI model
+type trackListModel struct {
+	targetDate time.Time
+	segments   []journal.TimeSegment
+	width      int
+	height     int
+	quitting   bool
+}
+
+func (m trackListModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m trackListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m trackListModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var content strings.Builder
+
+	// Title
+	content.WriteString(styles.TitleStyle.Render(fmt.Sprintf("⏱️  Time Segments for %s", m.targetDate.Format("Monday, January 2, 2006"))))
+	content.WriteString("\n\n")
+
+	// Table header
+	content.WriteString(styles.HeaderStyle.Render("ID") + "  ")
+	content.WriteString(styles.HeaderStyle.Render("Start") + "  ")
+	content.WriteString(styles.HeaderStyle.Render("End") + "      ")
+	content.WriteString(styles.HeaderStyle.Render("Duration") + "  ")
+	content.WriteString(styles.HeaderStyle.Render("Client/Project/Task"))
+	content.WriteString("\n")
+	content.WriteString(strings.Repeat("─", 80) + "\n")
+
+	// Segment rows
+	activeCount := 0
+	for _, segment := range m.segments {
+		endTime := "ongoing"
+		duration := "N/A"
+		if segment.IsActive() {
+			activeCount++
+		} else {
+			endTime = segment.EndTime.Format("15:04")
+			duration = formatDuration(segment.Duration())
+		}
+
+		name := fmt.Sprintf("%s/%s/%s", segment.GetClient(), segment.Project, segment.Task)
+		if segment.Description != "" {
+			name += fmt.Sprintf(" (%s)", segment.Description)
+		}
+
+		content.WriteString(styles.CellStyle.Render(fmt.Sprintf("%-2s", segment.ID)) + "  ")
+		content.WriteString(styles.CellStyle.Render(fmt.Sprintf("%-5s", segment.StartTime.Format("15:04"))) + "  ")
+		content.WriteString(styles.CellStyle.Render(fmt.Sprintf("%-7s", endTime)) + "  ")
+		content.WriteString(styles.CellStyle.Render(fmt.Sprintf("%-8s", duration)) + "  ")
+		content.WriteString(styles.ValueStyle.Render(name))
+		content.WriteString("\n")
+	}
+
+	// Summary
+	content.WriteString("\n")
+	content.WriteString(styles.SummaryStyle.Render("Summary"))
+	content.WriteString("\n")
+	content.WriteString(styles.LabelStyle.Render("Segments:") + " " + styles.ValueStyle.Render(fmt.Sprintf("%d (%d active)", len(m.segments), activeCount)))
+	content.WriteString("\n")
+	content.WriteString(styles.LabelStyle.Render("Tracked:") + " " + styles.SuccessStyle.Render(formatDuration(trackedTime(m.segments))))
+	content.WriteString("\n")
+
+	content.WriteString(styles.HelpStyle.Render("Press 'q' or 'esc' to quit"))
+
+	return content.String()
+}
+
+func init() {
+	rootCmd.AddCommand(trackCmd)
+	trackCmd.AddCommand(trackStartCmd)
+	trackCmd.AddCommand(trackStopCmd)
+	trackCmd.AddCommand(trackSwitchCmd)
+	trackCmd.AddCommand(trackListCmd)
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deadpyxel/workday/internal/journal"
+)
+
+func TestParseSegmentSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		wantClient  string
+		wantProject string
+		wantTask    string
+		wantErr     bool
+	}{
+		{name: "client, project and task", spec: "acme/website/landing", wantClient: "acme", wantProject: "website", wantTask: "landing"},
+		{name: "project and task only", spec: "website/landing", wantProject: "website", wantTask: "landing"},
+		{name: "surrounding spaces are trimmed", spec: " acme / website / landing ", wantClient: "acme", wantProject: "website", wantTask: "landing"},
+		{name: "single part", spec: "website", wantErr: true},
+		{name: "too many parts", spec: "a/b/c/d", wantErr: true},
+		{name: "empty task", spec: "acme/website/", wantErr: true},
+		{name: "empty project", spec: "/landing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, project, task, err := parseSegmentSpec(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSegmentSpec(%q) expected error, got nil", tt.spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSegmentSpec(%q) unexpected error: %v", tt.spec, err)
+			}
+			if client != tt.wantClient || project != tt.wantProject || task != tt.wantTask {
+				t.Errorf("parseSegmentSpec(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					tt.spec, client, project, task, tt.wantClient, tt.wantProject, tt.wantTask)
+			}
+		})
+	}
+}
+
+func TestStartSegmentInJournal(t *testing.T) {
+	now := time.Date(2026, 6, 3, 10, 0, 0, 0, time.Local)
+	todayID := now.Format("20060102")
+
+	t.Run("starts a segment on today's entry and persists it", func(t *testing.T) {
+		journalPath := bootstrapBreakJournal(t, []journal.JournalEntry{
+			{ID: todayID, StartTime: now.Add(-time.Hour)},
+		})
+
+		_, started, stopped, err := startSegmentInJournal(openBreakStore(journalPath), now, "acme/website/landing", "hero", false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stopped) != 0 {
+			t.Errorf("expected no stopped segments, got %d", len(stopped))
+		}
+		if started.ID != "1" || started.Client != "acme" || started.Project != "website" || started.Task != "landing" {
+			t.Errorf("unexpected started segment: %+v", started)
+		}
+
+		reloaded := loadBreakJournal(t, journalPath)
+		if len(reloaded[0].TimeSegments) != 1 {
+			t.Fatalf("expected 1 persisted segment, got %d", len(reloaded[0].TimeSegments))
+		}
+		if !reloaded[0].TimeSegments[0].IsActive() {
+			t.Error("expected persisted segment to be active")
+		}
+		if reloaded[0].TimeSegments[0].Description != "hero" {
+			t.Errorf("Description = %q, want %q", reloaded[0].TimeSegments[0].Description, "hero")
+		}
+	})
+
+	t.Run("refuses to start while another segment is active", func(t *testing.T) {
+		journalPath := bootstrapBreakJournal(t, []journal.JournalEntry{
+			{ID: todayID, StartTime: now.Add(-time.Hour), TimeSegments: []journal.TimeSegment{
+				{ID: "1", StartTime: now.Add(-30 * time.Minute), Project: "website", Task: "landing"},
+			}},
+		})
+
+		_, _, _, err := startSegmentInJournal(openBreakStore(journalPath), now, "website/review", "", false)
+		if err == nil {
+			t.Fatal("expected error for already active segment, got nil")
+		}
+		if !strings.Contains(err.Error(), "workday track switch") {
+			t.Errorf("error = %q, want hint about track switch", err.Error())
+		}
+	})
+
+	t.Run("switch stops the active segment at now", func(t *testing.T) {
+		journalPath := bootstrapBreakJournal(t, []journal.JournalEntry{
+			{ID: todayID, StartTime: now.Add(-time.Hour), TimeSegments: []journal.TimeSegment{
+				{ID: "1", StartTime: now.Add(-30 * time.Minute), Project: "website", Task: "landing"},
+			}},
+		})
+
+		_, started, stopped, err := startSegmentInJournal(openBreakStore(journalPath), now, "website/review", "", true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stopped) != 1 || stopped[0].ID != "1" {
+			t.Fatalf("expected segment 1 to be stopped, got %+v", stopped)
+		}
+		if started.ID != "2" {
+			t.Errorf("started ID = %q, want %q", started.ID, "2")
+		}
+
+		reloaded := loadBreakJournal(t, journalPath)
+		segments := reloaded[0].TimeSegments
+		if len(segments) != 2 {
+			t.Fatalf("expected 2 persisted segments, got %d", len(segments))
+		}
+		if segments[0].Duration() != 30*time.Minute {
+			t.Errorf("stopped segment duration = %v, want 30m", segments[0].Duration())
+		}
+		if !segments[1].IsActive() {
+			t.Error("expected new segment to be active")
+		}
+	})
+
+	t.Run("missing entry for today is refused", func(t *testing.T) {
+		journalPath := bootstrapBreakJournal(t, []journal.JournalEntry{})
+
+		_, _, _, err := startSegmentInJournal(openBreakStore(journalPath), now, "website/landing", "", false)
+		if err == nil {
+			t.Fatal("expected error for missing entry, got nil")
+		}
+	})
+}
+
+func TestStopSegmentInJournal(t *testing.T) {
+	now := time.Date(2026, 6, 3, 10, 0, 0, 0, time.Local)
+	todayID := now.Format("20060102")
+	seed := func() []journal.JournalEntry {
+		return []journal.JournalEntry{
+			{ID: todayID, StartTime: now.Add(-2 * time.Hour), TimeSegments: []journal.TimeSegment{
+				{ID: "1", StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour), Project: "website", Task: "landing"},
+				{ID: "2", StartTime: now.Add(-time.Hour), Project: "website", Task: "review"},
+			}},
+		}
+	}
+
+	t.Run("without ID stops every active segment", func(t *testing.T) {
+		journalPath := bootstrapBreakJournal(t, seed())
+
+		_, stopped, err := stopSegmentInJournal(openBreakStore(journalPath), now, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stopped) != 1 || stopped[0].ID != "2" {
+			t.Fatalf("expected segment 2 to be stopped, got %+v", stopped)
+		}
+
+		reloaded := loadBreakJournal(t, journalPath)
+		if len(reloaded[0].GetActiveTimeSegments()) != 0 {
+			t.Error("expected no active segments after stop")
+		}
+	})
+
+	t.Run("with ID of a completed segment fails", func(t *testing.T) {
+		journalPath := bootstrapBreakJournal(t, seed())
+
+		if _, _, err := stopSegmentInJournal(openBreakStore(journalPath), now, "1"); err == nil {
+			t.Fatal("expected error stopping an already stopped segment, got nil")
+		}
+	})
+
+	t.Run("with unknown ID fails", func(t *testing.T) {
+		journalPath := bootstrapBreakJournal(t, seed())
+
+		if _, _, err := stopSegmentInJournal(openBreakStore(journalPath), now, "9"); err == nil {
+			t.Fatal("expected error for unknown segment, got nil")
+		}
+	})
+}
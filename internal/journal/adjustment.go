@@ -0,0 +1,76 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Adjustment is a manual change to the overtime balance recorded on a day,
+// e.g. -5h for overtime that was paid out, or hours carried over from before
+// the journal was started.
+type Adjustment struct {
+	Amount time.Duration // added to the balance, negative to take time off it
+	Reason string
+}
+
+// adjustmentJSON is how an Adjustment is stored, with the amount written as a
+// duration string such as "-5h0m0s".
+type adjustmentJSON struct {
+	Amount string `json:"amount"`
+	Reason string `json:"reason"`
+}
+
+func (a Adjustment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(adjustmentJSON{Amount: a.Amount.String(), Reason: a.Reason})
+}
+
+func (a *Adjustment) UnmarshalJSON(data []byte) error {
+	var raw adjustmentJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	amount, err := time.ParseDuration(raw.Amount)
+	if err != nil {
+		return fmt.Errorf("invalid adjustment amount %q: %w", raw.Amount, err)
+	}
+	a.Amount, a.Reason = amount, raw.Reason
+	return nil
+}
+
+// NewEmptyEntry builds an entry for the calendar day of date that holds no
+// work. It starts and ends at midnight and has no sessions; days that are off
+// or only carry balance adjustments are recorded this way, and workday start
+// adds sessions to them like to any other day.
+func NewEmptyEntry(date time.Time) *JournalEntry {
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return &JournalEntry{
+		ID:        midnight.Format("20060102"),
+		Timezone:  LocationName(date.Location()),
+		StartTime: midnight,
+		EndTime:   midnight,
+	}
+}
+
+// AddAdjustment records a manual change of amount to the overtime balance.
+func (j *JournalEntry) AddAdjustment(amount time.Duration, reason string) error {
+	if amount == 0 {
+		return ValidationError("adjustment", "the amount cannot be zero")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ValidationError("adjustment", "a reason is required")
+	}
+	j.Adjustments = append(j.Adjustments, Adjustment{Amount: amount, Reason: reason})
+	return nil
+}
+
+// AdjustmentTotal returns the sum of the balance adjustments of the entry.
+func (j *JournalEntry) AdjustmentTotal() time.Duration {
+	var total time.Duration
+	for _, adjustment := range j.Adjustments {
+		total += adjustment.Amount
+	}
+	return total
+}
@@ -0,0 +1,86 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddAdjustment(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  time.Duration
+		reason  string
+		wantErr bool
+	}{
+		{name: "overtime paid out", amount: -5 * time.Hour, reason: "Paid out"},
+		{name: "carried over", amount: 12*time.Hour + 30*time.Minute, reason: "From 2025"},
+		{name: "zero amount", amount: 0, reason: "Nothing", wantErr: true},
+		{name: "no reason", amount: time.Hour, reason: "  ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &JournalEntry{ID: "20260331"}
+			err := entry.AddAdjustment(tt.amount, tt.reason)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("expected ErrValidation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddAdjustment() error = %v", err)
+			}
+			if entry.AdjustmentTotal() != tt.amount {
+				t.Errorf("AdjustmentTotal() = %v, want %v", entry.AdjustmentTotal(), tt.amount)
+			}
+		})
+	}
+}
+
+func TestAdjustmentJSONRoundTrip(t *testing.T) {
+	entry := NewEmptyEntry(time.Date(2026, 3, 31, 17, 0, 0, 0, time.UTC))
+	if err := entry.AddAdjustment(-5*time.Hour, "Paid out overtime"); err != nil {
+		t.Fatal(err)
+	}
+	if err := entry.AddAdjustment(90*time.Minute, "Training"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"amount":"-5h0m0s"`) {
+		t.Errorf("expected the amount as a duration string, got %s", data)
+	}
+
+	var decoded JournalEntry
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Adjustments) != 2 || decoded.AdjustmentTotal() != -5*time.Hour+90*time.Minute {
+		t.Errorf("unexpected adjustments after decoding: %+v", decoded.Adjustments)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":"five hours","reason":"x"}`), &Adjustment{}); err == nil {
+		t.Error("expected an error for an invalid amount")
+	}
+}
+
+func TestNewEmptyEntry(t *testing.T) {
+	entry := NewEmptyEntry(time.Date(2026, 3, 31, 17, 0, 0, 0, time.UTC))
+
+	if entry.ID != "20260331" {
+		t.Errorf("ID = %s, want 20260331", entry.ID)
+	}
+	if result := ValidateEntry(entry); !result.IsValid {
+		t.Errorf("expected an empty entry to be valid, got %v", result.Error)
+	}
+	if len(entry.WorkSessions()) != 0 || entry.TotalWorkTime() != 0 {
+		t.Errorf("expected an empty entry to hold no work, got %v", entry.TotalWorkTime())
+	}
+}
@@ -0,0 +1,241 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultBackupCount is how many backups are kept when no count is configured.
+const DefaultBackupCount = 10
+
+// BackupTimestampFormat is the layout of the timestamp embedded in backup file
+// names. It sorts lexically in chronological order and is what `workday backup
+// restore` expects as its argument.
+const BackupTimestampFormat = "20060102-150405.000"
+
+// backupSuffix is the extension of every backup file.
+const backupSuffix = ".bak"
+
+// BackupPolicy controls where backups of a journal are kept and how many.
+//
+// An empty Dir keeps backups next to the journal. A Count of zero or less
+// disables backups entirely.
+type BackupPolicy struct {
+	Dir   string
+	Count int
+}
+
+// Backup is a timestamped copy of a journal file.
+type Backup struct {
+	Path      string
+	Timestamp time.Time
+	Size      int64
+}
+
+// ID returns the timestamp that identifies the backup on the command line.
+func (b Backup) ID() string {
+	return b.Timestamp.Format(BackupTimestampFormat)
+}
+
+// dir resolves the directory that holds the backups of filename.
+func (p BackupPolicy) dir(filename string) string {
+	if p.Dir != "" {
+		return p.Dir
+	}
+	dir := filepath.Dir(filename)
+	if dir == "" {
+		return "."
+	}
+	return dir
+}
+
+// backupPrefix is the file name prefix shared by every backup of filename.
+func backupPrefix(filename string) string {
+	return filepath.Base(filename) + "."
+}
+
+// SaveEntriesWithBackup copies the current journal at filename to a new
+// backup according to policy and then saves journalEntries with SaveEntries.
+// Nothing is saved when the backup cannot be taken.
+func SaveEntriesWithBackup(journalEntries []JournalEntry, filename string, policy BackupPolicy) error {
+	if _, err := CreateBackup(filename, policy, time.Now()); err != nil {
+		return err
+	}
+	return SaveEntries(journalEntries, filename)
+}
+
+// CreateBackup copies the journal at filename into the backup directory,
+// naming the copy after now, or the first later millisecond no other backup
+// is named after, and then removes the oldest backups so that at
+// most policy.Count remain. It returns the new backup, or nil when there was
+// nothing to back up (the journal does not exist or is empty) or backups are
+// disabled.
+func CreateBackup(filename string, policy BackupPolicy, now time.Time) (*Backup, error) {
+	if policy.Count <= 0 {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, JournalIOError("read", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	dir := policy.dir(filename)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, JournalIOError("create backup directory for", err)
+	}
+
+	backup := Backup{Timestamp: now.Truncate(time.Millisecond), Size: int64(len(data))}
+	// Timestamps have millisecond precision, so a backup taken in the same
+	// millisecond as another one is moved to the next free one
+	for {
+		backup.Path = filepath.Join(dir, backupPrefix(filename)+backup.ID()+backupSuffix)
+		if _, err := os.Stat(backup.Path); errors.Is(err, os.ErrNotExist) {
+			break
+		} else if err != nil {
+			return nil, JournalIOError("stat backup of", err)
+		}
+		backup.Timestamp = backup.Timestamp.Add(time.Millisecond)
+	}
+	err = writeFileAtomic(backup.Path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pruneBackups(filename, policy); err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// ListBackups returns the backups of the journal at filename, newest first.
+// A missing backup directory yields no backups rather than an error.
+func ListBackups(filename string, policy BackupPolicy) ([]Backup, error) {
+	dir := policy.dir(filename)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, JournalIOError("list backups of", err)
+	}
+
+	prefix := backupPrefix(filename)
+	var backups []Backup
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, backupSuffix) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), backupSuffix)
+		timestamp, err := time.ParseInLocation(BackupTimestampFormat, stamp, time.Local)
+		if err != nil {
+			// Not one of ours, e.g. a file the user copied by hand
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return nil, JournalIOError("list backups of", err)
+		}
+		backups = append(backups, Backup{Path: filepath.Join(dir, name), Timestamp: timestamp, Size: info.Size()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Timestamp.After(backups[j].Timestamp)
+	})
+	return backups, nil
+}
+
+// FindBackup looks up the backup of filename identified by timestamp. The
+// timestamp may be shortened to any prefix of a backup ID as long as it
+// matches exactly one backup.
+func FindBackup(filename string, policy BackupPolicy, timestamp string) (Backup, error) {
+	backups, err := ListBackups(filename, policy)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	var matches []Backup
+	for _, backup := range backups {
+		if backup.ID() == timestamp {
+			return backup, nil
+		}
+		if timestamp != "" && strings.HasPrefix(backup.ID(), timestamp) {
+			matches = append(matches, backup)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Backup{}, BackupNotFoundError(timestamp, "no backup matches this timestamp")
+	case 1:
+		return matches[0], nil
+	default:
+		return Backup{}, BackupNotFoundError(timestamp, "the timestamp matches several backups, use a longer one")
+	}
+}
+
+// RestoreBackup replaces the journal at filename with the backup identified by
+// timestamp (see FindBackup). The current journal is itself backed up first,
+// so a restore can be undone by restoring that newer backup. The backup must
+// contain valid JSON; nothing is changed otherwise.
+func RestoreBackup(filename string, policy BackupPolicy, timestamp string, now time.Time) (Backup, error) {
+	backup, err := FindBackup(filename, policy, timestamp)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	data, err := os.ReadFile(backup.Path)
+	if err != nil {
+		return Backup{}, JournalIOError("read backup of", err)
+	}
+	if !json.Valid(data) {
+		return Backup{}, InvalidBackupError(backup.ID())
+	}
+
+	if _, err := CreateBackup(filename, policy, now); err != nil {
+		return Backup{}, err
+	}
+
+	err = writeFileAtomic(filename, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+	if err != nil {
+		return Backup{}, err
+	}
+	return backup, nil
+}
+
+// pruneBackups removes all but the newest policy.Count backups of filename.
+func pruneBackups(filename string, policy BackupPolicy) error {
+	backups, err := ListBackups(filename, policy)
+	if err != nil {
+		return err
+	}
+	if len(backups) <= policy.Count {
+		return nil
+	}
+
+	for _, backup := range backups[policy.Count:] {
+		if err := os.Remove(backup.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return JournalIOError("remove old backup of", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCreateBackup(t *testing.T) {
+	base := time.Date(2026, 4, 17, 15, 30, 0, 0, time.Local)
+
+	t.Run("When the journal does not exist no backup is created", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "journal.json")
+
+		backup, err := CreateBackup(filename, BackupPolicy{Count: 3}, base)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if backup != nil {
+			t.Errorf("Expected no backup, got %+v", backup)
+		}
+	})
+
+	t.Run("When backups are disabled nothing is written", func(t *testing.T) {
+		dir := t.TempDir()
+		filename := filepath.Join(dir, "journal.json")
+		if err := os.WriteFile(filename, []byte(`[]`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := CreateBackup(filename, BackupPolicy{Count: 0}, base); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		backups, err := ListBackups(filename, BackupPolicy{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(backups) != 0 {
+			t.Errorf("Expected no backups, got %d", len(backups))
+		}
+	})
+
+	t.Run("When backups are taken in the same millisecond each gets its own name", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "journal.json")
+		policy := BackupPolicy{Count: 5}
+
+		for i := 0; i < 3; i++ {
+			if err := os.WriteFile(filename, []byte{byte('0' + i)}, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := CreateBackup(filename, policy, base); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		backups, err := ListBackups(filename, policy)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(backups) != 3 {
+			t.Fatalf("Expected 3 backups, got %d", len(backups))
+		}
+		// Newest first, each with the contents it was taken of
+		for i, backup := range backups {
+			data, _ := os.ReadFile(backup.Path)
+			if want := string(rune('2' - i)); string(data) != want {
+				t.Errorf("Expected backup %s to hold %s, got %s", backup.ID(), want, data)
+			}
+		}
+	})
+
+	t.Run("When more than count backups exist the oldest are pruned", func(t *testing.T) {
+		dir := t.TempDir()
+		filename := filepath.Join(dir, "journal.json")
+		backupDir := filepath.Join(dir, "backups")
+		policy := BackupPolicy{Dir: backupDir, Count: 3}
+
+		for i := 0; i < 5; i++ {
+			if err := os.WriteFile(filename, []byte(`{"version":1,"entries":[]}`), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := CreateBackup(filename, policy, base.Add(time.Duration(i)*time.Minute)); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		backups, err := ListBackups(filename, policy)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(backups) != 3 {
+			t.Fatalf("Expected 3 backups, got %d", len(backups))
+		}
+		if want := base.Add(4 * time.Minute).Format(BackupTimestampFormat); backups[0].ID() != want {
+			t.Errorf("Expected newest backup %s first, got %s", want, backups[0].ID())
+		}
+		if want := base.Add(2 * time.Minute).Format(BackupTimestampFormat); backups[2].ID() != want {
+			t.Errorf("Expected oldest kept backup %s last, got %s", want, backups[2].ID())
+		}
+		if filepath.Dir(backups[0].Path) != backupDir {
+			t.Errorf("Expected backups in %s, got %s", backupDir, backups[0].Path)
+		}
+	})
+}
+
+func TestSaveEntriesWithBackup(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "journal.json")
+	policy := BackupPolicy{Count: 5}
+
+	first := []JournalEntry{{ID: "20260417", Notes: []Note{{Contents: "first"}}}}
+	if err := SaveEntriesWithBackup(first, filename, policy); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second := []JournalEntry{{ID: "20260417", Notes: []Note{{Contents: "second"}}}}
+	if err := SaveEntriesWithBackup(second, filename, policy); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	backups, err := ListBackups(filename, policy)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// The first save had no journal to back up
+	if len(backups) != 1 {
+		t.Fatalf("Expected 1 backup, got %d", len(backups))
+	}
+
+	restored, err := LoadEntries(backups[0].Path)
+	if err != nil {
+		t.Fatalf("Expected backup to load, got %v", err)
+	}
+	if restored[0].Notes[0].Contents != "first" {
+		t.Errorf("Expected backup to hold the previous journal, got %q", restored[0].Notes[0].Contents)
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "journal.json")
+	policy := BackupPolicy{Count: 5}
+	base := time.Date(2026, 4, 17, 15, 30, 0, 0, time.Local)
+
+	if err := SaveEntries([]JournalEntry{{ID: "20260416"}}, filename); err != nil {
+		t.Fatal(err)
+	}
+	old, err := CreateBackup(filename, policy, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveEntries([]JournalEntry{{ID: "20260417"}}, filename); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("When the timestamp matches nothing ErrBackupNotFound is returned", func(t *testing.T) {
+		_, err := RestoreBackup(filename, policy, "19990101", base.Add(time.Hour))
+		if !errors.Is(err, ErrBackupNotFound) {
+			t.Errorf("Expected ErrBackupNotFound, got %v", err)
+		}
+	})
+
+	t.Run("When the timestamp prefix matches the journal is replaced", func(t *testing.T) {
+		restored, err := RestoreBackup(filename, policy, "20260417-1530", base.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if restored.ID() != old.ID() {
+			t.Errorf("Expected backup %s to be restored, got %s", old.ID(), restored.ID())
+		}
+
+		entries, err := LoadEntries(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || entries[0].ID != "20260416" {
+			t.Errorf("Expected restored journal with entry 20260416, got %+v", entries)
+		}
+
+		// The journal replaced by the restore is kept as a new backup
+		backups, err := ListBackups(filename, policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(backups) != 2 {
+			t.Fatalf("Expected 2 backups, got %d", len(backups))
+		}
+		undo, err := LoadEntries(backups[0].Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if undo[0].ID != "20260417" {
+			t.Errorf("Expected newest backup to hold the replaced journal, got %+v", undo)
+		}
+	})
+
+	t.Run("When the backup is not valid JSON the journal is left untouched", func(t *testing.T) {
+		corrupt := filepath.Join(dir, "journal.json."+base.Add(-time.Hour).Format(BackupTimestampFormat)+".bak")
+		if err := os.WriteFile(corrupt, []byte(`{"version":`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		before, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = RestoreBackup(filename, policy, base.Add(-time.Hour).Format(BackupTimestampFormat), base.Add(2*time.Hour))
+		if !errors.Is(err, ErrInvalidBackup) {
+			t.Errorf("Expected ErrInvalidBackup, got %v", err)
+		}
+		after, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(before) != string(after) {
+			t.Error("Expected journal to be unchanged")
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package journal
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Batch is a Store that collects the changes made through it, for commands
+// that change several entries, and writes them to the store it wraps at once
+// with Commit. Until then its reads see the store as the changes would leave
+// it, so later changes can build on earlier ones, and nothing is written if
+// the command fails or only runs dry.
+type Batch struct {
+	store   Store
+	changes []Change
+	pending map[string]*JournalEntry // latest version by ID, nil when deleted
+}
+
+// NewBatch returns an empty batch of changes to store.
+func NewBatch(store Store) *Batch {
+	return &Batch{store: store, pending: make(map[string]*JournalEntry)}
+}
+
+// Location returns the location of the wrapped store.
+func (b *Batch) Location() string {
+	return b.store.Location()
+}
+
+// All returns every entry of the store with the changes of the batch
+// applied, sorted by ID.
+func (b *Batch) All() ([]JournalEntry, error) {
+	entries, err := b.store.All()
+	if err != nil {
+		return nil, err
+	}
+	return b.overlay(entries, func(string) bool { return true })
+}
+
+// Get returns the entry with the given ID as the batch would leave it.
+func (b *Batch) Get(id string) (*JournalEntry, error) {
+	entry, ok := b.pending[id]
+	if !ok {
+		return b.store.Get(id)
+	}
+	if entry == nil {
+		return nil, EntryNotFoundError(id)
+	}
+	return cloneEntry(*entry)
+}
+
+// Range returns the entries between from and to, inclusive, as the batch
+// would leave them, sorted by ID.
+func (b *Batch) Range(from, to time.Time) ([]JournalEntry, error) {
+	entries, err := b.store.Range(from, to)
+	if err != nil {
+		return nil, err
+	}
+	fromKey, toKey := dayKey(from), dayKey(to)
+	return b.overlay(entries, func(id string) bool { return id >= fromKey && id <= toKey })
+}
+
+// Upsert adds the insert or replacement of entry to the batch.
+func (b *Batch) Upsert(entry JournalEntry) error {
+	return b.Write([]Change{{Entry: entry}})
+}
+
+// Delete adds the removal of the entry with the given ID to the batch, or
+// returns a JournalError wrapping ErrEntryNotFound when there is no such
+// entry.
+func (b *Batch) Delete(id string) error {
+	return b.Write([]Change{{Entry: JournalEntry{ID: id}, Delete: true}})
+}
+
+// Write adds changes to the batch. A delete of an entry that is not there
+// fails and leaves the batch as it was.
+func (b *Batch) Write(changes []Change) error {
+	for _, change := range changes {
+		if !change.Delete {
+			continue
+		}
+		if _, err := b.Get(change.Entry.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range changes {
+		if change.Delete {
+			b.pending[change.Entry.ID] = nil
+			b.changes = append(b.changes, change)
+			continue
+		}
+		// Keep a copy of its own, so the caller can go on changing entry
+		entry, err := cloneEntry(change.Entry)
+		if err != nil {
+			return err
+		}
+		b.pending[entry.ID] = entry
+		b.changes = append(b.changes, Change{Entry: *entry})
+	}
+	return nil
+}
+
+// Len returns how many changes the batch holds.
+func (b *Batch) Len() int {
+	return len(b.changes)
+}
+
+// Commit writes the changes of the batch to the store in a single write and
+// empties the batch.
+func (b *Batch) Commit() error {
+	if len(b.changes) == 0 {
+		return nil
+	}
+	if err := b.store.Write(b.changes); err != nil {
+		return err
+	}
+	b.changes = nil
+	b.pending = make(map[string]*JournalEntry)
+	return nil
+}
+
+// Close discards the changes that were not committed. The wrapped store is
+// left open.
+func (b *Batch) Close() error {
+	b.changes = nil
+	b.pending = make(map[string]*JournalEntry)
+	return nil
+}
+
+// overlay applies the pending versions of the entries whose IDs match to
+// entries.
+func (b *Batch) overlay(entries []JournalEntry, match func(id string) bool) ([]JournalEntry, error) {
+	seen := make(map[string]bool)
+	result := entries[:0]
+	for _, entry := range entries {
+		seen[entry.ID] = true
+		pending, ok := b.pending[entry.ID]
+		switch {
+		case !ok:
+			result = append(result, entry)
+		case pending != nil:
+			clone, err := cloneEntry(*pending)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, *clone)
+		}
+	}
+	for id, pending := range b.pending {
+		if pending == nil || seen[id] || !match(id) {
+			continue
+		}
+		clone, err := cloneEntry(*pending)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *clone)
+	}
+	sortEntriesByID(result)
+	return result, nil
+}
+
+// cloneEntry returns a copy of entry that shares nothing with it, as read
+// back from a store.
+func cloneEntry(entry JournalEntry) (*JournalEntry, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, JournalIOError("marshal", err)
+	}
+	var clone JournalEntry
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, JournalIOError("decode entry from", err)
+	}
+	return &clone, nil
+}
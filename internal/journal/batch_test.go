@@ -0,0 +1,85 @@
+package journal
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	store := NewJSONStore(path, BackupPolicy{})
+	for _, day := range []int{1, 2} {
+		if err := store.Upsert(storeTestEntry(day, "first")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	batch := NewBatch(store)
+	if err := batch.Upsert(storeTestEntry(2, "second")); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Upsert(storeTestEntry(3, "first")); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Delete("20260401"); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Delete("20260401"); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("Expected ErrEntryNotFound deleting twice, got %v", err)
+	}
+
+	want := []JournalEntry{storeTestEntry(2, "second"), storeTestEntry(3, "first")}
+	t.Run("reads see the changes", func(t *testing.T) {
+		all, err := batch.All()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, all); diff != "" {
+			t.Errorf("All() mismatch (-want +got):\n%s", diff)
+		}
+		inRange, err := batch.Range(time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want[1:], inRange); diff != "" {
+			t.Errorf("Range() mismatch (-want +got):\n%s", diff)
+		}
+		if _, err := batch.Get("20260401"); !errors.Is(err, ErrEntryNotFound) {
+			t.Errorf("Expected ErrEntryNotFound for the deleted entry, got %v", err)
+		}
+	})
+
+	t.Run("entries read are copies", func(t *testing.T) {
+		entry, _ := batch.Get("20260402")
+		entry.Notes[0].Contents = "changed"
+		again, _ := batch.Get("20260402")
+		if again.Notes[0].Contents != "second" {
+			t.Errorf("Expected the batch to keep its own copy, got %q", again.Notes[0].Contents)
+		}
+	})
+
+	t.Run("nothing is written before Commit", func(t *testing.T) {
+		all, _ := store.All()
+		if diff := cmp.Diff([]JournalEntry{storeTestEntry(1, "first"), storeTestEntry(2, "first")}, all); diff != "" {
+			t.Errorf("store mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	if batch.Len() != 3 {
+		t.Errorf("Expected 3 changes, got %d", batch.Len())
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	all, _ := store.All()
+	if diff := cmp.Diff(want, all); diff != "" {
+		t.Errorf("store after Commit() mismatch (-want +got):\n%s", diff)
+	}
+	if batch.Len() != 0 {
+		t.Errorf("Expected an empty batch after Commit(), got %d changes", batch.Len())
+	}
+}
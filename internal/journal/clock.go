@@ -0,0 +1,79 @@
+package journal
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClockTime is a time of day as typed on the command line: "HH:MM", with an
+// optional "+N" suffix for a time that falls N days after the day it belongs
+// to, e.g. "06:00+1" for the end of a shift that started the evening before.
+type ClockTime struct {
+	Hour      int
+	Minute    int
+	DayOffset int
+}
+
+// ParseClockTime parses "HH:MM" or "HH:MM+N", where N is a positive number of
+// days.
+func ParseClockTime(s string) (ClockTime, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ClockTime{}, ValidationError("time", "time string cannot be empty")
+	}
+
+	hhmm, offset := s, 0
+	if i := strings.Index(s, "+"); i != -1 {
+		hhmm = s[:i]
+		days, err := strconv.Atoi(s[i+1:])
+		if err != nil || days < 1 {
+			return ClockTime{}, TimeFormatError(s, fmt.Errorf("day suffix must be +N with N >= 1"))
+		}
+		offset = days
+	}
+
+	parsed, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return ClockTime{}, TimeFormatError(s, err)
+	}
+	return ClockTime{Hour: parsed.Hour(), Minute: parsed.Minute(), DayOffset: offset}, nil
+}
+
+// On returns the clock time on the calendar day of day, moved forward by
+// DayOffset days, in the location of day.
+func (c ClockTime) On(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+c.DayOffset, c.Hour, c.Minute, 0, 0, day.Location())
+}
+
+// String formats the clock time the way ParseClockTime reads it.
+func (c ClockTime) String() string {
+	s := fmt.Sprintf("%02d:%02d", c.Hour, c.Minute)
+	if c.DayOffset > 0 {
+		s += fmt.Sprintf("+%d", c.DayOffset)
+	}
+	return s
+}
+
+// ClockTimeOf returns t as a ClockTime relative to the calendar day of day,
+// so a time after midnight of a shift that started on day gets a "+1". The
+// hour and minute are read in the location of t, as t.Format would.
+func ClockTimeOf(t, day time.Time) ClockTime {
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	tDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, day.Location())
+
+	// Days are 23 or 25 hours long across DST changes, so round
+	offset := int(math.Round(tDay.Sub(midnight).Hours() / 24))
+	if offset < 0 {
+		offset = 0
+	}
+	return ClockTime{Hour: t.Hour(), Minute: t.Minute(), DayOffset: offset}
+}
+
+// FormatClockTime formats t as "HH:MM", with a "+N" suffix when it falls N
+// days after the calendar day of day.
+func FormatClockTime(t, day time.Time) string {
+	return ClockTimeOf(t, day).String()
+}
@@ -0,0 +1,84 @@
+package journal
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseClockTime(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ClockTime
+		wantErr bool
+	}{
+		{input: "09:30", want: ClockTime{Hour: 9, Minute: 30}},
+		{input: " 22:00 ", want: ClockTime{Hour: 22}},
+		{input: "06:00+1", want: ClockTime{Hour: 6, DayOffset: 1}},
+		{input: "01:15+2", want: ClockTime{Hour: 1, Minute: 15, DayOffset: 2}},
+		{input: "", wantErr: true},
+		{input: "25:00", wantErr: true},
+		{input: "06:00+", wantErr: true},
+		{input: "06:00+0", wantErr: true},
+		{input: "06:00-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseClockTime(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseClockTime(%q) = %+v, expected an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseClockTime(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseClockTime(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseClockTime("6pm"); !errors.Is(err, ErrInvalidTimeFormat) {
+		t.Errorf("expected ErrInvalidTimeFormat, got %v", err)
+	}
+}
+
+func TestClockTimeOn(t *testing.T) {
+	day := time.Date(2026, 3, 31, 14, 45, 0, 0, time.UTC)
+
+	got := ClockTime{Hour: 6, Minute: 30, DayOffset: 1}.On(day)
+	want := time.Date(2026, 4, 1, 6, 30, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("On() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatClockTime(t *testing.T) {
+	day := time.Date(2026, 3, 31, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{name: "same day", t: day, want: "22:00"},
+		{name: "next day", t: time.Date(2026, 4, 1, 6, 0, 0, 0, time.UTC), want: "06:00+1"},
+		{name: "two days later", t: time.Date(2026, 4, 2, 1, 5, 0, 0, time.UTC), want: "01:05+2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatClockTime(tt.t, day)
+			if got != tt.want {
+				t.Errorf("FormatClockTime() = %q, want %q", got, tt.want)
+			}
+			parsed, err := ParseClockTime(got)
+			if err != nil || !parsed.On(day).Equal(tt.t) {
+				t.Errorf("%q does not parse back to %v", got, tt.t)
+			}
+		})
+	}
+}
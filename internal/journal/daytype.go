@@ -0,0 +1,80 @@
+package journal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DayType is the kind of day an entry records. An entry without a day type is
+// a work day.
+type DayType string
+
+const (
+	DayWork    DayType = "work"
+	DayHoliday DayType = "holiday" // public holiday
+	DayPTO     DayType = "pto"     // paid time off, i.e. vacation
+	DaySick    DayType = "sick"    // sick leave
+)
+
+// DayTypes lists the known day types.
+var DayTypes = []DayType{DayWork, DayHoliday, DayPTO, DaySick}
+
+// ParseDayType parses one of the DayTypes, ignoring case.
+func ParseDayType(s string) (DayType, error) {
+	for _, dayType := range DayTypes {
+		if strings.EqualFold(s, string(dayType)) {
+			return dayType, nil
+		}
+	}
+	names := make([]string, len(DayTypes))
+	for i, dayType := range DayTypes {
+		names[i] = string(dayType)
+	}
+	return "", ValidationError("day_type", fmt.Sprintf("unknown day type %q, expected one of %s", s, strings.Join(names, ", ")))
+}
+
+// IsOff reports whether the day type is a day without expected work.
+func (d DayType) IsOff() bool {
+	return d != "" && d != DayWork
+}
+
+// Label returns a short description of the day type for display.
+func (d DayType) Label() string {
+	switch d {
+	case DayHoliday:
+		return "Holiday"
+	case DayPTO:
+		return "PTO"
+	case DaySick:
+		return "Sick"
+	default:
+		return "Work"
+	}
+}
+
+// NewDayOffEntry builds the entry of a day off on the calendar day of date,
+// see NewEmptyEntry.
+func NewDayOffEntry(date time.Time, dayType DayType, reason string) (*JournalEntry, error) {
+	if !dayType.IsOff() {
+		return nil, ValidationError("day_type", fmt.Sprintf("%q is not a day off", dayType))
+	}
+	entry := NewEmptyEntry(date)
+	entry.DayType, entry.DayReason = dayType, reason
+	return entry, nil
+}
+
+// IsDayOff reports whether the entry records a day off.
+func (j *JournalEntry) IsDayOff() bool {
+	return j.DayType.IsOff()
+}
+
+// SetDayType marks the entry as a day of the given type. Setting DayWork
+// clears the day type and reason.
+func (j *JournalEntry) SetDayType(dayType DayType, reason string) {
+	if !dayType.IsOff() {
+		j.DayType, j.DayReason = "", ""
+		return
+	}
+	j.DayType, j.DayReason = dayType, reason
+}
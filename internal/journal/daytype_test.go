@@ -0,0 +1,76 @@
+package journal
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDayType(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    DayType
+		wantErr bool
+	}{
+		{input: "holiday", want: DayHoliday},
+		{input: "PTO", want: DayPTO},
+		{input: "sick", want: DaySick},
+		{input: "work", want: DayWork},
+		{input: "sabbatical", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDayType(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("expected ErrValidation, got %v", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseDayType(%q) = %q, %v, want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDayOffEntry(t *testing.T) {
+	date := time.Date(2026, 12, 24, 15, 30, 0, 0, time.UTC)
+
+	if _, err := NewDayOffEntry(date, DayWork, ""); err == nil {
+		t.Error("expected an error for a work day")
+	}
+
+	entry, err := NewDayOffEntry(date, DayHoliday, "Christmas Eve")
+	if err != nil {
+		t.Fatalf("NewDayOffEntry() error = %v", err)
+	}
+	if entry.ID != "20261224" || !entry.IsDayOff() || entry.DayReason != "Christmas Eve" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if entry.TotalWorkTime() != 0 || len(entry.WorkSessions()) != 0 || entry.OpenSession() != nil {
+		t.Errorf("expected a day off without work, got %+v", entry)
+	}
+	if result := ValidateEntry(entry); !result.IsValid {
+		t.Errorf("ValidateEntry() error = %v", result.Error)
+	}
+
+	// Work on a day off is recorded as sessions
+	start := time.Date(2026, 12, 24, 10, 0, 0, 0, time.UTC)
+	if err := entry.StartSession(start); err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	entry.SetEndTime(start.Add(2 * time.Hour))
+	if got := entry.TotalWorkTime(); got != 2*time.Hour {
+		t.Errorf("TotalWorkTime() = %v, want 2h", got)
+	}
+	if !entry.StartTime.Equal(start) || !entry.IsDayOff() {
+		t.Errorf("expected the day off to start with its first session, got %+v", entry)
+	}
+
+	entry.SetDayType(DayWork, "")
+	if entry.IsDayOff() || entry.DayReason != "" {
+		t.Errorf("expected SetDayType(DayWork) to clear the day type, got %+v", entry)
+	}
+}
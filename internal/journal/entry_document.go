@@ -0,0 +1,428 @@
+package journal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Sections of an entry document, in the order they are written.
+const (
+	documentSessions = "sessions"
+	documentBreaks   = "breaks"
+	documentNotes    = "notes"
+	documentSegments = "segments"
+)
+
+// documentCommentPrefix starts the comment lines of an entry document. Notes
+// may start with a tag or a markdown heading, so comments have a marker of
+// their own.
+const documentCommentPrefix = "#:"
+
+// documentErrorPrefix starts the comments AnnotateEntryDocument adds for the
+// errors of a line.
+const documentErrorPrefix = documentCommentPrefix + " ERROR: "
+
+// documentCurrentPrefix starts the comments ConflictEntryDocument adds with
+// the current version of an entry.
+const documentCurrentPrefix = documentCommentPrefix + " CURRENT: "
+
+// DocumentError is a problem found on a line of an entry document.
+type DocumentError struct {
+	Line int // 1-based, 0 for a problem with the document as a whole
+	Err  error
+}
+
+func (e DocumentError) Error() string {
+	if e.Line == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// FormatEntryDocument renders the sessions, breaks, notes and time segments
+// of entry as a text document to be edited by hand, and read back with
+// ParseEntryDocument:
+//
+//	[sessions]
+//	08:00-12:00
+//	13:00-
+//
+//	[breaks]
+//	10:00-10:15 coffee
+//
+//	[notes]
+//	Reviewed the parser #review
+//
+//	[segments]
+//	09:00-10:00 acme/parser/review Walked through the lexer
+//
+// Times are written as ClockTime relative to the day of the entry, and a
+// session, break or segment without an end is still going on.
+func FormatEntryDocument(entry *JournalEntry) string {
+	day := entry.StartTime
+	span := func(start, end time.Time) string {
+		s := FormatClockTime(start, day) + "-"
+		if !end.IsZero() {
+			s += FormatClockTime(end, day)
+		}
+		return s
+	}
+
+	var doc strings.Builder
+	fmt.Fprintf(&doc, "#: Workday entry for %s", day.Format("Monday, January 2, 2006"))
+	if entry.IsDayOff() {
+		fmt.Fprintf(&doc, " (%s)", entry.DayType)
+	}
+	doc.WriteString(`
+#:
+#: Times are HH:MM, or HH:MM+1 for the next day. A session, break or segment
+#: without an end, such as "13:00-", is still going on.
+#:   [breaks]    START-END reason
+#:   [notes]     one note per line, with its #tags
+#:   [segments]  START-END client/project/task description
+#:
+#: Lines starting with "#:" are ignored. Save the file unchanged, or without
+#: any sections, to cancel.
+`)
+
+	doc.WriteString("\n[" + documentSessions + "]\n")
+	for _, session := range entry.WorkSessions() {
+		doc.WriteString(span(session.StartTime, session.EndTime) + "\n")
+	}
+
+	doc.WriteString("\n[" + documentBreaks + "]\n")
+	for _, br := range entry.Breaks {
+		doc.WriteString(span(br.StartTime, br.EndTime) + " " + br.Reason + "\n")
+	}
+
+	doc.WriteString("\n[" + documentNotes + "]\n")
+	for _, note := range entry.Notes {
+		doc.WriteString(documentNoteLine(note) + "\n")
+	}
+
+	doc.WriteString("\n[" + documentSegments + "]\n")
+	for _, segment := range entry.TimeSegments {
+		spec := segment.Project + "/" + segment.Task
+		if segment.Client != "" {
+			spec = segment.Client + "/" + spec
+		}
+		line := span(segment.StartTime, segment.EndTime) + " " + spec
+		if segment.Description != "" {
+			line += " " + segment.Description
+		}
+		doc.WriteString(line + "\n")
+	}
+	return doc.String()
+}
+
+// documentNoteLine renders note on a single line, with its tags inline.
+func documentNoteLine(note Note) string {
+	contents := strings.Join(strings.Fields(note.Contents), " ")
+	return FormatNoteWithTags(contents, note.Tags)
+}
+
+// isDocumentComment reports whether line is a comment of an entry document,
+// i.e. documentCommentPrefix on its own or followed by a space.
+func isDocumentComment(line string) bool {
+	return line == documentCommentPrefix || strings.HasPrefix(line, documentCommentPrefix+" ")
+}
+
+// documentLine is a line of a section of an entry document.
+type documentLine struct {
+	number int
+	text   string
+}
+
+// ParseEntryDocument reads a document written by FormatEntryDocument and
+// edited by hand back into a copy of entry, whose sessions, breaks, notes
+// and time segments it replaces. The day is validated as a backfilled one
+// (see NewBackfilledEntry); an open session or break, on a day still going
+// on, is checked as if it ended at now.
+//
+// Notes are matched with those of entry by their text, so unchanged notes
+// keep their times and revisions wherever they are moved to. A changed note
+// is taken as a rewrite of the first note of entry that is no longer in the
+// document, see Note.Rewrite, and any other line is a new note written at
+// now.
+//
+// Every problem found is returned, with its line where it has one; the
+// returned entry is only valid when there are none.
+func ParseEntryDocument(doc string, entry *JournalEntry, now time.Time) (*JournalEntry, []DocumentError) {
+	var errs []DocumentError
+	fail := func(line int, err error) {
+		errs = append(errs, DocumentError{Line: line, Err: err})
+	}
+
+	sections := make(map[string][]documentLine)
+	headers := make(map[string]int)
+	section := ""
+	for i, raw := range strings.Split(doc, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || isDocumentComment(line) {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			switch {
+			case name == documentSessions, name == documentBreaks, name == documentNotes, name == documentSegments:
+				section = name
+				headers[section] = i + 1
+				continue
+			case section != documentNotes:
+				// A note may be written in brackets, such as "[x] Deployed"
+				fail(i+1, ValidationError("section", fmt.Sprintf("unknown section [%s], use [sessions], [breaks], [notes] or [segments]", name)))
+				section = ""
+				continue
+			}
+		}
+		if section == "" {
+			fail(i+1, ValidationError("section", "line is outside of a section"))
+			continue
+		}
+		sections[section] = append(sections[section], documentLine{number: i + 1, text: line})
+	}
+
+	day, err := time.ParseInLocation("20060102", entry.ID, entry.Location())
+	if err != nil {
+		day = entry.StartTime
+	}
+	edited := *entry
+
+	// Sessions, each validated on its own here and in order below
+	edited.Sessions = nil
+	for _, line := range sections[documentSessions] {
+		start, end, rest, err := parseDocumentSpan(line.text, day)
+		if err == nil && rest != "" {
+			err = ValidationError("session", fmt.Sprintf("unexpected text '%s' after the session", rest))
+		}
+		if err == nil {
+			session := Session{StartTime: start, EndTime: end}
+			if result := ValidateSession(session); !result.IsValid {
+				err = result.Error
+			}
+			edited.Sessions = append(edited.Sessions, session)
+		}
+		if err != nil {
+			fail(line.number, err)
+		}
+	}
+
+	// Breaks
+	edited.Breaks = nil
+	breakLines := make(map[int]int) // break index to line
+	for _, line := range sections[documentBreaks] {
+		start, end, reason, err := parseDocumentSpan(line.text, day)
+		if err == nil {
+			br := Break{StartTime: start, EndTime: end, Reason: reason}
+			if result := ValidateBreak(br); !result.IsValid {
+				err = result.Error
+			} else if result := ValidateBreakOverlap(br, edited.Breaks); !result.IsValid {
+				err = result.Error
+			}
+			breakLines[len(edited.Breaks)] = line.number
+			edited.Breaks = append(edited.Breaks, br)
+		}
+		if err != nil {
+			fail(line.number, err)
+		}
+	}
+
+	// Time segments, numbered in their new order
+	edited.TimeSegments = nil
+	for _, line := range sections[documentSegments] {
+		start, end, rest, err := parseDocumentSpan(line.text, day)
+		if err == nil {
+			spec, description, _ := strings.Cut(rest, " ")
+			segment := TimeSegment{StartTime: start, EndTime: end, Description: strings.TrimSpace(description)}
+			segment.Client, segment.Project, segment.Task, err = parseDocumentSegmentSpec(spec)
+			if err == nil {
+				err = edited.AddTimeSegment(segment)
+			}
+		}
+		if err != nil {
+			fail(line.number, err)
+		}
+	}
+
+	edited.Notes = matchDocumentNotes(sections[documentNotes], entry.Notes, now)
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	// The day as a whole, like a backfilled one
+	sessionsLine := headers[documentSessions]
+	if len(edited.Sessions) == 0 {
+		if !entry.IsDayOff() || len(edited.Breaks) > 0 {
+			fail(sessionsLine, ValidationError("sessions", "a work day needs at least one session"))
+			return nil, errs
+		}
+		// A day off without work keeps its bounds
+		return &edited, nil
+	}
+
+	first, last := edited.Sessions[0], edited.Sessions[len(edited.Sessions)-1]
+	end := last.EndTime
+	if end.IsZero() {
+		end = now
+	}
+	var closed []Break
+	for _, br := range edited.Breaks {
+		if !br.EndTime.IsZero() {
+			closed = append(closed, br)
+		}
+	}
+	if _, err := NewBackfilledEntry(day, first.StartTime, end, closed, nil); err != nil {
+		// Point at the break the error is about when there is one
+		line := sessionsLine
+		for i, br := range edited.Breaks {
+			if br.StartTime.Before(first.StartTime) || (!br.EndTime.IsZero() && br.EndTime.After(end)) {
+				line = breakLines[i]
+				break
+			}
+		}
+		fail(line, err)
+		return nil, errs
+	}
+
+	edited.StartTime = first.StartTime
+	edited.EndTime = last.EndTime
+	if result := ValidateEntry(&edited); !result.IsValid {
+		fail(sessionsLine, result.Error)
+		return nil, errs
+	}
+	return &edited, nil
+}
+
+// parseDocumentSpan parses a line starting with "START-END" or "START-" and
+// returns the times, anchored to day, and the rest of the line.
+func parseDocumentSpan(line string, day time.Time) (time.Time, time.Time, string, error) {
+	span, rest, _ := strings.Cut(line, " ")
+	startStr, endStr, found := strings.Cut(span, "-")
+	if !found {
+		return time.Time{}, time.Time{}, "", ValidationError("time_range", fmt.Sprintf("'%s' is not a time range, use START-END such as 09:00-12:30", span))
+	}
+
+	start, err := ParseClockTime(startStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, "", err
+	}
+	var end time.Time
+	if endStr != "" {
+		endClock, err := ParseClockTime(endStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, "", err
+		}
+		end = endClock.On(day)
+	}
+	return start.On(day), end, strings.TrimSpace(rest), nil
+}
+
+// parseDocumentSegmentSpec splits "client/project/task" or "project/task".
+func parseDocumentSegmentSpec(spec string) (string, string, string, error) {
+	parts := strings.Split(spec, "/")
+	switch {
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return "", parts[0], parts[1], nil
+	case len(parts) == 3 && parts[0] != "" && parts[1] != "" && parts[2] != "":
+		return parts[0], parts[1], parts[2], nil
+	}
+	return "", "", "", ValidationError("segment", fmt.Sprintf("invalid task '%s', use client/project/task or project/task", spec))
+}
+
+// matchDocumentNotes turns the lines of the notes section into notes, see
+// ParseEntryDocument.
+func matchDocumentNotes(lines []documentLine, notes []Note, now time.Time) []Note {
+	used := make([]bool, len(notes))
+	matched := make([]int, len(lines))
+	for i, line := range lines {
+		matched[i] = -1
+		for k, note := range notes {
+			if !used[k] && documentNoteLine(note) == line.text {
+				matched[i], used[k] = k, true
+				break
+			}
+		}
+	}
+
+	var result []Note
+	next := 0
+	for i, line := range lines {
+		if k := matched[i]; k != -1 {
+			result = append(result, notes[k])
+			continue
+		}
+		for next < len(notes) && used[next] {
+			next++
+		}
+		if next < len(notes) {
+			note := notes[next]
+			used[next] = true
+			note.Rewrite(line.text, now)
+			result = append(result, note)
+			continue
+		}
+		result = append(result, NewNote(line.text, now))
+	}
+	return result
+}
+
+// AnnotateEntryDocument adds a comment with each of errs after the line it is
+// about, or at the top for errors of the whole document, so the document can
+// be fixed in an editor. The comments of an earlier annotation are removed.
+func AnnotateEntryDocument(doc string, errs []DocumentError) string {
+	byLine := make(map[int][]string)
+	for _, e := range errs {
+		byLine[e.Line] = append(byLine[e.Line], documentErrorPrefix+strings.ReplaceAll(e.Err.Error(), "\n", " "))
+	}
+
+	var annotated []string
+	annotated = append(annotated, byLine[0]...)
+	for i, line := range strings.Split(doc, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), documentErrorPrefix) {
+			continue
+		}
+		annotated = append(annotated, line)
+		annotated = append(annotated, byLine[i+1]...)
+	}
+	return strings.Join(annotated, "\n")
+}
+
+// ConflictEntryDocument marks doc, an edited document of an entry that was
+// changed by someone else in the meantime, as in conflict: a comment at the
+// top explains what happened and current, the entry as it is now, is added at
+// the end as comments, so both versions can be merged by hand. The comments
+// of an earlier conflict are replaced.
+func ConflictEntryDocument(doc string, current *JournalEntry) string {
+	var lines []string
+	for _, line := range strings.Split(doc, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), documentCurrentPrefix) {
+			lines = append(lines, line)
+		}
+	}
+	doc = strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	doc = AnnotateEntryDocument(doc, []DocumentError{{Err: fmt.Errorf(
+		"the entry was changed while the editor was open, its current version is at the end of the document. " +
+			"Merge what you want to keep into your edits and save again, or save unchanged to cancel")}})
+
+	var conflict strings.Builder
+	conflict.WriteString(doc + "\n\n")
+	for _, line := range strings.Split(FormatEntryDocument(current), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !isDocumentComment(line) {
+			conflict.WriteString(documentCurrentPrefix + line + "\n")
+		}
+	}
+	return conflict.String()
+}
+
+// IsEmptyEntryDocument reports whether doc has no sections left, only
+// comments and blank lines.
+func IsEmptyEntryDocument(doc string) bool {
+	for _, line := range strings.Split(doc, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !isDocumentComment(line) {
+			return false
+		}
+	}
+	return true
+}
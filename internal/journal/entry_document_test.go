@@ -0,0 +1,295 @@
+package journal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// documentEntry returns a finished day with every part an entry document
+// holds.
+func documentEntry() *JournalEntry {
+	at := func(h, m int) time.Time { return time.Date(2026, 4, 7, h, m, 0, 0, time.UTC) }
+	return &JournalEntry{
+		ID:        "20260407",
+		StartTime: at(8, 0),
+		EndTime:   at(17, 0),
+		Sessions: []Session{
+			{StartTime: at(8, 0), EndTime: at(12, 0)},
+			{StartTime: at(13, 0), EndTime: at(17, 0)},
+		},
+		Breaks: []Break{{StartTime: at(10, 0), EndTime: at(10, 15), Reason: "coffee"}},
+		Notes: []Note{
+			{Contents: "Reviewed the parser", Tags: []string{"review"}, CreatedAt: at(9, 0)},
+			{Contents: "Planned the sprint", CreatedAt: at(14, 0)},
+		},
+		TimeSegments: []TimeSegment{
+			{ID: "1", StartTime: at(9, 0), EndTime: at(10, 0), Client: "acme", Project: "parser", Task: "review", Description: "Walked through the lexer"},
+		},
+	}
+}
+
+// replaceLine replaces the first line of doc equal to old.
+func replaceLine(doc, old, new string) string {
+	return strings.Replace(doc, "\n"+old+"\n", "\n"+new+"\n", 1)
+}
+
+func TestEntryDocumentRoundTrip(t *testing.T) {
+	entry := documentEntry()
+	now := entry.EndTime.Add(time.Hour)
+
+	doc := FormatEntryDocument(entry)
+	for _, line := range []string{"08:00-12:00", "10:00-10:15 coffee", "Reviewed the parser #review", "09:00-10:00 acme/parser/review Walked through the lexer"} {
+		if !strings.Contains(doc, "\n"+line+"\n") {
+			t.Errorf("document is missing line %q:\n%s", line, doc)
+		}
+	}
+
+	got, errs := ParseEntryDocument(doc, entry, now)
+	if len(errs) > 0 {
+		t.Fatalf("ParseEntryDocument() errors = %v", errs)
+	}
+	if diff := cmp.Diff(entry, got); diff != "" {
+		t.Errorf("ParseEntryDocument() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseEntryDocumentEdits(t *testing.T) {
+	entry := documentEntry()
+	day := entry.StartTime
+	now := entry.EndTime.Add(time.Hour)
+
+	doc := FormatEntryDocument(entry)
+	doc = replaceLine(doc, "13:00-17:00", "13:00-18:30")
+	doc = replaceLine(doc, "10:00-10:15 coffee", "10:00-10:15 coffee\n15:00-15:10 walk")
+	// A moved note keeps its time, a changed one is a rewrite of the note that
+	// is no longer there
+	doc = replaceLine(doc, "Reviewed the parser #review", "Planned the sprint #planning")
+	doc = replaceLine(doc, "Planned the sprint", "Reviewed the parser #review\nCalled the client")
+	doc = replaceLine(doc, "09:00-10:00 acme/parser/review Walked through the lexer", "14:00-15:00 parser/fix")
+
+	got, errs := ParseEntryDocument(doc, entry, now)
+	if len(errs) > 0 {
+		t.Fatalf("ParseEntryDocument() errors = %v", errs)
+	}
+
+	if want := day.Add(10*time.Hour + 30*time.Minute); !got.EndTime.Equal(want) {
+		t.Errorf("EndTime = %v, want %v", got.EndTime, want)
+	}
+	if len(got.Breaks) != 2 || got.Breaks[1].Reason != "walk" {
+		t.Errorf("Breaks = %+v, want coffee and walk", got.Breaks)
+	}
+
+	wantNotes := []Note{
+		{
+			Contents:  "Planned the sprint",
+			Tags:      []string{"planning"},
+			CreatedAt: day.Add(6 * time.Hour),
+			UpdatedAt: now,
+			Revisions: []NoteRevision{{Contents: "Planned the sprint", Time: day.Add(6 * time.Hour)}},
+		},
+		{Contents: "Reviewed the parser", Tags: []string{"review"}, CreatedAt: day.Add(time.Hour)},
+		{Contents: "Called the client", CreatedAt: now},
+	}
+	if diff := cmp.Diff(wantNotes, got.Notes); diff != "" {
+		t.Errorf("Notes mismatch (-want +got):\n%s", diff)
+	}
+
+	wantSegments := []TimeSegment{{ID: "1", StartTime: day.Add(6 * time.Hour), EndTime: day.Add(7 * time.Hour), Project: "parser", Task: "fix"}}
+	if diff := cmp.Diff(wantSegments, got.TimeSegments); diff != "" {
+		t.Errorf("TimeSegments mismatch (-want +got):\n%s", diff)
+	}
+
+	// The entry it was parsed from is left as it was
+	if diff := cmp.Diff(documentEntry(), entry); diff != "" {
+		t.Errorf("ParseEntryDocument() changed the entry (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseEntryDocumentErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		old, new  string
+		wantLines []string // the lines the errors are about
+	}{
+		{name: "bad time", old: "08:00-12:00", new: "08:00-25:00", wantLines: []string{"08:00-25:00"}},
+		{name: "break without a reason", old: "10:00-10:15 coffee", new: "10:00-10:15", wantLines: []string{"10:00-10:15"}},
+		{name: "overlapping breaks", old: "10:00-10:15 coffee", new: "10:00-10:15 coffee\n10:10-10:20 tea", wantLines: []string{"10:10-10:20 tea"}},
+		{name: "break outside the day", old: "10:00-10:15 coffee", new: "07:00-07:30 coffee", wantLines: []string{"07:00-07:30 coffee"}},
+		{name: "overlapping sessions", old: "13:00-17:00", new: "11:00-17:00", wantLines: []string{"[sessions]"}},
+		{name: "bad segment task", old: "09:00-10:00 acme/parser/review Walked through the lexer", new: "09:00-10:00 parser", wantLines: []string{"09:00-10:00 parser"}},
+		{name: "unknown section", old: "[notes]", new: "[todo]", wantLines: []string{"[todo]", "Reviewed the parser #review", "Planned the sprint"}},
+		{name: "two problems", old: "08:00-12:00", new: "8-12\n13:00-12:00", wantLines: []string{"8-12", "13:00-12:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := documentEntry()
+			doc := replaceLine(FormatEntryDocument(entry), tt.old, tt.new)
+
+			got, errs := ParseEntryDocument(doc, entry, entry.EndTime)
+			if got != nil {
+				t.Errorf("ParseEntryDocument() = %+v, want no entry", got)
+			}
+			lines := strings.Split(doc, "\n")
+			var gotLines []string
+			for _, e := range errs {
+				gotLines = append(gotLines, lines[e.Line-1])
+			}
+			if diff := cmp.Diff(tt.wantLines, gotLines); diff != "" {
+				t.Errorf("error lines mismatch (-want +got):\n%s\nerrors: %v", diff, errs)
+			}
+		})
+	}
+}
+
+func TestParseEntryDocumentBracketNotes(t *testing.T) {
+	entry := documentEntry()
+	doc := replaceLine(FormatEntryDocument(entry), "Planned the sprint", "[x]\n[x] Planned the sprint")
+
+	got, errs := ParseEntryDocument(doc, entry, entry.EndTime)
+	if len(errs) > 0 {
+		t.Fatalf("ParseEntryDocument() errors = %v", errs)
+	}
+	var notes []string
+	for _, note := range got.Notes {
+		notes = append(notes, note.Contents)
+	}
+	if diff := cmp.Diff([]string{"Reviewed the parser", "[x]", "[x] Planned the sprint"}, notes); diff != "" {
+		t.Errorf("Notes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseEntryDocumentHeadingNotes(t *testing.T) {
+	entry := documentEntry()
+	doc := replaceLine(FormatEntryDocument(entry), "Planned the sprint", "# Release notes\n#: not a note\nPlanned the sprint")
+
+	got, errs := ParseEntryDocument(doc, entry, entry.EndTime)
+	if len(errs) > 0 {
+		t.Fatalf("ParseEntryDocument() errors = %v", errs)
+	}
+	var notes []string
+	for _, note := range got.Notes {
+		notes = append(notes, note.Contents)
+	}
+	if diff := cmp.Diff([]string{"Reviewed the parser", "# Release notes", "Planned the sprint"}, notes); diff != "" {
+		t.Errorf("Notes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseEntryDocumentOpenDay(t *testing.T) {
+	entry := documentEntry()
+	entry.EndTime = time.Time{}
+	entry.Sessions[1].EndTime = time.Time{}
+	now := entry.StartTime.Add(7 * time.Hour)
+
+	doc := FormatEntryDocument(entry)
+	if !strings.Contains(doc, "\n13:00-\n") {
+		t.Fatalf("document is missing the open session:\n%s", doc)
+	}
+
+	// A break still going on is checked as ending now
+	got, errs := ParseEntryDocument(replaceLine(doc, "10:00-10:15 coffee", "10:00-10:15 coffee\n14:30- walk"), entry, now)
+	if len(errs) > 0 {
+		t.Fatalf("ParseEntryDocument() errors = %v", errs)
+	}
+	if !got.EndTime.IsZero() || !got.Breaks[1].EndTime.IsZero() {
+		t.Errorf("ParseEntryDocument() closed the day or its break: %+v", got)
+	}
+}
+
+func TestParseEntryDocumentDayOff(t *testing.T) {
+	day := time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC)
+	entry := &JournalEntry{ID: "20261224", StartTime: day, EndTime: day, DayType: DayHoliday, DayReason: "Christmas Eve"}
+
+	doc := FormatEntryDocument(entry)
+	got, errs := ParseEntryDocument(replaceLine(doc, "[notes]", "[notes]\nBought presents #family"), entry, day)
+	if len(errs) > 0 {
+		t.Fatalf("ParseEntryDocument() errors = %v", errs)
+	}
+	if !got.StartTime.Equal(day) || !got.EndTime.Equal(day) || len(got.Notes) != 1 {
+		t.Errorf("ParseEntryDocument() = %+v, want the day off with one note", got)
+	}
+
+	// A working day needs a session
+	work := documentEntry()
+	doc = FormatEntryDocument(work)
+	doc = replaceLine(doc, "08:00-12:00", "")
+	doc = replaceLine(doc, "13:00-17:00", "")
+	if _, errs := ParseEntryDocument(doc, work, work.EndTime); len(errs) != 1 {
+		t.Errorf("ParseEntryDocument() errors = %v, want one for the missing sessions", errs)
+	}
+}
+
+func TestAnnotateEntryDocument(t *testing.T) {
+	doc := "[breaks]\n10:00-10:15\n#: ERROR: an old problem\n[notes]"
+	errs := []DocumentError{
+		{Line: 0, Err: ValidationError("sessions", "no sessions")},
+		{Line: 2, Err: ValidationError("break", "reason cannot be empty")},
+	}
+
+	got := AnnotateEntryDocument(doc, errs)
+	want := strings.Join([]string{
+		"#: ERROR: " + errs[0].Err.Error(),
+		"[breaks]",
+		"10:00-10:15",
+		"#: ERROR: " + errs[1].Err.Error(),
+		"[notes]",
+	}, "\n")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("AnnotateEntryDocument() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConflictEntryDocument(t *testing.T) {
+	entry := documentEntry()
+	edited := replaceLine(FormatEntryDocument(entry), "Planned the sprint", "Planned the release")
+	current := documentEntry()
+	current.Notes = append(current.Notes, Note{Contents: "Deployed the fix"})
+
+	got := ConflictEntryDocument(edited, current)
+	if !strings.HasPrefix(got, documentErrorPrefix+"the entry was changed") {
+		t.Errorf("conflict document does not start with the conflict:\n%s", got)
+	}
+	for _, line := range []string{"Planned the release", "#: CURRENT: Planned the sprint", "#: CURRENT: Deployed the fix"} {
+		if !strings.Contains(got, "\n"+line+"\n") {
+			t.Errorf("conflict document is missing line %q:\n%s", line, got)
+		}
+	}
+
+	// A second conflict replaces the comments of the first
+	again := ConflictEntryDocument(got, entry)
+	if strings.Contains(again, "Deployed the fix") || strings.Count(again, documentErrorPrefix) != 1 {
+		t.Errorf("second conflict kept the first one:\n%s", again)
+	}
+
+	// The comments leave the document as it was edited
+	parsed, errs := ParseEntryDocument(got, current, entry.EndTime)
+	if len(errs) > 0 {
+		t.Fatalf("ParseEntryDocument() errors = %v", errs)
+	}
+	if len(parsed.Notes) != 2 || parsed.Notes[1].Contents != "Planned the release" {
+		t.Errorf("Notes = %+v, want the edited ones", parsed.Notes)
+	}
+}
+
+func TestIsEmptyEntryDocument(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want bool
+	}{
+		{doc: "", want: true},
+		{doc: "#: A comment\n#:\n\n", want: true},
+		{doc: "#: A comment\n[notes]", want: false},
+		{doc: "#tagged note", want: false},
+		{doc: "# A heading", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsEmptyEntryDocument(tt.doc); got != tt.want {
+			t.Errorf("IsEmptyEntryDocument(%q) = %v, want %v", tt.doc, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package journal
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Error types for better error categorization and handling
@@ -30,6 +31,19 @@ var (
 	
 	// ErrValidation is returned when validation fails
 	ErrValidation = errors.New("validation failed")
+
+	// ErrJournalLocked is returned when another process holds the journal lock
+	ErrJournalLocked = errors.New("journal is locked")
+
+	// ErrBackupNotFound is returned when no backup matches a timestamp
+	ErrBackupNotFound = errors.New("backup not found")
+
+	// ErrInvalidBackup is returned when a backup cannot be restored
+	ErrInvalidBackup = errors.New("invalid backup")
+
+	// ErrMigration is returned when a journal cannot be migrated to the
+	// current schema version
+	ErrMigration = errors.New("schema migration failed")
 )
 
 // JournalError represents a structured error with context
@@ -136,4 +150,49 @@ func ValidationError(field string, reason string) error {
 		Message: fmt.Sprintf("validation failed for %s: %s", field, reason),
 		Context: make(map[string]interface{}),
 	}
-}
\ No newline at end of file
+}
+
+// JournalLockedError creates an error for a journal lock that could not be
+// taken within the timeout
+func JournalLockedError(lockPath string, timeout time.Duration) error {
+	err := &JournalError{
+		Type:    ErrJournalLocked,
+		Message: fmt.Sprintf("could not acquire %s within %s, another workday command may be running", lockPath, timeout),
+		Context: make(map[string]interface{}),
+	}
+	return err.WithContext("lock_file", lockPath)
+}
+
+// BackupNotFoundError creates an error for a backup timestamp that does not
+// identify exactly one backup
+func BackupNotFoundError(timestamp string, reason string) error {
+	err := &JournalError{
+		Type:    ErrBackupNotFound,
+		Message: fmt.Sprintf("backup '%s' not found: %s", timestamp, reason),
+		Context: make(map[string]interface{}),
+	}
+	return err.WithContext("timestamp", timestamp)
+}
+
+// InvalidBackupError creates an error for a backup whose contents are not a
+// valid journal
+func InvalidBackupError(timestamp string) error {
+	err := &JournalError{
+		Type:    ErrInvalidBackup,
+		Message: fmt.Sprintf("backup %s does not contain valid JSON", timestamp),
+		Context: make(map[string]interface{}),
+	}
+	return err.WithContext("timestamp", timestamp)
+}
+
+// MigrationError creates an error for a journal that cannot be migrated from
+// the given schema version
+func MigrationError(version int, reason string, err error) error {
+	jerr := &JournalError{
+		Type:    ErrMigration,
+		Message: fmt.Sprintf("cannot migrate journal from schema version %d: %s", version, reason),
+		Context: make(map[string]interface{}),
+		Err:     err,
+	}
+	return jerr.WithContext("version", version)
+}
@@ -2,7 +2,9 @@ package journal
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestJournalError(t *testing.T) {
@@ -286,6 +288,28 @@ func TestValidationError(t *testing.T) {
 	}
 }
 
+func TestJournalLockedError(t *testing.T) {
+	lockPath := "/tmp/journal.json.lock"
+	err := JournalLockedError(lockPath, 5*time.Second)
+
+	var journalErr *JournalError
+	if !errors.As(err, &journalErr) {
+		t.Fatal("Expected error to be of type JournalError")
+	}
+
+	if !errors.Is(err, ErrJournalLocked) {
+		t.Error("Expected error to be ErrJournalLocked")
+	}
+
+	if !strings.Contains(journalErr.Message, lockPath) || !strings.Contains(journalErr.Message, "5s") {
+		t.Errorf("Expected message to mention lock path and timeout, got %q", journalErr.Message)
+	}
+
+	if journalErr.Context["lock_file"] != lockPath {
+		t.Errorf("Expected lock_file context %q, got %v", lockPath, journalErr.Context["lock_file"])
+	}
+}
+
 func TestErrorConstants(t *testing.T) {
 	// Test that all error constants are defined
 	constants := []error{
@@ -297,6 +321,10 @@ func TestErrorConstants(t *testing.T) {
 		ErrInvalidBreak,
 		ErrJournalIO,
 		ErrValidation,
+		ErrJournalLocked,
+		ErrBackupNotFound,
+		ErrInvalidBackup,
+		ErrMigration,
 	}
 
 	for i, constant := range constants {
@@ -0,0 +1,64 @@
+package journal
+
+import "time"
+
+// Finding is a problem found when an entry was validated, such as too little
+// work time or a broken labor rule. Findings stay on the entry until they are
+// acknowledged, or until the entry is changed so that the problem is gone.
+type Finding struct {
+	Code         string    `json:"code"` // what was checked, e.g. "lunch_break" or a rule name
+	Severity     Severity  `json:"severity"`
+	Message      string    `json:"message"`
+	CreatedAt    time.Time `json:"created_at"` // when the problem was first found
+	Acknowledged bool      `json:"acknowledged,omitempty"`
+}
+
+func (f Finding) String() string {
+	return (Violation{Rule: f.Code, Severity: f.Severity, Message: f.Message}).String()
+}
+
+// UpdateFindings replaces the findings of the entry with the violations found
+// when it was last validated, at now. A finding whose code is found again
+// keeps when it was first found and whether it was acknowledged.
+func (j *JournalEntry) UpdateFindings(violations []Violation, now time.Time) {
+	previous := make(map[string]Finding, len(j.Findings))
+	for _, finding := range j.Findings {
+		previous[finding.Code] = finding
+	}
+
+	var findings []Finding
+	for _, v := range violations {
+		finding := Finding{Code: v.Rule, Severity: v.Severity, Message: v.Message, CreatedAt: now}
+		if old, ok := previous[v.Rule]; ok {
+			finding.CreatedAt, finding.Acknowledged = old.CreatedAt, old.Acknowledged
+		}
+		findings = append(findings, finding)
+	}
+	j.Findings = findings
+}
+
+// ActiveFindings returns the findings that have not been acknowledged.
+func (j *JournalEntry) ActiveFindings() []Finding {
+	var active []Finding
+	for _, finding := range j.Findings {
+		if !finding.Acknowledged {
+			active = append(active, finding)
+		}
+	}
+	return active
+}
+
+// Acknowledge dismisses the findings with the given codes, or every finding
+// when no code is given, and returns how many it dismissed.
+func (j *JournalEntry) Acknowledge(codes ...string) int {
+	count := 0
+	for i := range j.Findings {
+		finding := &j.Findings[i]
+		if finding.Acknowledged || (len(codes) > 0 && !containsFold(codes, finding.Code)) {
+			continue
+		}
+		finding.Acknowledged = true
+		count++
+	}
+	return count
+}
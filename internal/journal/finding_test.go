@@ -0,0 +1,49 @@
+package journal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUpdateFindings(t *testing.T) {
+	first := time.Date(2026, 4, 7, 17, 0, 0, 0, time.UTC)
+	later := first.Add(2 * time.Hour)
+	entry := &JournalEntry{ID: "20260407"}
+
+	entry.UpdateFindings([]Violation{
+		{Rule: "min_work_time", Severity: SeverityWarning, Message: "6h worked"},
+		{Rule: "lunch_break", Severity: SeverityWarning, Message: "no lunch"},
+	}, first)
+	if got := len(entry.ActiveFindings()); got != 2 {
+		t.Fatalf("expected 2 active findings, got %d", got)
+	}
+
+	if n := entry.Acknowledge("LUNCH_BREAK"); n != 1 {
+		t.Fatalf("Acknowledge() = %d, want 1", n)
+	}
+
+	// The day is edited: the lunch break is still missing and a rule is broken
+	entry.UpdateFindings([]Violation{
+		{Rule: "lunch_break", Severity: SeverityWarning, Message: "still no lunch"},
+		{Rule: "daily_rest", Severity: SeverityError, Message: "short rest"},
+	}, later)
+
+	if len(entry.Findings) != 2 {
+		t.Fatalf("expected the settled min_work_time finding to be dropped, got %+v", entry.Findings)
+	}
+	lunch, rest := entry.Findings[0], entry.Findings[1]
+	if !lunch.Acknowledged || !lunch.CreatedAt.Equal(first) || lunch.Message != "still no lunch" {
+		t.Errorf("expected the lunch finding to stay acknowledged with its first time, got %+v", lunch)
+	}
+	if rest.Acknowledged || !rest.CreatedAt.Equal(later) {
+		t.Errorf("expected a new daily_rest finding, got %+v", rest)
+	}
+
+	active := entry.ActiveFindings()
+	if len(active) != 1 || active[0].Code != "daily_rest" {
+		t.Errorf("ActiveFindings() = %+v, want only daily_rest", active)
+	}
+	if n := entry.Acknowledge(); n != 1 || len(entry.ActiveFindings()) != 0 {
+		t.Errorf("expected Acknowledge() to dismiss the rest, got %d", n)
+	}
+}
@@ -0,0 +1,172 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Holiday is a public holiday read from a holiday list.
+type Holiday struct {
+	Date time.Time // midnight of the holiday
+	Name string
+}
+
+// holidayFile is the YAML holiday list format:
+//
+//	country: DE
+//	holidays:
+//	  - date: 2026-01-01
+//	    name: Neujahr
+//	  - date: 2026-01-06
+//	    name: Heilige Drei Könige
+//	    regions: [BW, BY, ST]
+//
+// Holidays without regions apply to the whole country.
+type holidayFile struct {
+	Country  string `yaml:"country"`
+	Holidays []struct {
+		Date    string   `yaml:"date"`
+		Name    string   `yaml:"name"`
+		Regions []string `yaml:"regions"`
+	} `yaml:"holidays"`
+}
+
+// LoadHolidays reads a holiday list from an ICS calendar (.ics) or a YAML
+// file (.yaml or .yml). Regional holidays in a YAML list are only included
+// when they list region. Dates are placed in loc.
+func LoadHolidays(path, region string, loc *time.Location) ([]Holiday, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, JournalIOError("read holidays", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ics":
+		return ParseHolidaysICS(data, loc)
+	case ".yaml", ".yml":
+		return ParseHolidaysYAML(data, region, loc)
+	default:
+		return nil, ValidationError("holidays", fmt.Sprintf("unsupported holiday list %s, expected an .ics or .yaml file", path))
+	}
+}
+
+// ParseHolidaysYAML parses a YAML holiday list, see holidayFile.
+func ParseHolidaysYAML(data []byte, region string, loc *time.Location) ([]Holiday, error) {
+	var file holidayFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, ValidationError("holidays", "invalid YAML holiday list: "+err.Error())
+	}
+
+	var holidays []Holiday
+	for _, h := range file.Holidays {
+		if len(h.Regions) > 0 && !containsFold(h.Regions, region) {
+			continue
+		}
+		date, err := time.ParseInLocation("2006-01-02", h.Date, loc)
+		if err != nil {
+			return nil, ValidationError("holidays", fmt.Sprintf("invalid date %q for %s, expected YYYY-MM-DD", h.Date, h.Name))
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: h.Name})
+	}
+	return holidays, nil
+}
+
+// ParseHolidaysICS parses the events of an iCalendar file as holidays. An
+// event that spans several days gives a holiday for each of them; only the
+// date part of DTSTART and DTEND is used.
+func ParseHolidaysICS(data []byte, loc *time.Location) ([]Holiday, error) {
+	var holidays []Holiday
+	var start, end time.Time
+	var name string
+	inEvent := false
+
+	for _, line := range unfoldICSLines(data) {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Drop parameters such as DTSTART;VALUE=DATE
+		property, _, _ := strings.Cut(key, ";")
+
+		switch strings.ToUpper(property) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent = true
+				start, end, name = time.Time{}, time.Time{}, ""
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				return nil, ValidationError("holidays", fmt.Sprintf("event %q has no DTSTART", name))
+			}
+			// DTEND is exclusive, and optional for single-day events
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+				holidays = append(holidays, Holiday{Date: day, Name: name})
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			if len(value) < 8 {
+				return nil, ValidationError("holidays", fmt.Sprintf("invalid %s %q", property, value))
+			}
+			date, err := time.ParseInLocation("20060102", value[:8], loc)
+			if err != nil {
+				return nil, ValidationError("holidays", fmt.Sprintf("invalid %s %q", property, value))
+			}
+			if strings.EqualFold(property, "DTSTART") {
+				start = date
+			} else {
+				end = date
+			}
+		case "SUMMARY":
+			if inEvent {
+				name = unescapeICSText(value)
+			}
+		}
+	}
+	return holidays, nil
+}
+
+// unfoldICSLines splits iCalendar data into content lines, joining the
+// continuation lines that start with a space or a tab.
+func unfoldICSLines(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// unescapeICSText undoes the escaping of iCalendar TEXT values.
+func unescapeICSText(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
+
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func holidayDates(holidays []Holiday) []string {
+	dates := make([]string, len(holidays))
+	for i, h := range holidays {
+		dates[i] = h.Date.Format("2006-01-02") + " " + h.Name
+	}
+	return dates
+}
+
+func TestParseHolidaysYAML(t *testing.T) {
+	data := []byte(`country: DE
+holidays:
+  - date: 2026-01-01
+    name: Neujahr
+  - date: 2026-01-06
+    name: Heilige Drei Könige
+    regions: [BW, BY, ST]
+`)
+
+	tests := []struct {
+		region string
+		want   []string
+	}{
+		{region: "", want: []string{"2026-01-01 Neujahr"}},
+		{region: "by", want: []string{"2026-01-01 Neujahr", "2026-01-06 Heilige Drei Könige"}},
+		{region: "BE", want: []string{"2026-01-01 Neujahr"}},
+	}
+	for _, tt := range tests {
+		t.Run("region "+tt.region, func(t *testing.T) {
+			holidays, err := ParseHolidaysYAML(data, tt.region, time.UTC)
+			if err != nil {
+				t.Fatalf("ParseHolidaysYAML() error = %v", err)
+			}
+			got := holidayDates(holidays)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("holiday %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := ParseHolidaysYAML([]byte("holidays:\n  - date: 01/01/2026\n    name: bad\n"), "", time.UTC); err == nil {
+		t.Error("expected an error for a malformed date")
+	}
+}
+
+func TestParseHolidaysICS(t *testing.T) {
+	data := []byte("BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20261225\r\n" +
+		"DTEND;VALUE=DATE:20261227\r\n" +
+		"SUMMARY:Christmas\\, and Boxing\r\n" +
+		"  Day\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20260501T000000Z\r\n" +
+		"SUMMARY:Labour Day\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n")
+
+	holidays, err := ParseHolidaysICS(data, time.UTC)
+	if err != nil {
+		t.Fatalf("ParseHolidaysICS() error = %v", err)
+	}
+	want := []string{
+		"2026-12-25 Christmas, and Boxing Day",
+		"2026-12-26 Christmas, and Boxing Day",
+		"2026-05-01 Labour Day",
+	}
+	got := holidayDates(holidays)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("holiday %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLoadHolidaysRejectsUnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	if err := os.WriteFile(path, []byte("2026-01-01"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadHolidays(path, "", time.UTC); err == nil {
+		t.Error("expected an error for a .txt holiday list")
+	}
+}
@@ -0,0 +1,598 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ImportedDay is one day read from an import file, before it is turned into
+// an entry with BuildImportedEntry or merged into one with MergeImported.
+type ImportedDay struct {
+	Date      time.Time // midnight of the day
+	Sessions  []Session // work, in order and without overlaps
+	Breaks    []Break
+	Notes     []Note
+	Segments  []TimeSegment
+	DayType   DayType // set for a day off
+	DayReason string
+}
+
+// ID returns the ID of the entry the day imports into.
+func (d *ImportedDay) ID() string {
+	return d.Date.Format("20060102")
+}
+
+// LoadImport reads the days of an import file: a CSV file (.csv) written by
+// 'workday export' or by a time tracker such as Toggl or Clockify, or an
+// iCalendar file (.ics). Times without a zone of their own are placed in loc.
+func LoadImport(path string, loc *time.Location) ([]ImportedDay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, JournalIOError("read import", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParseImportCSV(data, loc)
+	case ".ics":
+		return ParseImportICS(data, loc)
+	default:
+		return nil, ValidationError("import", fmt.Sprintf("unsupported import file %s, expected a .csv or .ics file", path))
+	}
+}
+
+// csvTable is a CSV file with its columns looked up by header name.
+type csvTable struct {
+	columns map[string]int // lower case header name to column
+	rows    [][]string
+}
+
+func (t csvTable) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := t.columns[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// get returns the trimmed value of a column of row, or "" when the file has
+// no such column.
+func (t csvTable) get(row []string, name string) string {
+	i, ok := t.columns[name]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// ParseImportCSV parses a CSV file, telling its layout from the header: the
+// timesheet or the breaks of 'workday export', or the time entries of a time
+// tracker with Start Date, Start Time, End Date and End Time columns, such as
+// Toggl or Clockify. Time tracker entries become work sessions and time
+// segments.
+func ParseImportCSV(data []byte, loc *time.Location) ([]ImportedDay, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, ValidationError("import", "invalid CSV: "+err.Error())
+	}
+	if len(records) == 0 {
+		return nil, ValidationError("import", "the CSV file is empty")
+	}
+
+	table := csvTable{columns: make(map[string]int), rows: records[1:]}
+	for i, name := range records[0] {
+		table.columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	switch {
+	case table.has("date", "break id", "start time", "end time", "reason"):
+		return parseBreaksCSV(table, loc)
+	case table.has("date", "day type", "start time", "end time"):
+		return parseTimesheetCSV(table, loc)
+	case table.has("start date", "start time", "end date", "end time"):
+		return parseTrackerCSV(table, loc)
+	default:
+		return nil, ValidationError("import", "unknown CSV layout, expected a workday export or Start Date, Start Time, End Date and End Time columns")
+	}
+}
+
+// importLayouts are the date and time formats accepted in CSV files.
+var (
+	importDateLayouts = []string{"2006-01-02", "01/02/2006", "02.01.2006", "2006/01/02"}
+	importTimeLayouts = []string{"15:04:05", "15:04", "3:04:05 PM", "3:04 PM"}
+)
+
+// parseImportDate parses a date in one of importDateLayouts.
+func parseImportDate(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range importDateLayouts {
+		if date, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// parseImportTime parses a time of day in one of importTimeLayouts and places
+// it on date.
+func parseImportTime(date time.Time, s string) (time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if t, err := time.Parse(layout, strings.ToUpper(s)); err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), 0, date.Location()), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// parseImportInterval parses a start and an end time on date. An end before
+// the start falls on the next day, as in a night shift.
+func parseImportInterval(date time.Time, startStr, endStr string) (time.Time, time.Time, error) {
+	start, err := parseImportTime(date, startStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if endStr == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("no end time, it may still be ongoing")
+	}
+	end, err := parseImportTime(date, endStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if end.Before(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end, nil
+}
+
+// importLineError reports a problem with line (counted from 1, header
+// included) of an import file.
+func importLineError(line int, err error) error {
+	return ValidationError("import", fmt.Sprintf("line %d: %v", line, err))
+}
+
+// importDays collects the days of an import in the order they are first seen.
+type importDays struct {
+	loc   *time.Location
+	byID  map[string]*ImportedDay
+	order []string
+}
+
+func newImportDays(loc *time.Location) *importDays {
+	return &importDays{loc: loc, byID: make(map[string]*ImportedDay)}
+}
+
+// on returns the day t falls on in the import's zone.
+func (d *importDays) on(t time.Time) *ImportedDay {
+	t = t.In(d.loc)
+	id := t.Format("20060102")
+	day, ok := d.byID[id]
+	if !ok {
+		day = &ImportedDay{Date: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, d.loc)}
+		d.byID[id] = day
+		d.order = append(d.order, id)
+	}
+	return day
+}
+
+// list returns the days sorted by date, with overlapping or touching
+// sessions joined and the breaks in order.
+func (d *importDays) list() []ImportedDay {
+	sort.Strings(d.order)
+	days := make([]ImportedDay, 0, len(d.order))
+	for _, id := range d.order {
+		day := *d.byID[id]
+		day.Sessions = joinSessions(day.Sessions)
+		sort.Slice(day.Breaks, func(a, b int) bool { return day.Breaks[a].StartTime.Before(day.Breaks[b].StartTime) })
+		days = append(days, day)
+	}
+	return days
+}
+
+// joinSessions sorts sessions and joins those that overlap or touch, such as
+// two time tracker entries back to back.
+func joinSessions(sessions []Session) []Session {
+	sort.Slice(sessions, func(a, b int) bool { return sessions[a].StartTime.Before(sessions[b].StartTime) })
+	var joined []Session
+	for _, session := range sessions {
+		if n := len(joined); n > 0 && !session.StartTime.After(joined[n-1].EndTime) {
+			if session.EndTime.After(joined[n-1].EndTime) {
+				joined[n-1].EndTime = session.EndTime
+			}
+			continue
+		}
+		joined = append(joined, session)
+	}
+	return joined
+}
+
+// parseTimesheetCSV parses the timesheet of 'workday export'. It holds the
+// start and end of each day, so a day with several sessions comes back as
+// one session from the first start to the last end.
+func parseTimesheetCSV(table csvTable, loc *time.Location) ([]ImportedDay, error) {
+	days := newImportDays(loc)
+	for i, row := range table.rows {
+		line := i + 2
+		date, err := parseImportDate(table.get(row, "date"), loc)
+		if err != nil {
+			return nil, importLineError(line, err)
+		}
+
+		dayType := DayWork
+		if s := table.get(row, "day type"); s != "" {
+			if dayType, err = ParseDayType(s); err != nil {
+				return nil, importLineError(line, err)
+			}
+		}
+
+		startStr, endStr := table.get(row, "start time"), table.get(row, "end time")
+		day := days.on(date)
+		if dayType.IsOff() {
+			day.DayType = dayType
+			// Days off without work start and end at midnight
+			if startStr == endStr {
+				continue
+			}
+		}
+		start, end, err := parseImportInterval(date, startStr, endStr)
+		if err != nil {
+			return nil, importLineError(line, err)
+		}
+		day.Sessions = append(day.Sessions, Session{StartTime: start, EndTime: end})
+	}
+	return days.list(), nil
+}
+
+// parseBreaksCSV parses the breaks of 'workday export'. The days have breaks
+// only, so they can be merged into days that are already in the journal.
+func parseBreaksCSV(table csvTable, loc *time.Location) ([]ImportedDay, error) {
+	days := newImportDays(loc)
+	for i, row := range table.rows {
+		line := i + 2
+		date, err := parseImportDate(table.get(row, "date"), loc)
+		if err != nil {
+			return nil, importLineError(line, err)
+		}
+		start, end, err := parseImportInterval(date, table.get(row, "start time"), table.get(row, "end time"))
+		if err != nil {
+			return nil, importLineError(line, err)
+		}
+		day := days.on(date)
+		day.Breaks = append(day.Breaks, Break{StartTime: start, EndTime: end, Reason: table.get(row, "reason")})
+	}
+	return days.list(), nil
+}
+
+// parseTrackerCSV parses the time entries of a time tracker. Every entry is
+// tracked as a time segment and counts as work; entries back to back are
+// joined into one session.
+func parseTrackerCSV(table csvTable, loc *time.Location) ([]ImportedDay, error) {
+	days := newImportDays(loc)
+	for i, row := range table.rows {
+		line := i + 2
+		startDate, err := parseImportDate(table.get(row, "start date"), loc)
+		if err != nil {
+			return nil, importLineError(line, err)
+		}
+		endDate, err := parseImportDate(table.get(row, "end date"), loc)
+		if err != nil {
+			return nil, importLineError(line, err)
+		}
+		start, err := parseImportTime(startDate, table.get(row, "start time"))
+		if err != nil {
+			return nil, importLineError(line, err)
+		}
+		end, err := parseImportTime(endDate, table.get(row, "end time"))
+		if err != nil {
+			return nil, importLineError(line, err)
+		}
+		if !end.After(start) {
+			return nil, importLineError(line, fmt.Errorf("the entry ends before it starts"))
+		}
+
+		// Segments need a project and a task, which trackers leave optional
+		project := table.get(row, "project")
+		if project == "" {
+			project = "unassigned"
+		}
+		task, description := table.get(row, "task"), table.get(row, "description")
+		if task == "" {
+			task, description = description, ""
+		}
+		if task == "" {
+			task = project
+		}
+
+		day := days.on(start)
+		day.Sessions = append(day.Sessions, Session{StartTime: start, EndTime: end})
+		day.Segments = append(day.Segments, TimeSegment{
+			StartTime:   start,
+			EndTime:     end,
+			Client:      table.get(row, "client"),
+			Project:     project,
+			Task:        task,
+			Description: description,
+		})
+	}
+	return days.list(), nil
+}
+
+// ParseImportICS parses the events of an iCalendar file, such as one written
+// by 'workday export --format ics'. Events whose summary starts with "Break"
+// become breaks, with the rest of the summary as the reason; the others are
+// work sessions, and the lines of their description become notes. All-day
+// events are skipped.
+func ParseImportICS(data []byte, loc *time.Location) ([]ImportedDay, error) {
+	days := newImportDays(loc)
+	var start, end time.Time
+	var summary, description string
+	inEvent := false
+
+	for _, line := range unfoldICSLines(data) {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		property, params, _ := strings.Cut(key, ";")
+
+		switch strings.ToUpper(property) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent = true
+				start, end, summary, description = time.Time{}, time.Time{}, "", ""
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() || end.IsZero() {
+				continue
+			}
+			if !end.After(start) {
+				return nil, ValidationError("import", fmt.Sprintf("event %q ends before it starts", summary))
+			}
+
+			day := days.on(start)
+			if reason, ok := cutPrefixFold(summary, "break"); ok {
+				reason = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(reason), ":"))
+				if reason == "" {
+					reason = "break"
+				}
+				day.Breaks = append(day.Breaks, Break{StartTime: start.In(loc), EndTime: end.In(loc), Reason: reason})
+				continue
+			}
+			day.Sessions = append(day.Sessions, Session{StartTime: start.In(loc), EndTime: end.In(loc)})
+			for _, text := range splitICSText(description) {
+				if !containsNote(day.Notes, text) {
+					day.Notes = append(day.Notes, Note{Contents: text})
+				}
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			t, err := parseICSDateTime(value, params, loc)
+			if err != nil {
+				return nil, ValidationError("import", fmt.Sprintf("invalid %s %q", property, value))
+			}
+			if strings.EqualFold(property, "DTSTART") {
+				start = t
+			} else {
+				end = t
+			}
+		case "SUMMARY":
+			if inEvent {
+				summary = unescapeICSText(value)
+			}
+		case "DESCRIPTION":
+			if inEvent {
+				description = value
+			}
+		}
+	}
+	return days.list(), nil
+}
+
+// parseICSDateTime parses an iCalendar date-time: in UTC with a Z suffix, in
+// the zone of a TZID parameter, or else in loc. All-day dates give a zero
+// time.
+func parseICSDateTime(value, params string, loc *time.Location) (time.Time, error) {
+	if len(value) == len("20060102") {
+		return time.Time{}, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse("20060102T150405Z", value)
+	}
+	for _, param := range strings.Split(params, ";") {
+		if name, zone, ok := strings.Cut(param, "="); ok && strings.EqualFold(name, "TZID") {
+			if tz, err := time.LoadLocation(strings.Trim(zone, `"`)); err == nil {
+				loc = tz
+			}
+		}
+	}
+	return time.ParseInLocation("20060102T150405", value, loc)
+}
+
+// splitICSText splits an escaped iCalendar TEXT value into its lines.
+func splitICSText(value string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(value, `\N`, `\n`), `\n`) {
+		if line = strings.TrimSpace(unescapeICSText(line)); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+func containsNote(notes []Note, contents string) bool {
+	for _, note := range notes {
+		if note.Contents == contents {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildImportedEntry turns day into a new entry, validated the way
+// NewBackfilledEntry validates a backfilled day: the breaks must fall within
+// the day and not overlap, and every time segment must be valid. A day with
+// breaks but no work cannot be an entry of its own.
+func BuildImportedEntry(day ImportedDay) (*JournalEntry, error) {
+	if len(day.Sessions) == 0 {
+		if day.DayType.IsOff() {
+			return NewDayOffEntry(day.Date, day.DayType, day.DayReason)
+		}
+		return nil, ValidationError("import", fmt.Sprintf("%s has no work to import", day.Date.Format("2006-01-02")))
+	}
+
+	loc := day.Date.Location()
+	sessions := make([]Session, len(day.Sessions))
+	for i, session := range day.Sessions {
+		sessions[i] = Session{StartTime: toMinute(session.StartTime, loc), EndTime: toMinute(session.EndTime, loc)}
+	}
+	breaks := make([]Break, len(day.Breaks))
+	for i, br := range day.Breaks {
+		breaks[i] = Break{StartTime: br.StartTime.In(loc), EndTime: br.EndTime.In(loc), Reason: br.Reason}
+	}
+
+	entry, err := NewBackfilledEntry(day.Date, sessions[0].StartTime, sessions[len(sessions)-1].EndTime, breaks, day.Notes)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) > 1 {
+		entry.Sessions = sessions
+		if result := ValidateEntry(entry); !result.IsValid {
+			return nil, result.Error
+		}
+	}
+	entry.SetDayType(day.DayType, day.DayReason)
+	for _, segment := range day.Segments {
+		if err := entry.AddTimeSegment(segment); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+// MergeImported adds to the entry the sessions, breaks, notes and time
+// segments of day that it does not have yet, and widens its start and end to
+// cover them. The entry is left unchanged when the result is not valid, e.g.
+// because an imported session overlaps one of its own.
+func (j *JournalEntry) MergeImported(day ImportedDay) error {
+	if j.EndTime.IsZero() {
+		return ValidationError("import", fmt.Sprintf("%s is still in progress, end it before merging into it", j.ID))
+	}
+
+	merged := *j
+	merged.Sessions = append([]Session(nil), j.Sessions...)
+	merged.Breaks = append([]Break(nil), j.Breaks...)
+	merged.Notes = append([]Note(nil), j.Notes...)
+	merged.TimeSegments = append([]TimeSegment(nil), j.TimeSegments...)
+
+	loc := j.StartTime.Location()
+	for _, session := range day.Sessions {
+		session = Session{StartTime: toMinute(session.StartTime, loc), EndTime: toMinute(session.EndTime, loc)}
+		if !containsSession(merged.Sessions, session) {
+			merged.Sessions = append(merged.Sessions, session)
+		}
+	}
+	sort.Slice(merged.Sessions, func(a, b int) bool { return merged.Sessions[a].StartTime.Before(merged.Sessions[b].StartTime) })
+	if len(merged.Sessions) > 0 {
+		first, last := merged.Sessions[0], merged.Sessions[len(merged.Sessions)-1]
+		if len(j.Sessions) == 0 || first.StartTime.Before(merged.StartTime) {
+			merged.StartTime = first.StartTime
+		}
+		if last.EndTime.After(merged.EndTime) {
+			merged.EndTime = last.EndTime
+		}
+	}
+
+	for _, br := range day.Breaks {
+		br = Break{StartTime: toMinute(br.StartTime, loc), EndTime: toMinute(br.EndTime, loc), Reason: br.Reason}
+		if containsBreak(merged.Breaks, br) {
+			continue
+		}
+		if result := ValidateBreak(br); !result.IsValid {
+			return ValidationError("break", result.Error.Error())
+		}
+		if result := ValidateBreakOverlap(br, merged.Breaks); !result.IsValid {
+			return ValidationError("break", result.Error.Error())
+		}
+		merged.Breaks = append(merged.Breaks, br)
+	}
+	sort.Slice(merged.Breaks, func(a, b int) bool { return merged.Breaks[a].StartTime.Before(merged.Breaks[b].StartTime) })
+
+	for _, note := range day.Notes {
+		note.ParseContent()
+		if !containsNote(merged.Notes, note.Contents) {
+			merged.Notes = append(merged.Notes, note)
+		}
+	}
+
+	for _, segment := range day.Segments {
+		if containsSegment(merged.TimeSegments, segment) {
+			continue
+		}
+		if err := merged.AddTimeSegment(segment); err != nil {
+			return err
+		}
+	}
+
+	if day.DayType.IsOff() && !merged.IsDayOff() {
+		merged.SetDayType(day.DayType, day.DayReason)
+	}
+
+	if result := ValidateEntry(&merged); !result.IsValid {
+		return result.Error
+	}
+	*j = merged
+	return nil
+}
+
+// toMinute places t in loc and drops its seconds, since entries are kept to
+// the minute.
+func toMinute(t time.Time, loc *time.Location) time.Time {
+	return t.In(loc).Truncate(time.Minute)
+}
+
+func containsSession(sessions []Session, s Session) bool {
+	for _, session := range sessions {
+		if session.StartTime.Equal(s.StartTime) && session.EndTime.Equal(s.EndTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsBreak(breaks []Break, b Break) bool {
+	for _, br := range breaks {
+		if br.StartTime.Equal(b.StartTime) && br.EndTime.Equal(b.EndTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSegment(segments []TimeSegment, s TimeSegment) bool {
+	for _, segment := range segments {
+		if segment.StartTime.Equal(s.StartTime) && segment.Project == s.Project && segment.Task == s.Task {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,352 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// describeDays renders imported days as one line each, e.g.
+// "2026-04-07 work 09:00-12:00,13:00-17:00 breaks lunch@12:00 notes 1 segments 0".
+func describeDays(days []ImportedDay) []string {
+	lines := make([]string, len(days))
+	for i, day := range days {
+		var sessions, breaks []string
+		for _, s := range day.Sessions {
+			sessions = append(sessions, FormatClockTime(s.StartTime, day.Date)+"-"+FormatClockTime(s.EndTime, day.Date))
+		}
+		for _, b := range day.Breaks {
+			breaks = append(breaks, b.Reason+"@"+FormatClockTime(b.StartTime, day.Date))
+		}
+		dayType := string(day.DayType)
+		if dayType == "" {
+			dayType = "work"
+		}
+		lines[i] = fmt.Sprintf("%s %s %s breaks %s notes %d segments %d", day.Date.Format("2006-01-02"), dayType,
+			strings.Join(sessions, ","), strings.Join(breaks, ","), len(day.Notes), len(day.Segments))
+	}
+	return lines
+}
+
+func TestParseImportCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "workday timesheet",
+			data: "Date,Day Type,Start Time,End Time,Work Time,Break Time,Number of Sessions,Number of Breaks,Number of Notes\n" +
+				"2026-04-07,work,09:00:00,17:30:00,7h30m0s,1h0m0s,1,1,0\n" +
+				"2026-04-08,holiday,00:00:00,00:00:00,0s,0s,0,0,0\n" +
+				"2026-04-09,work,22:00:00,06:00:00,8h0m0s,0s,1,0,0\n",
+			want: []string{
+				"2026-04-07 work 09:00-17:30 breaks  notes 0 segments 0",
+				"2026-04-08 holiday  breaks  notes 0 segments 0",
+				"2026-04-09 work 22:00-06:00+1 breaks  notes 0 segments 0",
+			},
+		},
+		{
+			name: "workday breaks",
+			data: "Date,Break ID,Start Time,End Time,Duration,Reason\n" +
+				"2026-04-07,2,15:00:00,15:15:00,15m0s,coffee\n" +
+				"2026-04-07,1,12:00:00,12:45:00,45m0s,lunch\n",
+			want: []string{"2026-04-07 work  breaks lunch@12:00,coffee@15:00 notes 0 segments 0"},
+		},
+		{
+			name: "toggl",
+			data: "\ufeffUser,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags\n" +
+				"Ana,ana@example.com,Acme,Website,,Fix header,No,2026-04-07,09:00:00,2026-04-07,10:30:00,01:30:00,\n" +
+				"Ana,ana@example.com,Acme,Website,,Review,No,2026-04-07,10:30:00,2026-04-07,12:00:00,01:30:00,\n" +
+				"Ana,ana@example.com,,,,,No,2026-04-07,13:00:00,2026-04-07,14:00:00,01:00:00,\n",
+			want: []string{"2026-04-07 work 09:00-12:00,13:00-14:00 breaks  notes 0 segments 3"},
+		},
+		{
+			name: "clockify with 12 hour times",
+			data: "Project,Client,Description,Task,Start Date,Start Time,End Date,End Time\n" +
+				"Website,Acme,Header,Frontend,04/07/2026,09:00 AM,04/07/2026,01:15 PM\n",
+			want: []string{"2026-04-07 work 09:00-13:15 breaks  notes 0 segments 1"},
+		},
+		{
+			name:    "unknown layout",
+			data:    "When,What\n2026-04-07,work\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed date",
+			data:    "Date,Day Type,Start Time,End Time\nyesterday,work,09:00:00,17:00:00\n",
+			wantErr: true,
+		},
+		{
+			name:    "ongoing day",
+			data:    "Date,Day Type,Start Time,End Time\n2026-04-07,work,09:00:00,\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, err := ParseImportCSV([]byte(tt.data), time.UTC)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) {
+					t.Fatalf("ParseImportCSV() error = %v, want a validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseImportCSV() error = %v", err)
+			}
+			got := describeDays(days)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("days =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseImportCSVSegments(t *testing.T) {
+	data := "Project,Task,Description,Start Date,Start Time,End Date,End Time\n" +
+		"Website,,Fix header,2026-04-07,09:00,2026-04-07,10:00\n" +
+		",,,2026-04-07,10:00,2026-04-07,11:00\n"
+	days, err := ParseImportCSV([]byte(data), time.UTC)
+	if err != nil {
+		t.Fatalf("ParseImportCSV() error = %v", err)
+	}
+	segments := days[0].Segments
+	if len(segments) != 2 {
+		t.Fatalf("got %d segments, want 2", len(segments))
+	}
+	if segments[0].Project != "Website" || segments[0].Task != "Fix header" || segments[0].Description != "" {
+		t.Errorf("segment 0 = %+v, want the description as the task", segments[0])
+	}
+	if segments[1].Project != "unassigned" || segments[1].Task != "unassigned" {
+		t.Errorf("segment 1 = %+v, want an unassigned project and task", segments[1])
+	}
+}
+
+func TestParseImportICS(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no time zone data")
+	}
+	data := []byte("BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART:20260407T070000Z\r\nDTEND:20260407T100000Z\r\nSUMMARY:Work\r\n" +
+		"DESCRIPTION:Shipped the #release\\nReviewed PRs\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART:20260407T110000Z\r\nDTEND:20260407T150000Z\r\nSUMMARY:Work\r\n" +
+		"DESCRIPTION:Shipped the #release\\nReviewed PRs\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART:20260407T100000Z\r\nDTEND:20260407T104500Z\r\nSUMMARY:Break: lun\r\n ch\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART;TZID=Europe/Berlin:20260408T080000\r\nDTEND;TZID=Europe/Berlin:20260408T120000\r\n" +
+		"SUMMARY:On call\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20260409\r\nDTEND;VALUE=DATE:20260410\r\nSUMMARY:Offsite\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n")
+
+	days, err := ParseImportICS(data, berlin)
+	if err != nil {
+		t.Fatalf("ParseImportICS() error = %v", err)
+	}
+	want := []string{
+		"2026-04-07 work 09:00-12:00,13:00-17:00 breaks lunch@12:00 notes 2 segments 0",
+		"2026-04-08 work 08:00-12:00 breaks  notes 0 segments 0",
+	}
+	if got := describeDays(days); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("days =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if days[0].Notes[0].Contents != "Shipped the #release" {
+		t.Errorf("note = %q, want the first line of the description", days[0].Notes[0].Contents)
+	}
+
+	bad := []byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20260407T100000Z\r\nDTEND:20260407T090000Z\r\nSUMMARY:Work\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n")
+	if _, err := ParseImportICS(bad, time.UTC); !errors.Is(err, ErrValidation) {
+		t.Errorf("ParseImportICS() error = %v, want a validation error for an event ending before it starts", err)
+	}
+}
+
+func TestBuildImportedEntry(t *testing.T) {
+	date := time.Date(2026, 4, 7, 0, 0, 0, 0, time.UTC)
+	at := func(hour, min int) time.Time {
+		return date.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+
+	tests := []struct {
+		name         string
+		day          ImportedDay
+		wantSessions int
+		wantDayOff   bool
+		wantErr      bool
+	}{
+		{
+			name: "single session with a break",
+			day: ImportedDay{
+				Date:     date,
+				Sessions: []Session{{StartTime: at(9, 0), EndTime: at(17, 0)}},
+				Breaks:   []Break{{StartTime: at(12, 0), EndTime: at(12, 30), Reason: "lunch"}},
+				Notes:    []Note{{Contents: "imported #toggl"}},
+			},
+			wantSessions: 1,
+		},
+		{
+			name: "several sessions and segments",
+			day: ImportedDay{
+				Date:     date,
+				Sessions: []Session{{StartTime: at(9, 0), EndTime: at(12, 0)}, {StartTime: at(13, 0), EndTime: at(17, 0)}},
+				Segments: []TimeSegment{{StartTime: at(9, 0), EndTime: at(12, 0), Project: "web", Task: "header"}},
+			},
+			wantSessions: 2,
+		},
+		{
+			name:       "day off",
+			day:        ImportedDay{Date: date, DayType: DayHoliday},
+			wantDayOff: true,
+		},
+		{
+			name:    "breaks only",
+			day:     ImportedDay{Date: date, Breaks: []Break{{StartTime: at(12, 0), EndTime: at(12, 30), Reason: "lunch"}}},
+			wantErr: true,
+		},
+		{
+			name: "break outside the day",
+			day: ImportedDay{
+				Date:     date,
+				Sessions: []Session{{StartTime: at(9, 0), EndTime: at(12, 0)}},
+				Breaks:   []Break{{StartTime: at(18, 0), EndTime: at(18, 30), Reason: "late"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "segment without a task",
+			day: ImportedDay{
+				Date:     date,
+				Sessions: []Session{{StartTime: at(9, 0), EndTime: at(12, 0)}},
+				Segments: []TimeSegment{{StartTime: at(9, 0), EndTime: at(12, 0), Project: "web"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := BuildImportedEntry(tt.day)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildImportedEntry() error = %v", err)
+			}
+			if entry.ID != "20260407" {
+				t.Errorf("ID = %q, want 20260407", entry.ID)
+			}
+			if got := len(entry.WorkSessions()); got != tt.wantSessions {
+				t.Errorf("got %d sessions, want %d", got, tt.wantSessions)
+			}
+			if entry.IsDayOff() != tt.wantDayOff {
+				t.Errorf("IsDayOff() = %v, want %v", entry.IsDayOff(), tt.wantDayOff)
+			}
+			if len(entry.TimeSegments) != len(tt.day.Segments) {
+				t.Errorf("got %d segments, want %d", len(entry.TimeSegments), len(tt.day.Segments))
+			}
+			for _, note := range entry.Notes {
+				if len(note.Tags) == 0 {
+					t.Errorf("note %q has no tags, want them parsed", note.Contents)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeImported(t *testing.T) {
+	date := time.Date(2026, 4, 7, 0, 0, 0, 0, time.UTC)
+	at := func(hour, min int) time.Time {
+		return date.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	existing := func() *JournalEntry {
+		entry, err := NewBackfilledEntry(date, at(9, 0), at(12, 0), []Break{{StartTime: at(10, 0), EndTime: at(10, 15), Reason: "coffee"}}, []Note{{Contents: "standup"}})
+		if err != nil {
+			t.Fatalf("NewBackfilledEntry() error = %v", err)
+		}
+		return entry
+	}
+
+	tests := []struct {
+		name         string
+		day          ImportedDay
+		wantSessions int
+		wantBreaks   int
+		wantNotes    int
+		wantEnd      time.Time
+		wantErr      bool
+	}{
+		{
+			name: "adds a later session and a break",
+			day: ImportedDay{
+				Date:     date,
+				Sessions: []Session{{StartTime: at(13, 0), EndTime: at(17, 0)}},
+				Breaks:   []Break{{StartTime: at(15, 0), EndTime: at(15, 10), Reason: "tea"}},
+				Notes:    []Note{{Contents: "standup"}, {Contents: "deploy"}},
+			},
+			wantSessions: 2,
+			wantBreaks:   2,
+			wantNotes:    2,
+			wantEnd:      at(17, 0),
+		},
+		{
+			name: "skips what is already there",
+			day: ImportedDay{
+				Date:     date,
+				Sessions: []Session{{StartTime: at(9, 0), EndTime: at(12, 0)}},
+				Breaks:   []Break{{StartTime: at(10, 0), EndTime: at(10, 15), Reason: "coffee"}},
+			},
+			wantSessions: 1,
+			wantBreaks:   1,
+			wantNotes:    1,
+			wantEnd:      at(12, 0),
+		},
+		{
+			name:    "overlapping session",
+			day:     ImportedDay{Date: date, Sessions: []Session{{StartTime: at(11, 0), EndTime: at(14, 0)}}},
+			wantErr: true,
+		},
+		{
+			name:    "overlapping break",
+			day:     ImportedDay{Date: date, Breaks: []Break{{StartTime: at(10, 10), EndTime: at(10, 30), Reason: "call"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := existing()
+			err := entry.MergeImported(tt.day)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if len(entry.Sessions) != 1 || len(entry.Breaks) != 1 {
+					t.Error("the entry was changed by a failed merge")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MergeImported() error = %v", err)
+			}
+			if len(entry.Sessions) != tt.wantSessions || len(entry.Breaks) != tt.wantBreaks || len(entry.Notes) != tt.wantNotes {
+				t.Errorf("got %d sessions, %d breaks, %d notes, want %d, %d, %d",
+					len(entry.Sessions), len(entry.Breaks), len(entry.Notes), tt.wantSessions, tt.wantBreaks, tt.wantNotes)
+			}
+			if !entry.EndTime.Equal(tt.wantEnd) {
+				t.Errorf("EndTime = %v, want %v", entry.EndTime, tt.wantEnd)
+			}
+		})
+	}
+
+	open := existing()
+	open.EndTime, open.Sessions[0].EndTime = time.Time{}, time.Time{}
+	if err := open.MergeImported(ImportedDay{Date: date}); !errors.Is(err, ErrValidation) {
+		t.Errorf("MergeImported() on an open day error = %v, want a validation error", err)
+	}
+}
@@ -4,12 +4,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 )
 
 // SaveEntries encodes the given journal entries into JSON format and writes
 // them to a file with the specified filename.
 //
+// The write is atomic: the data goes to a temporary file in the same directory,
+// which is synced to disk and then renamed over filename. A crash, a full disk
+// or an interrupt mid-write leaves the previous journal untouched. The mode of
+// an existing journal file is preserved.
+//
 // The function will return an error if the encoding process fails or if the
 // file cannot be created or written to.
 //
@@ -29,26 +36,134 @@ func SaveEntries(journalEntries []JournalEntry, filename string) error {
 	if err != nil {
 		return JournalIOError("marshal", err)
 	}
-	file, err := os.Create(filename)
+
+	return writeFileAtomic(filename, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// maxSymlinks is how many symlinks resolveSymlinks follows before it gives up
+// on a loop.
+const maxSymlinks = 255
+
+// resolveSymlinks returns the file filename ends up at once every symlink on
+// the way is followed. The file itself need not exist yet: a symlink to a
+// missing file resolves to the path it points to.
+func resolveSymlinks(filename string) (string, error) {
+	for i := 0; i < maxSymlinks; i++ {
+		resolved, err := filepath.EvalSymlinks(filename)
+		if err == nil {
+			return resolved, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		// Something on the way is missing: a new file, or a link to one
+		info, err := os.Lstat(filename)
+		if errors.Is(err, os.ErrNotExist) {
+			return filename, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			return filename, nil
+		}
+		target, err := os.Readlink(filename)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(filename), target)
+		}
+		filename = target
+	}
+	return "", fmt.Errorf("too many levels of symbolic links in %s", filename)
+}
+
+// writeFileAtomic replaces filename with the contents produced by write.
+//
+// The contents are written to a temporary file next to filename, synced and
+// renamed into place, so readers either see the old file or the complete new
+// one. If write fails the temporary file is removed and filename is left as it
+// was. An existing file's permission bits are carried over; new files get 0644.
+// When filename is a symlink the file it points to is replaced, and the link
+// is kept.
+func writeFileAtomic(filename string, write func(w io.Writer) error) error {
+	filename, err := resolveSymlinks(filename)
 	if err != nil {
-		return JournalIOError("create", err)
+		return JournalIOError("resolve", err)
+	}
+
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(filename); err == nil {
+		mode = info.Mode().Perm()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return JournalIOError("stat", err)
 	}
-	defer file.Close()
 
-	_, err = file.Write(data)
+	dir, base := filepath.Split(filename)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp-*")
 	if err != nil {
+		return JournalIOError("create temporary", err)
+	}
+	tmpName := tmp.Name()
+	// Clean up the temporary file on any failure; after a successful rename
+	// there is nothing left to remove.
+	committed := false
+	defer func() {
+		if !committed {
+			tmp.Close()
+			os.Remove(tmpName)
+		}
+	}()
+
+	if err := write(tmp); err != nil {
 		return JournalIOError("write", err)
 	}
+	if err := tmp.Chmod(mode); err != nil {
+		return JournalIOError("set permissions on", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return JournalIOError("sync", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return JournalIOError("close", err)
+	}
+	if err := os.Rename(tmpName, filename); err != nil {
+		return JournalIOError("replace", err)
+	}
+	committed = true
 
-	return nil
+	// Persist the rename itself. Not every platform supports syncing a
+	// directory, so failures here are not reported.
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
 
+	return nil
 }
 
 // LoadEntries reads the JSON file with the given filename, unmarshals its contents
 // into a slice of JournalEntry, and returns the slice. If the file does not exists,
 // LoadEntries creates the file and returns an empty slice. If the file cannot be
 // created, LoadEntries returns an error.
+//
+// A journal with an older schema version is migrated first, see MigrateFile,
+// while holding its lock unless this process already holds it.
 func LoadEntries(filename string) ([]JournalEntry, error) {
+	return loadJournalFile(filename, filename)
+}
+
+// loadJournalFile is LoadEntries for filename, a file of the journal that is
+// locked at lockPath.
+func loadJournalFile(filename, lockPath string) ([]JournalEntry, error) {
 	// Try to read the file
 	data, err := os.ReadFile(filename)
 	if err != nil {
@@ -60,7 +175,10 @@ func LoadEntries(filename string) ([]JournalEntry, error) {
 			if err != nil {
 				return nil, JournalIOError("marshal empty journal", err)
 			}
-			err = os.WriteFile(filename, jsonData, 0644)
+			err = writeFileAtomic(filename, func(w io.Writer) error {
+				_, err := w.Write(jsonData)
+				return err
+			})
 			if err != nil {
 				return nil, JournalIOError("create empty journal", err)
 			}
@@ -70,47 +188,56 @@ func LoadEntries(filename string) ([]JournalEntry, error) {
 		return nil, JournalIOError("read", err)
 	}
 
-	var journalData Journal
-	err = json.Unmarshal(data, &journalData)
-	if err != nil {
-		fmt.Println("Error unmarshaling, will attempt to use older format...")
-		//If we reach this section, it probably means we are using an old format file
-		var oldEntries []JournalEntry
-
-		err = json.Unmarshal(data, &oldEntries)
-		if err != nil {
-			fmt.Printf("error unmarshaling using the old format: %v\n", err)
-			return nil, err
-		}
-		journalData = Journal{
-			Version: SchemaVersion,
-			Entries: migrateEntries(oldEntries, 0),
-		}
+	entries, current, err := decodeEntries(data)
+	if err != nil || current {
+		return entries, err
 	}
 
-	// Handle migration if necessary
-	if journalData.Version < SchemaVersion {
-		fmt.Printf("Detected outdated schema version %d, updating to %d...\n", journalData.Version, SchemaVersion)
-		journalData.Entries = migrateEntries(journalData.Entries, journalData.Version)
-		journalData.Version = SchemaVersion
-		// Save the migrated data back to the file
-		err = SaveEntries(journalData.Entries, filename)
-		if err != nil {
-			return nil, JournalIOError("save migrated data", err)
-		}
+	// Migrate under the lock, where the file is read again in case another
+	// process migrated it meanwhile
+	var report *MigrationReport
+	err = withMigrationLock(lockPath, func() error {
+		var err error
+		report, err = MigrateFile(filename, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if report.Migrated() && OnMigrated != nil {
+		OnMigrated(filename, report)
 	}
 
-	return journalData.Entries, nil
+	if data, err = os.ReadFile(filename); err != nil {
+		return nil, JournalIOError("read", err)
+	}
+	entries, current, err = decodeEntries(data)
+	if err == nil && !current {
+		err = MigrationError(report.From, "the migrated journal is still at an older version", nil)
+	}
+	return entries, err
 }
 
-func migrateEntries(entries []JournalEntry, version int) []JournalEntry {
-	if version < 1 {
-		// Adds default empty breaks to entries
-		for i := range entries {
-			if entries[i].Breaks == nil {
-				entries[i].Breaks = []Break{}
-			}
-		}
+// decodeEntries decodes the entries of a journal. It reports false, without
+// entries, when the journal is at an older schema version and has to be
+// migrated first.
+func decodeEntries(data []byte) ([]JournalEntry, bool, error) {
+	var journalData Journal
+	err := json.Unmarshal(data, &journalData)
+	if err == nil && journalData.Version == SchemaVersion {
+		return journalData.Entries, true, nil
+	}
+
+	// Older journals may not even decode into the current types
+	_, version, versionErr := decodeJournalDocument(data)
+	if versionErr != nil {
+		return nil, false, versionErr
+	}
+	if version < SchemaVersion {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, JournalIOError("decode", err)
 	}
-	return entries
+	return journalData.Entries, true, nil
 }
@@ -2,6 +2,8 @@ package journal
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
 	"reflect"
 	"testing"
@@ -145,3 +147,133 @@ func TestSaveEntries(t *testing.T) {
 		}
 	})
 }
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Run("When the writer fails the previous journal stays intact", func(t *testing.T) {
+		dir := t.TempDir()
+		filename := dir + "/journal.json"
+		entries := []JournalEntry{{ID: "20240527", StartTime: time.Date(2024, 5, 27, 9, 0, 0, 0, time.UTC)}}
+		if err := SaveEntries(entries, filename); err != nil {
+			t.Fatal(err)
+		}
+		before, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Simulate a write that dies halfway through, e.g. a full disk.
+		err = writeFileAtomic(filename, func(w io.Writer) error {
+			w.Write([]byte(`{"version":1,"entr`))
+			return errors.New("no space left on device")
+		})
+		if err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+		if !errors.Is(err, ErrJournalIO) {
+			t.Errorf("Expected ErrJournalIO, got %v", err)
+		}
+
+		after, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(before) != string(after) {
+			t.Errorf("Journal was modified by a failed write; before=%q after=%q", before, after)
+		}
+
+		// No temporary files may be left behind.
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) != 1 {
+			t.Errorf("Expected only the journal in %s, found %d files", dir, len(files))
+		}
+	})
+
+	t.Run("When replacing a file its permissions are preserved", func(t *testing.T) {
+		filename := t.TempDir() + "/journal.json"
+		if err := os.WriteFile(filename, []byte(`{"version":1,"entries":[]}`), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := SaveEntries([]JournalEntry{{ID: "1"}}, filename); err != nil {
+			t.Fatalf("Error saving entries: %v", err)
+		}
+
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("Expected mode 0600, got %v", info.Mode().Perm())
+		}
+	})
+
+	t.Run("When the file does not exist it is created", func(t *testing.T) {
+		filename := t.TempDir() + "/journal.json"
+
+		if err := SaveEntries([]JournalEntry{}, filename); err != nil {
+			t.Fatalf("Error saving entries: %v", err)
+		}
+
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0644 {
+			t.Errorf("Expected mode 0644, got %v", info.Mode().Perm())
+		}
+	})
+
+	t.Run("When the journal is a symlink the link is kept", func(t *testing.T) {
+		dir := t.TempDir()
+		target := dir + "/data/journal.json"
+		if err := os.Mkdir(dir+"/data", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := SaveEntries([]JournalEntry{{ID: "1"}}, target); err != nil {
+			t.Fatal(err)
+		}
+		link := dir + "/journal.json"
+		if err := os.Symlink("data/journal.json", link); err != nil {
+			t.Skipf("cannot create symlinks: %v", err)
+		}
+
+		if err := SaveEntries([]JournalEntry{{ID: "2"}}, link); err != nil {
+			t.Fatalf("Error saving entries: %v", err)
+		}
+
+		info, err := os.Lstat(link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("Expected %s to stay a symlink, got mode %v", link, info.Mode())
+		}
+		entries, err := LoadEntries(target)
+		if err != nil || len(entries) != 1 || entries[0].ID != "2" {
+			t.Errorf("Expected the target to be saved, got %+v, %v", entries, err)
+		}
+	})
+
+	t.Run("When the symlink target does not exist it is created", func(t *testing.T) {
+		dir := t.TempDir()
+		target := dir + "/journal-target.json"
+		link := dir + "/journal.json"
+		if err := os.Symlink(target, link); err != nil {
+			t.Skipf("cannot create symlinks: %v", err)
+		}
+
+		if err := SaveEntries([]JournalEntry{{ID: "1"}}, link); err != nil {
+			t.Fatalf("Error saving entries: %v", err)
+		}
+
+		if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("Expected %s to stay a symlink, got %v, %v", link, info, err)
+		}
+		if _, err := os.Stat(target); err != nil {
+			t.Errorf("Expected the target to be created: %v", err)
+		}
+	})
+}
@@ -6,8 +6,11 @@ import (
 )
 
 type Note struct {
-	Contents string   `json:"Contents"`       // Note contents
-	Tags     []string `json:"Tags,omitempty"` // Tags for this particular note
+	Contents  string         `json:"Contents"`             // Note contents
+	Tags      []string       `json:"Tags,omitempty"`       // Tags for this particular note
+	CreatedAt time.Time      `json:"created_at,omitempty"` // When the note was written, zero for older notes
+	UpdatedAt time.Time      `json:"updated_at,omitempty"` // When the note was last edited, zero if never
+	Revisions []NoteRevision `json:"revisions,omitempty"`  // Earlier versions of the note, oldest first
 }
 
 func (n *Note) String() string {
@@ -54,6 +57,27 @@ func (b *Break) Duration() time.Duration {
 	return total
 }
 
+// Session is one continuous stretch of work within a day, e.g. 08:00-12:00
+// and again 20:00-22:00. The last session of a day is open, with a zero
+// EndTime, while work is ongoing.
+type Session struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// Duration returns the length of the session, or zero while it is open.
+func (s *Session) Duration() time.Duration {
+	if s.EndTime.IsZero() {
+		return 0
+	}
+	return s.EndTime.Sub(s.StartTime)
+}
+
+// IsOpen returns true while the session has not ended.
+func (s *Session) IsOpen() bool {
+	return s.EndTime.IsZero()
+}
+
 type TimeSegment struct {
 	ID          string    `json:"id"`                    // Unique ID within the day
 	StartTime   time.Time `json:"start_time"`            // When tracking started
@@ -99,56 +123,81 @@ func (ts *TimeSegment) String() string {
 		status, client, ts.Project, ts.Task, duration, ts.Description)
 }
 
+// JournalEntry is one day of work. StartTime and EndTime span the whole day:
+// the start of its first session and the end of its last one. The ID is the
+// day in Timezone, the IANA zone the entry was recorded in.
 type JournalEntry struct {
 	ID           string        `json:"id"`
+	Timezone     string        `json:"timezone,omitempty"`   // IANA zone, e.g. "Europe/Berlin"
+	DayType      DayType       `json:"day_type,omitempty"`   // Empty for a work day
+	DayReason    string        `json:"day_reason,omitempty"` // Why the day is off, e.g. "Christmas"
 	StartTime    time.Time     `json:"start_time"`
 	EndTime      time.Time     `json:"end_time"`
+	Sessions     []Session     `json:"sessions,omitempty"` // Work sessions, in order
 	Notes        []Note        `json:"notes,omitempty"`
 	Breaks       []Break       `json:"breaks,omitempty"`
 	TimeSegments []TimeSegment `json:"time_segments,omitempty"` // Time tracking segments
+	Adjustments  []Adjustment  `json:"adjustments,omitempty"`   // Manual overtime balance changes
+	Findings     []Finding     `json:"findings,omitempty"`      // Problems found when the entry was validated
 }
 
 func NewJournalEntry() *JournalEntry {
-	now := time.Now()
+	return NewJournalEntryIn(time.Local)
+}
+
+// NewJournalEntryIn starts an entry now, with its day and times taken in loc.
+func NewJournalEntryIn(loc *time.Location) *JournalEntry {
+	now := time.Now().In(loc)
 	id := now.Format("20060102")
-	return &JournalEntry{ID: id, StartTime: time.Now()}
+	return &JournalEntry{
+		ID:        id,
+		Timezone:  LocationName(loc),
+		StartTime: now,
+		Sessions:  []Session{{StartTime: now}},
+	}
 }
 
 // NewBackfilledEntry builds a complete JournalEntry for a past day from explicit
 // start/end times, breaks, and notes. The date argument supplies the entry ID
 // (YYYYMMDD) and the year/month/day used to anchor every time-of-day value, the
-// same anchoring pattern used by the break add command.
+// same anchoring pattern used by the break add command. A time on a later day
+// than date (see ClockTime.On) keeps its distance in days from date, so a shift
+// may end, and have breaks, after midnight.
 //
 // Structural validation runs in a fixed order and returns a JournalError wrapping
-// ErrValidation on the first failure: start must be before end; each break must be
-// individually valid (ValidateBreak); each break must fall within [start, end]
-// (boundaries inclusive); and no break may overlap a prior one (ValidateBreakOverlap).
-// Each note's ParseContent is invoked so inline hashtags are extracted into Tags.
+// ErrValidation on the first failure: start must fall on date and before end; each
+// break must be individually valid (ValidateBreak); each break must fall within
+// [start, end] (boundaries inclusive); and no break may overlap a prior one
+// (ValidateBreakOverlap). Each note's ParseContent is invoked so inline hashtags
+// are extracted into Tags.
 func NewBackfilledEntry(date, start, end time.Time, breaks []Break, notes []Note) (*JournalEntry, error) {
 	id := date.Format("20060102")
 
 	// Anchor start/end to the entry's calendar day.
-	startTime := time.Date(date.Year(), date.Month(), date.Day(),
-		start.Hour(), start.Minute(), 0, 0, date.Location())
-	endTime := time.Date(date.Year(), date.Month(), date.Day(),
-		end.Hour(), end.Minute(), 0, 0, date.Location())
+	anchor := func(t time.Time) time.Time {
+		return ClockTimeOf(t, date).On(date)
+	}
+	startTime := anchor(start)
+	endTime := anchor(end)
 
-	// 1. start must be strictly before end.
+	// 1. start must be on the entry's day, and strictly before end.
+	if startTime.Format("20060102") != id {
+		return nil, ValidationError("time_range", fmt.Sprintf(
+			"start time %s must fall on %s", FormatClockTime(startTime, date), date.Format("2006-01-02")))
+	}
 	if !startTime.Before(endTime) {
 		return nil, ValidationError("time_range", fmt.Sprintf(
 			"start time %s must be before end time %s",
-			startTime.Format("15:04"), endTime.Format("15:04")))
+			FormatClockTime(startTime, date), FormatClockTime(endTime, date)))
 	}
 
 	// Anchor and validate each break against the day and the accumulated prior breaks.
 	anchoredBreaks := make([]Break, 0, len(breaks))
 	for _, br := range breaks {
 		anchored := Break{
-			StartTime: time.Date(date.Year(), date.Month(), date.Day(),
-				br.StartTime.Hour(), br.StartTime.Minute(), 0, 0, date.Location()),
-			EndTime: time.Date(date.Year(), date.Month(), date.Day(),
-				br.EndTime.Hour(), br.EndTime.Minute(), 0, 0, date.Location()),
-			Reason: br.Reason,
+			StartTime: anchor(br.StartTime),
+			EndTime:   anchor(br.EndTime),
+			Reason:    br.Reason,
 		}
 
 		// 2. Each break must be individually valid (reason non-empty, end after start).
@@ -160,8 +209,8 @@ func NewBackfilledEntry(date, start, end time.Time, breaks []Break, notes []Note
 		if anchored.StartTime.Before(startTime) || anchored.EndTime.After(endTime) {
 			return nil, ValidationError("break", fmt.Sprintf(
 				"break %s-%s falls outside day %s-%s",
-				anchored.StartTime.Format("15:04"), anchored.EndTime.Format("15:04"),
-				startTime.Format("15:04"), endTime.Format("15:04")))
+				FormatClockTime(anchored.StartTime, date), FormatClockTime(anchored.EndTime, date),
+				FormatClockTime(startTime, date), FormatClockTime(endTime, date)))
 		}
 
 		// 4. Each break must not overlap a prior break.
@@ -182,8 +231,10 @@ func NewBackfilledEntry(date, start, end time.Time, breaks []Break, notes []Note
 
 	entry := &JournalEntry{
 		ID:        id,
+		Timezone:  LocationName(date.Location()),
 		StartTime: startTime,
 		EndTime:   endTime,
+		Sessions:  []Session{{StartTime: startTime, EndTime: endTime}},
 	}
 	if len(anchoredBreaks) > 0 {
 		entry.Breaks = anchoredBreaks
@@ -200,23 +251,12 @@ type Journal struct {
 	Entries []JournalEntry `json:"entries"` // journal entries
 }
 
-const SchemaVersion = 1
+const SchemaVersion = 3
 
 func (j *JournalEntry) String() string {
 	start := j.StartTime.Format("15:04:05")
 	end := j.EndTime.Format("15:04:05")
-	var totalBreakTime time.Duration
-	if len(j.Breaks) > 0 {
-		for _, br := range j.Breaks {
-			if br.EndTime.IsZero() {
-				continue
-			}
-			totalBreakTime += br.EndTime.Sub(br.StartTime)
-		}
-	}
-	totalWorkTime := j.EndTime.Sub(j.StartTime)
-	totalWorkTime -= totalBreakTime
-	totalTime := totalWorkTime.String()
+	totalTime := j.TotalWorkTime().String()
 	if j.EndTime.IsZero() {
 		end = "Ongoing"
 		totalTime = "N/A"
@@ -245,27 +285,128 @@ func (j *JournalEntry) AddNote(note Note) error {
 	return nil
 }
 
+// EndDay ends the day now, see SetEndTime.
 func (j *JournalEntry) EndDay() {
-	j.EndTime = time.Now()
+	j.SetEndTime(time.Now())
+}
+
+// WorkSessions returns the sessions of the entry. An entry built without
+// sessions is treated as a single session from StartTime to EndTime, unless it
+// is a day off or holds no work at all (see NewEmptyEntry).
+func (j *JournalEntry) WorkSessions() []Session {
+	if len(j.Sessions) > 0 || j.StartTime.IsZero() || j.IsDayOff() || j.EndTime.Equal(j.StartTime) {
+		return j.Sessions
+	}
+	return []Session{{StartTime: j.StartTime, EndTime: j.EndTime}}
+}
+
+// OpenSession returns the open session of the entry, or nil when every
+// session has ended.
+func (j *JournalEntry) OpenSession() *Session {
+	j.Sessions = j.WorkSessions()
+	if n := len(j.Sessions); n > 0 && j.Sessions[n-1].IsOpen() {
+		return &j.Sessions[n-1]
+	}
+	return nil
+}
+
+// StartSession opens a new session at t. The previous session must have
+// ended before t. The day is ongoing again until the new session ends.
+func (j *JournalEntry) StartSession(t time.Time) error {
+	if open := j.OpenSession(); open != nil {
+		return InvalidEntryError(j.ID, fmt.Sprintf("a session is already open since %s", open.StartTime.Format("15:04")))
+	}
+	if n := len(j.Sessions); n > 0 && !t.After(j.Sessions[n-1].EndTime) {
+		return InvalidEntryError(j.ID, fmt.Sprintf("a new session must start after the previous one ended at %s",
+			FormatClockTime(j.Sessions[n-1].EndTime, j.StartTime)))
+	}
+
+	if len(j.Sessions) == 0 {
+		j.StartTime = t
+	}
+	j.Sessions = append(j.Sessions, Session{StartTime: t})
+	j.EndTime = time.Time{}
+	return nil
 }
 
+// SetStartTime moves the start of the day, and of its first session, to t.
+func (j *JournalEntry) SetStartTime(t time.Time) {
+	j.Sessions = j.WorkSessions()
+	if len(j.Sessions) == 0 {
+		j.Sessions = []Session{{}}
+	}
+	j.Sessions[0].StartTime = t
+	j.StartTime = t
+}
+
+// SetEndTime ends the open session at t or, when every session has already
+// ended, moves the end of the last session to t. Either way the day ends at t.
+func (j *JournalEntry) SetEndTime(t time.Time) {
+	j.Sessions = j.WorkSessions()
+	if n := len(j.Sessions); n > 0 {
+		j.Sessions[n-1].EndTime = t
+	}
+	j.EndTime = t
+}
+
+// TotalWorkTime returns the time worked in the entry's ended sessions, breaks
+// excluded.
 func (j *JournalEntry) TotalWorkTime() time.Duration {
 	var totalWorkTime time.Duration
-	if j.EndTime.IsZero() {
-		return totalWorkTime
+	for _, session := range j.WorkSessions() {
+		totalWorkTime += j.sessionWorkTime(session, session.StartTime, session.EndTime)
 	}
-	totalWorkTime = j.EndTime.Sub(j.StartTime)
+	return totalWorkTime
+}
 
-	var totalBreakTime time.Duration
+// WorkTimeBetween returns the part of the entry's work time, breaks excluded,
+// that falls between from and to. It splits a shift that runs past midnight
+// between the days it was worked on. Sessions that are still open do not
+// count yet.
+func (j *JournalEntry) WorkTimeBetween(from, to time.Time) time.Duration {
+	var workTime time.Duration
+	for _, session := range j.WorkSessions() {
+		workTime += j.sessionWorkTime(session, from, to)
+	}
+	return workTime
+}
+
+// sessionWorkTime returns the time worked in an ended session between from
+// and to, less the completed breaks taken during it.
+func (j *JournalEntry) sessionWorkTime(session Session, from, to time.Time) time.Duration {
+	if session.IsOpen() {
+		return 0
+	}
+
+	workTime := overlap(session.StartTime, session.EndTime, from, to)
 	for _, br := range j.Breaks {
-		if !br.EndTime.IsZero() {
-			totalBreakTime += br.Duration()
+		if br.EndTime.IsZero() {
+			continue
+		}
+		start, end := br.StartTime, br.EndTime
+		if start.Before(session.StartTime) {
+			start = session.StartTime
 		}
+		if end.After(session.EndTime) {
+			end = session.EndTime
+		}
+		workTime -= overlap(start, end, from, to)
 	}
+	return workTime
+}
 
-	totalWorkTime -= totalBreakTime
-
-	return totalWorkTime
+// overlap returns how long the interval [start, end) overlaps [from, to).
+func overlap(start, end, from, to time.Time) time.Duration {
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
 }
 
 // AddTimeSegment adds a new time segment to the journal entry
@@ -297,12 +438,21 @@ func (j *JournalEntry) GetActiveTimeSegments() []TimeSegment {
 
 // StopTimeSegment stops a time segment by ID
 func (j *JournalEntry) StopTimeSegment(segmentID string) error {
+	return j.StopTimeSegmentAt(segmentID, time.Now())
+}
+
+// StopTimeSegmentAt stops a time segment by ID using the given end time.
+// The end time must not be before the segment's start time.
+func (j *JournalEntry) StopTimeSegmentAt(segmentID string, endTime time.Time) error {
 	for i := range j.TimeSegments {
 		if j.TimeSegments[i].ID == segmentID {
 			if !j.TimeSegments[i].IsActive() {
 				return fmt.Errorf("time segment %s is already stopped", segmentID)
 			}
-			j.TimeSegments[i].EndTime = time.Now()
+			if endTime.Before(j.TimeSegments[i].StartTime) {
+				return ValidationError("end_time", fmt.Sprintf("time segment %s cannot end before it started", segmentID))
+			}
+			j.TimeSegments[i].EndTime = endTime
 			return nil
 		}
 	}
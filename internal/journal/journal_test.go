@@ -409,4 +409,163 @@ func TestNewBackfilledEntry(t *testing.T) {
 			t.Errorf("Expected ErrValidation, got %v", err)
 		}
 	})
+
+	t.Run("overnight shift keeps times after midnight on the next day", func(t *testing.T) {
+		nextDay := func(h, m int) time.Time {
+			return time.Date(2024, 5, 28, h, m, 0, 0, time.UTC)
+		}
+		breaks := []Break{{StartTime: nextDay(2, 0), EndTime: nextDay(2, 30), Reason: "meal"}}
+		entry, err := NewBackfilledEntry(anchor, at(22, 0), nextDay(6, 0), breaks, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if entry.ID != "20240527" {
+			t.Errorf("Expected ID %q, got %q", "20240527", entry.ID)
+		}
+		if !entry.EndTime.Equal(nextDay(6, 0)) {
+			t.Errorf("Expected EndTime %v, got %v", nextDay(6, 0), entry.EndTime)
+		}
+		if !entry.Breaks[0].StartTime.Equal(nextDay(2, 0)) {
+			t.Errorf("Expected break start %v, got %v", nextDay(2, 0), entry.Breaks[0].StartTime)
+		}
+		if got := entry.TotalWorkTime(); got != 7*time.Hour+30*time.Minute {
+			t.Errorf("Expected 7h30m of work, got %v", got)
+		}
+	})
+
+	t.Run("start on a later day than the entry returns ErrValidation", func(t *testing.T) {
+		start := time.Date(2024, 5, 28, 1, 0, 0, 0, time.UTC)
+		_, err := NewBackfilledEntry(anchor, start, start.Add(time.Hour), nil, nil)
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("Expected ErrValidation, got %v", err)
+		}
+	})
+}
+
+func TestWorkTimeBetween(t *testing.T) {
+	// A night shift from 22:00 to 06:00 with a 30 minute break after midnight
+	entry := JournalEntry{
+		ID:        "20260331",
+		StartTime: time.Date(2026, 3, 31, 22, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 4, 1, 6, 0, 0, 0, time.UTC),
+		Breaks: []Break{{
+			StartTime: time.Date(2026, 4, 1, 2, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2026, 4, 1, 2, 30, 0, 0, time.UTC),
+			Reason:    "meal",
+		}},
+	}
+	midnight := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     time.Duration
+	}{
+		{name: "day the shift started", from: midnight(3, 31), to: midnight(4, 1), want: 2 * time.Hour},
+		{name: "day the shift ended", from: midnight(4, 1), to: midnight(4, 2), want: 5*time.Hour + 30*time.Minute},
+		{name: "whole shift", from: midnight(3, 31), to: midnight(4, 2), want: entry.TotalWorkTime()},
+		{name: "unrelated day", from: midnight(3, 20), to: midnight(3, 21), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := entry.WorkTimeBetween(tt.from, tt.to); got != tt.want {
+				t.Errorf("WorkTimeBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	ongoing := JournalEntry{StartTime: entry.StartTime}
+	if got := ongoing.WorkTimeBetween(midnight(3, 31), midnight(4, 2)); got != 0 {
+		t.Errorf("Expected no work time for an ongoing entry, got %v", got)
+	}
+}
+
+func TestStartSession(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2026, 4, 6, hour, 0, 0, 0, time.UTC)
+	}
+
+	entry := JournalEntry{ID: "20260406", StartTime: at(8), Sessions: []Session{{StartTime: at(8)}}}
+	if err := entry.StartSession(at(9)); err == nil {
+		t.Error("Expected an error when starting a session while one is open")
+	}
+
+	entry.SetEndTime(at(12))
+	if err := entry.StartSession(at(11)); err == nil {
+		t.Error("Expected an error when a session starts before the previous one ended")
+	}
+	if err := entry.StartSession(at(20)); err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	if !entry.EndTime.IsZero() || entry.OpenSession() == nil {
+		t.Errorf("Expected the day to be ongoing again, got %+v", entry)
+	}
+	if entry.StartTime != at(8) {
+		t.Errorf("StartTime = %v, want the start of the first session", entry.StartTime)
+	}
+
+	entry.SetEndTime(at(22))
+	if got, want := entry.TotalWorkTime(), 6*time.Hour; got != want {
+		t.Errorf("TotalWorkTime() = %v, want %v", got, want)
+	}
+	if entry.EndTime != at(22) || len(entry.Sessions) != 2 {
+		t.Errorf("Expected two sessions ending at 22:00, got %+v", entry)
+	}
+}
+
+func TestTotalWorkTimeWithSessions(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 4, 6, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		entry JournalEntry
+		want  time.Duration
+	}{
+		{
+			name:  "entry without sessions spans start to end",
+			entry: JournalEntry{StartTime: at(9, 0), EndTime: at(17, 0)},
+			want:  8 * time.Hour,
+		},
+		{
+			name: "breaks are only taken off the session they fall in",
+			entry: JournalEntry{
+				StartTime: at(8, 0),
+				EndTime:   at(22, 0),
+				Sessions:  []Session{{StartTime: at(8, 0), EndTime: at(12, 0)}, {StartTime: at(20, 0), EndTime: at(22, 0)}},
+				Breaks:    []Break{{StartTime: at(10, 0), EndTime: at(10, 30), Reason: "coffee"}},
+			},
+			want: 5*time.Hour + 30*time.Minute,
+		},
+		{
+			name: "the gap between sessions is not a break",
+			entry: JournalEntry{
+				StartTime: at(8, 0),
+				EndTime:   at(22, 0),
+				Sessions:  []Session{{StartTime: at(8, 0), EndTime: at(12, 0)}, {StartTime: at(20, 0), EndTime: at(22, 0)}},
+				Breaks:    []Break{{StartTime: at(12, 0), EndTime: at(20, 0), Reason: "afternoon off"}},
+			},
+			want: 6 * time.Hour,
+		},
+		{
+			name: "an open session does not count yet",
+			entry: JournalEntry{
+				StartTime: at(8, 0),
+				Sessions:  []Session{{StartTime: at(8, 0), EndTime: at(12, 0)}, {StartTime: at(20, 0)}},
+			},
+			want: 4 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.TotalWorkTime(); got != tt.want {
+				t.Errorf("TotalWorkTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
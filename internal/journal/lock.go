@@ -0,0 +1,130 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLockTimeout is how long LockJournal waits for another process to
+// release the journal before giving up.
+const DefaultLockTimeout = 5 * time.Second
+
+// lockRetryInterval is the pause between attempts to take a busy lock.
+const lockRetryInterval = 50 * time.Millisecond
+
+// FileLock is an advisory, exclusive lock on a journal file.
+//
+// The lock is taken on a sidecar file (the journal path with a ".lock" suffix)
+// rather than on the journal itself, because SaveEntries replaces the journal
+// file on every write and a lock held on the old file would not protect the
+// new one.
+type FileLock struct {
+	file     *os.File
+	filename string
+}
+
+// heldLocks counts the journal locks this process holds, by journal path, so
+// code run inside WithLock can tell that the journal is already locked.
+var heldLocks = struct {
+	sync.Mutex
+	paths map[string]int
+}{paths: make(map[string]int)}
+
+// lockHeld reports whether this process holds the lock of the journal at
+// filename, or of a directory journal filename is part of, such as the
+// directory of a MonthlyStore.
+func lockHeld(filename string) bool {
+	heldLocks.Lock()
+	defer heldLocks.Unlock()
+	for path, count := range heldLocks.paths {
+		if count == 0 {
+			continue
+		}
+		if filename == path || strings.HasPrefix(filename, path+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// LockFilename returns the path of the sidecar file used to lock filename.
+func LockFilename(filename string) string {
+	return filename + ".lock"
+}
+
+// LockJournal takes the exclusive lock for the journal at filename, waiting up
+// to timeout for other holders to release it. It returns a JournalError
+// wrapping ErrJournalLocked when the lock is still busy after timeout.
+//
+// Callers must call Unlock once they are done with the read-modify-write
+// cycle the lock protects.
+func LockJournal(filename string, timeout time.Duration) (*FileLock, error) {
+	lockPath := LockFilename(filename)
+	file, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, JournalIOError("open lock file for", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLockFile(file)
+		if err != nil {
+			file.Close()
+			return nil, JournalIOError("lock", err)
+		}
+		if locked {
+			heldLocks.Lock()
+			heldLocks.paths[filename]++
+			heldLocks.Unlock()
+			return &FileLock{file: file, filename: filename}, nil
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, JournalLockedError(lockPath, timeout)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// Unlock releases the lock. The sidecar file is intentionally left in place:
+// removing it would let two processes lock different files at the same path.
+func (l *FileLock) Unlock() error {
+	heldLocks.Lock()
+	if heldLocks.paths[l.filename]--; heldLocks.paths[l.filename] <= 0 {
+		delete(heldLocks.paths, l.filename)
+	}
+	heldLocks.Unlock()
+
+	if err := unlockFile(l.file); err != nil {
+		l.file.Close()
+		return JournalIOError("unlock", err)
+	}
+	return l.file.Close()
+}
+
+// WithLock runs fn while holding the lock for the journal at filename.
+// Loading, changing and saving the journal inside fn is safe against other
+// processes doing the same.
+//
+// Example:
+//
+//	err := WithLock("journal.json", DefaultLockTimeout, func() error {
+//	    entries, err := LoadEntries("journal.json")
+//	    if err != nil {
+//	        return err
+//	    }
+//	    // ... change entries ...
+//	    return SaveEntries(entries, "journal.json")
+//	})
+func WithLock(filename string, timeout time.Duration, fn func() error) error {
+	lock, err := LockJournal(filename, timeout)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	return fn()
+}
@@ -0,0 +1,105 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockJournal(t *testing.T) {
+	t.Run("When the lock is held a second locker times out with ErrJournalLocked", func(t *testing.T) {
+		filename := t.TempDir() + "/journal.json"
+
+		held, err := LockJournal(filename, time.Second)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		defer held.Unlock()
+
+		start := time.Now()
+		_, err = LockJournal(filename, 100*time.Millisecond)
+		if !errors.Is(err, ErrJournalLocked) {
+			t.Fatalf("Expected ErrJournalLocked, got %v", err)
+		}
+		if waited := time.Since(start); waited < 100*time.Millisecond {
+			t.Errorf("Expected to wait for the timeout, gave up after %v", waited)
+		}
+	})
+
+	t.Run("When the lock is released a waiting locker acquires it", func(t *testing.T) {
+		filename := t.TempDir() + "/journal.json"
+
+		held, err := LockJournal(filename, time.Second)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			held.Unlock()
+		}()
+
+		lock, err := LockJournal(filename, 2*time.Second)
+		if err != nil {
+			t.Fatalf("Expected the lock after release, got %v", err)
+		}
+		if err := lock.Unlock(); err != nil {
+			t.Errorf("Expected no error on unlock, got %v", err)
+		}
+	})
+}
+
+func TestWithLockConcurrentWriters(t *testing.T) {
+	filename := t.TempDir() + "/journal.json"
+	if err := SaveEntries([]JournalEntry{{ID: "20240527", StartTime: time.Now()}}, filename); err != nil {
+		t.Fatal(err)
+	}
+
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- WithLock(filename, 10*time.Second, func() error {
+				entries, err := LoadEntries(filename)
+				if err != nil {
+					return err
+				}
+				// Half the writers add a note to the shared entry, the other
+				// half append an entry of their own.
+				if i%2 == 0 {
+					entries[0].Notes = append(entries[0].Notes, Note{Contents: fmt.Sprintf("note %d", i)})
+				} else {
+					entries = append(entries, JournalEntry{ID: fmt.Sprintf("writer-%d", i), StartTime: time.Now()})
+				}
+				// Widen the window between read and write so unsynchronised
+				// writers would clobber each other.
+				time.Sleep(5 * time.Millisecond)
+				return SaveEntries(entries, filename)
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Expected no error from writers, got %v", err)
+		}
+	}
+
+	entries, err := LoadEntries(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1+writers/2 {
+		t.Errorf("Expected %d entries, got %d", 1+writers/2, len(entries))
+	}
+	if len(entries[0].Notes) != writers/2 {
+		t.Errorf("Expected %d notes, got %d", writers/2, len(entries[0].Notes))
+	}
+}
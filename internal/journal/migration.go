@@ -0,0 +1,428 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Migration upgrades a raw journal document from schema version From to
+// From+1.
+//
+// Migrations work on the decoded JSON document instead of on JournalEntry, so
+// each one only depends on the format it reads and keeps working as the Go
+// types change. Every migration receives the document as an object with a
+// "version" and an "entries" key; a version 0 journal, which is a bare list of
+// entries, is wrapped before the first migration runs.
+type Migration struct {
+	// From is the schema version the migration reads.
+	From int
+
+	// Description summarises the migration for dry runs.
+	Description string
+
+	// Apply rewrites doc in place and describes each change it made. The
+	// version key is updated by the caller.
+	Apply func(doc map[string]interface{}) ([]string, error)
+}
+
+// migrations is the ordered registry of schema migrations: migrations[i]
+// upgrades version i to version i+1. Bumping SchemaVersion means appending the
+// migration from the previous version, along with golden files for it under
+// testdata/migrations.
+var migrations = []Migration{
+	{
+		From:        0,
+		Description: "wrap the entry list in a versioned journal, turn plain-text notes into note objects and give every entry a break list",
+		Apply:       migrateV0ToV1,
+	},
+	{
+		From:        1,
+		Description: "record the start and end of every day as its first work session",
+		Apply:       migrateV1ToV2,
+	},
+	{
+		From:        2,
+		Description: "move the \"Validation Error\" notes of every entry into its findings",
+		Apply:       migrateV2ToV3,
+	},
+}
+
+// MigrationStep describes one migration applied to a journal.
+type MigrationStep struct {
+	From        int
+	To          int
+	Description string
+	Changes     []string
+}
+
+// MigrationReport describes how a journal was, or would be, brought up to the
+// current schema version.
+type MigrationReport struct {
+	From   int             // schema version the journal was read at
+	To     int             // schema version after migrating
+	Steps  []MigrationStep // migrations applied, in order
+	Backup string          // copy of the original journal, if one was written
+}
+
+// Migrated reports whether any migration had to run.
+func (r *MigrationReport) Migrated() bool {
+	return len(r.Steps) > 0
+}
+
+// MigrationBackupFilename returns where the copy of filename is kept before
+// it is migrated away from the given schema version.
+func MigrationBackupFilename(filename string, version int) string {
+	return fmt.Sprintf("%s.v%d%s", filename, version, backupSuffix)
+}
+
+// MigrateJournalData brings the raw journal in data up to SchemaVersion and
+// returns the migrated JSON. When the journal is already current, data is
+// returned unchanged. A journal written by a newer version of workday is an
+// error.
+func MigrateJournalData(data []byte) ([]byte, *MigrationReport, error) {
+	doc, version, err := decodeJournalDocument(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &MigrationReport{From: version, To: version}
+	if version == SchemaVersion {
+		return data, report, nil
+	}
+
+	report.Steps, err = runMigrations(doc, version, SchemaVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	report.To = SchemaVersion
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, JournalIOError("marshal migrated", err)
+	}
+	return migrated, report, nil
+}
+
+// MigrateFile brings the journal file at filename up to SchemaVersion. The
+// original contents are copied to MigrationBackupFilename before the file is
+// rewritten. With dryRun set nothing is written, and the report only
+// describes what would change.
+func MigrateFile(filename string, dryRun bool) (*MigrationReport, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, JournalIOError("read", err)
+	}
+	migrated, report, err := MigrateJournalData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var journalData Journal
+	if err := json.Unmarshal(migrated, &journalData); err != nil {
+		return nil, JournalIOError("decode", err)
+	}
+	if !report.Migrated() || dryRun {
+		return report, nil
+	}
+
+	report.Backup = MigrationBackupFilename(filename, report.From)
+	if err := writeMigrationBackup(report.Backup, data); err != nil {
+		return nil, err
+	}
+	if err := SaveEntries(journalData.Entries, filename); err != nil {
+		return nil, JournalIOError("save migrated data", err)
+	}
+	return report, nil
+}
+
+// OnMigrated, when set, is called after a journal at an older schema version
+// was migrated as it was loaded or opened, so the user can be told.
+var OnMigrated func(location string, report *MigrationReport)
+
+// withMigrationLock runs migrate while holding the lock of the journal at
+// lockPath. When this process already holds it, as inside WithLock, migrate
+// runs right away.
+func withMigrationLock(lockPath string, migrate func() error) error {
+	if lockHeld(lockPath) {
+		return migrate()
+	}
+	return WithLock(lockPath, DefaultLockTimeout, migrate)
+}
+
+// writeMigrationBackup writes the pre-migration contents of a journal to
+// path. An older backup at the same path is replaced.
+func writeMigrationBackup(path string, data []byte) error {
+	return writeFileAtomic(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// decodeJournalDocument decodes a raw journal and returns it as an object
+// together with its schema version. Numbers are kept as json.Number so that
+// migrating does not change their precision.
+func decodeJournalDocument(data []byte) (map[string]interface{}, int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var raw interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, 0, JournalIOError("decode", err)
+	}
+
+	switch doc := raw.(type) {
+	case []interface{}:
+		// Before versioning, the journal was a bare list of entries
+		return map[string]interface{}{"version": json.Number("0"), "entries": doc}, 0, nil
+	case map[string]interface{}:
+		version := 0
+		if v, ok := doc["version"]; ok {
+			n, ok := v.(json.Number)
+			if !ok {
+				return nil, 0, MigrationError(0, "the version is not a number", nil)
+			}
+			parsed, err := n.Int64()
+			if err != nil || parsed < 0 {
+				return nil, 0, MigrationError(0, fmt.Sprintf("invalid version %s", n), err)
+			}
+			version = int(parsed)
+		}
+		if version > SchemaVersion {
+			return nil, 0, MigrationError(version, fmt.Sprintf("the journal was written by a newer version of workday (this one supports up to %d)", SchemaVersion), nil)
+		}
+		return doc, version, nil
+	default:
+		return nil, 0, MigrationError(0, "the journal is neither a list of entries nor an object", nil)
+	}
+}
+
+// runMigrations applies the registered migrations that take doc from version
+// from to version to.
+func runMigrations(doc map[string]interface{}, from, to int) ([]MigrationStep, error) {
+	if to > len(migrations) {
+		return nil, MigrationError(from, fmt.Sprintf("no migration registered to version %d", to), nil)
+	}
+
+	var steps []MigrationStep
+	for _, m := range migrations[from:to] {
+		changes, err := m.Apply(doc)
+		if err != nil {
+			return nil, MigrationError(m.From, m.Description, err)
+		}
+		doc["version"] = m.From + 1
+		steps = append(steps, MigrationStep{
+			From:        m.From,
+			To:          m.From + 1,
+			Description: m.Description,
+			Changes:     changes,
+		})
+	}
+	return steps, nil
+}
+
+// documentEntries returns the entry objects of a journal document.
+func documentEntries(doc map[string]interface{}) ([]map[string]interface{}, error) {
+	raw, ok := doc["entries"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("entries is not a list")
+	}
+
+	entries := make([]map[string]interface{}, len(list))
+	for i, item := range list {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("entry %d is not an object", i)
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+// legacyEntryKeys maps the field names of the earliest journals, written
+// without JSON tags, to the names used since.
+var legacyEntryKeys = map[string]string{
+	"ID":        "id",
+	"StartTime": "start_time",
+	"EndTime":   "end_time",
+	"Notes":     "notes",
+}
+
+// migrateV0ToV1 handles both unversioned formats: the first journals, whose
+// fields were untagged and whose notes were plain strings, and the later bare
+// lists of entries, which lacked break lists.
+func migrateV0ToV1(doc map[string]interface{}) ([]string, error) {
+	entries, err := documentEntries(doc)
+	if err != nil {
+		return nil, err
+	}
+	if doc["entries"] == nil {
+		doc["entries"] = []interface{}{}
+	}
+
+	changes := []string{fmt.Sprintf("wrap %d entries in a versioned journal", len(entries))}
+	var withBreaks int
+	for _, entry := range entries {
+		renamed := 0
+		for legacy, current := range legacyEntryKeys {
+			if value, ok := entry[legacy]; ok {
+				if _, exists := entry[current]; !exists {
+					entry[current] = value
+				}
+				delete(entry, legacy)
+				renamed++
+			}
+		}
+
+		id, _ := entry["id"].(string)
+		if renamed > 0 {
+			changes = append(changes, fmt.Sprintf("entry %s: rename %d legacy fields", id, renamed))
+		}
+
+		if notes, ok := entry["notes"].([]interface{}); ok {
+			converted := 0
+			for i, note := range notes {
+				if text, ok := note.(string); ok {
+					notes[i] = map[string]interface{}{"Contents": text}
+					converted++
+				}
+			}
+			if converted > 0 {
+				changes = append(changes, fmt.Sprintf("entry %s: turn %d plain-text notes into note objects", id, converted))
+			}
+		}
+
+		if entry["breaks"] == nil {
+			entry["breaks"] = []interface{}{}
+			withBreaks++
+		}
+	}
+	if withBreaks > 0 {
+		changes = append(changes, fmt.Sprintf("add an empty break list to %d entries", withBreaks))
+	}
+	return changes, nil
+}
+
+// zeroTimeJSON is how encoding/json writes a zero time.Time.
+const zeroTimeJSON = "0001-01-01T00:00:00Z"
+
+// migrateV1ToV2 gives every entry a session list. Until version 2 a day was a
+// single stretch of work, so its start and end become its only session.
+func migrateV1ToV2(doc map[string]interface{}) ([]string, error) {
+	entries, err := documentEntries(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	for _, entry := range entries {
+		if entry["sessions"] != nil {
+			continue
+		}
+		start, ok := entry["start_time"]
+		if !ok {
+			continue
+		}
+		end, ok := entry["end_time"]
+		if !ok {
+			end = zeroTimeJSON
+		}
+		entry["sessions"] = []interface{}{
+			map[string]interface{}{"start_time": start, "end_time": end},
+		}
+
+		id, _ := entry["id"].(string)
+		changes = append(changes, fmt.Sprintf("entry %s: add a session for the whole day", id))
+	}
+	return changes, nil
+}
+
+// legacyFindingPrefix starts the notes that recorded failed validations
+// before findings existed.
+const legacyFindingPrefix = "Validation Error: "
+
+// legacyFindingCode guesses the code of a finding from the message of a
+// legacy "Validation Error" note.
+func legacyFindingCode(message string) (code string, severity Severity, text string) {
+	// Rule violations were written as "[severity] rule: message"
+	if rest, ok := strings.CutPrefix(message, "["); ok {
+		if sev, afterSev, ok := strings.Cut(rest, "] "); ok {
+			if rule, text, ok := strings.Cut(afterSev, ": "); ok {
+				if parsed, err := ParseSeverity(sev); err == nil {
+					return rule, parsed, text
+				}
+			}
+		}
+	}
+
+	switch {
+	case strings.Contains(message, "less than the minimum"):
+		return "min_work_time", SeverityWarning, message
+	case strings.Contains(message, "exceeds the maximum"):
+		return "max_work_time", SeverityWarning, message
+	case strings.Contains(message, "lunchtime break"):
+		return "lunch_break", SeverityWarning, message
+	default:
+		return "validation", SeverityWarning, message
+	}
+}
+
+// migrateV2ToV3 turns the notes that workday end and backfill added for failed
+// validations into findings. The notes carry no time, so the end of the day
+// stands in for when the problem was found.
+func migrateV2ToV3(doc map[string]interface{}) ([]string, error) {
+	entries, err := documentEntries(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	for _, entry := range entries {
+		notes, ok := entry["notes"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		var kept, findings []interface{}
+		for _, note := range notes {
+			fields, _ := note.(map[string]interface{})
+			contents, _ := fields["Contents"].(string)
+			message, ok := strings.CutPrefix(contents, legacyFindingPrefix)
+			if !ok {
+				kept = append(kept, note)
+				continue
+			}
+			code, severity, text := legacyFindingCode(message)
+			createdAt, ok := entry["end_time"]
+			if !ok {
+				createdAt = zeroTimeJSON
+			}
+			findings = append(findings, map[string]interface{}{
+				"code":       code,
+				"severity":   string(severity),
+				"message":    text,
+				"created_at": createdAt,
+			})
+		}
+		if len(findings) == 0 {
+			continue
+		}
+
+		if kept == nil {
+			kept = []interface{}{}
+		}
+		entry["notes"] = kept
+		entry["findings"] = findings
+
+		id, _ := entry["id"].(string)
+		changes = append(changes, fmt.Sprintf("entry %s: move %d validation notes into findings", id, len(findings)))
+	}
+	return changes, nil
+}
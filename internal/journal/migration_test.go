@@ -0,0 +1,264 @@
+package journal
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the migration golden files")
+
+// TestMigrationGoldenFiles runs every registered migration on its own over the
+// inputs in testdata/migrations/v<From> and compares the result with the
+// matching .golden file. Run with -update to regenerate the golden files.
+func TestMigrationGoldenFiles(t *testing.T) {
+	if len(migrations) != SchemaVersion {
+		t.Fatalf("%d migrations registered for schema version %d", len(migrations), SchemaVersion)
+	}
+
+	for i, m := range migrations {
+		if m.From != i {
+			t.Fatalf("migration %d reads version %d, registry is out of order", i, m.From)
+		}
+
+		dir := filepath.Join("testdata", "migrations", fmt.Sprintf("v%d", m.From))
+		inputs, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(inputs) == 0 {
+			t.Errorf("no golden inputs in %s for the v%d migration", dir, m.From)
+		}
+
+		for _, input := range inputs {
+			t.Run(strings.TrimPrefix(input, filepath.Join("testdata", "migrations")+string(filepath.Separator)), func(t *testing.T) {
+				data, err := os.ReadFile(input)
+				if err != nil {
+					t.Fatal(err)
+				}
+				doc, version, err := decodeJournalDocument(data)
+				if err != nil {
+					t.Fatalf("decodeJournalDocument() error = %v", err)
+				}
+				if version != m.From {
+					t.Fatalf("input is at version %d, want %d", version, m.From)
+				}
+
+				if _, err := runMigrations(doc, m.From, m.From+1); err != nil {
+					t.Fatalf("migration error = %v", err)
+				}
+				got, err := json.MarshalIndent(doc, "", "  ")
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, '\n')
+
+				golden := strings.TrimSuffix(input, ".json") + ".golden"
+				if *updateGolden {
+					if err := os.WriteFile(golden, got, 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("reading golden file: %v (run with -update to create it)", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("migrated %s does not match %s:\n%s", input, golden, got)
+				}
+			})
+		}
+	}
+}
+
+func TestMigrateJournalData(t *testing.T) {
+	t.Run("A current journal is returned unchanged", func(t *testing.T) {
+		data := []byte(fmt.Sprintf(`{"version":%d,"entries":[]}`, SchemaVersion))
+		migrated, report, err := MigrateJournalData(data)
+		if err != nil {
+			t.Fatalf("MigrateJournalData() error = %v", err)
+		}
+		if report.Migrated() || !bytes.Equal(migrated, data) {
+			t.Errorf("expected no migration, got report %+v and %s", report, migrated)
+		}
+	})
+
+	t.Run("A bare entry list is migrated to the current version", func(t *testing.T) {
+		migrated, report, err := MigrateJournalData([]byte(`[{"ID":"20231002","Notes":["first"]}]`))
+		if err != nil {
+			t.Fatalf("MigrateJournalData() error = %v", err)
+		}
+		if report.From != 0 || report.To != SchemaVersion || len(report.Steps) != SchemaVersion {
+			t.Errorf("unexpected report %+v", report)
+		}
+
+		var journalData Journal
+		if err := json.Unmarshal(migrated, &journalData); err != nil {
+			t.Fatal(err)
+		}
+		if journalData.Version != SchemaVersion || journalData.Entries[0].Notes[0].Contents != "first" {
+			t.Errorf("unexpected migrated journal %+v", journalData)
+		}
+	})
+
+	t.Run("A journal from a newer version is rejected", func(t *testing.T) {
+		_, _, err := MigrateJournalData([]byte(fmt.Sprintf(`{"version":%d,"entries":[]}`, SchemaVersion+1)))
+		if !errors.Is(err, ErrMigration) {
+			t.Errorf("expected ErrMigration, got %v", err)
+		}
+	})
+}
+
+func TestMigrateFile(t *testing.T) {
+	legacy := []byte(`[{"ID":"20231002","StartTime":"2023-10-02T09:00:00Z","EndTime":"2023-10-02T17:00:00Z","Notes":["Reviewed PRs"]}]`)
+	filename := filepath.Join(t.TempDir(), "journal.json")
+	if err := os.WriteFile(filename, legacy, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := MigrateFile(filename, true)
+	if err != nil {
+		t.Fatalf("MigrateFile() dry run error = %v", err)
+	}
+	if !report.Migrated() || report.Backup != "" {
+		t.Errorf("unexpected dry run report %+v", report)
+	}
+	if data, _ := os.ReadFile(filename); !bytes.Equal(data, legacy) {
+		t.Error("dry run modified the journal")
+	}
+
+	report, err = MigrateFile(filename, false)
+	if err != nil {
+		t.Fatalf("MigrateFile() error = %v", err)
+	}
+	if report.Backup != MigrationBackupFilename(filename, 0) {
+		t.Errorf("backup = %q, want %q", report.Backup, MigrationBackupFilename(filename, 0))
+	}
+	if data, _ := os.ReadFile(report.Backup); !bytes.Equal(data, legacy) {
+		t.Error("backup does not hold the original journal")
+	}
+
+	entries, err := LoadEntries(filename)
+	if err != nil {
+		t.Fatalf("LoadEntries() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Notes[0].Contents != "Reviewed PRs" {
+		t.Errorf("unexpected entries after migration: %+v", entries)
+	}
+
+	report, err = MigrateFile(filename, false)
+	if err != nil || report.Migrated() {
+		t.Errorf("expected a migrated journal to stay as is, got %+v, %v", report, err)
+	}
+}
+
+func TestLoadEntriesMigratesLegacyJournal(t *testing.T) {
+	legacy := []byte(`[{"ID":"20231002","StartTime":"2023-10-02T09:00:00Z","EndTime":"2023-10-02T17:00:00Z","Notes":["one","two"]}]`)
+
+	for _, locked := range []bool{false, true} {
+		t.Run(fmt.Sprintf("locked=%v", locked), func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "journal.json")
+			if err := os.WriteFile(filename, legacy, 0644); err != nil {
+				t.Fatal(err)
+			}
+			var reported *MigrationReport
+			OnMigrated = func(location string, report *MigrationReport) { reported = report }
+			defer func() { OnMigrated = nil }()
+
+			// Inside WithLock the lock this process holds is used
+			var entries []JournalEntry
+			load := func() error {
+				var err error
+				entries, err = LoadEntries(filename)
+				return err
+			}
+			var err error
+			if locked {
+				err = WithLock(filename, time.Second, load)
+			} else {
+				err = load()
+			}
+			if err != nil {
+				t.Fatalf("LoadEntries() error = %v", err)
+			}
+
+			if len(entries) != 1 || len(entries[0].Notes) != 2 || entries[0].Notes[1].Contents != "two" {
+				t.Errorf("unexpected entries: %+v", entries)
+			}
+			if reported == nil || reported.From != 0 || reported.Backup != MigrationBackupFilename(filename, 0) {
+				t.Errorf("unexpected migration report %+v", reported)
+			}
+			backup, err := os.ReadFile(MigrationBackupFilename(filename, 0))
+			if err != nil || !bytes.Equal(backup, legacy) {
+				t.Errorf("expected the original journal as backup, got %s, %v", backup, err)
+			}
+
+			data, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var journalData Journal
+			if err := json.Unmarshal(data, &journalData); err != nil || journalData.Version != SchemaVersion {
+				t.Errorf("expected the journal to be saved at version %d, got %s", SchemaVersion, data)
+			}
+		})
+	}
+}
+
+func TestSQLiteStoreMigratesOldDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.sqlite")
+	store, err := OpenSQLiteStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	// Rewind the database to version 0 with a row in the legacy format
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`UPDATE meta SET value = '0' WHERE key = 'schema_version';
+		INSERT INTO entries (id, data) VALUES ('20231002', '{"ID":"20231002","Notes":["legacy"]}')`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := MigrateSQLite(path, true)
+	if err != nil {
+		t.Fatalf("MigrateSQLite() dry run error = %v", err)
+	}
+	if report.From != 0 || report.To != SchemaVersion || !report.Migrated() || report.Backup != "" {
+		t.Errorf("unexpected dry run report %+v", report)
+	}
+	if _, err := os.Stat(MigrationBackupFilename(path, 0)); !os.IsNotExist(err) {
+		t.Errorf("expected no backup from a dry run, got %v", err)
+	}
+
+	// Opening the database migrates it
+	store, err = OpenSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("OpenSQLiteStore() error = %v", err)
+	}
+	defer store.Close()
+
+	entry, err := store.Get("20231002")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(entry.Notes) != 1 || entry.Notes[0].Contents != "legacy" {
+		t.Errorf("unexpected migrated entry %+v", entry)
+	}
+	if _, err := os.Stat(MigrationBackupFilename(path, 0)); err != nil {
+		t.Errorf("expected a backup before migrating: %v", err)
+	}
+}
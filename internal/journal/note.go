@@ -0,0 +1,199 @@
+package journal
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// NoteRevision is an earlier version of a note, kept when the note is edited.
+type NoteRevision struct {
+	Contents string    `json:"contents"`
+	Tags     []string  `json:"tags,omitempty"`
+	Time     time.Time `json:"time,omitempty"` // when this version was written, zero if unknown
+}
+
+// NewNote returns a note written at now, with the hashtags of contents parsed
+// into its tags.
+func NewNote(contents string, now time.Time) Note {
+	note := Note{Contents: contents, CreatedAt: now}
+	note.ParseContent()
+	return note
+}
+
+// WrittenAt returns when the current version of the note was written: when
+// it was last edited, or else when it was created. It is zero for notes
+// written before notes had times.
+func (n *Note) WrittenAt() time.Time {
+	if !n.UpdatedAt.IsZero() {
+		return n.UpdatedAt
+	}
+	return n.CreatedAt
+}
+
+// Edit replaces the contents of the note at now and keeps the previous
+// version among its revisions. The hashtags of contents are parsed and added
+// to the tags the note already has, so tags are never lost by an edit. It
+// reports whether the note changed; an edit to the same text and tags is not
+// recorded.
+func (n *Note) Edit(contents string, now time.Time) bool {
+	edited := Note{Contents: contents, Tags: append([]string(nil), n.Tags...)}
+	edited.ParseContent()
+	return n.revise(edited.Contents, edited.Tags, now)
+}
+
+// Rewrite makes text, with its hashtags as the tags, the current version of
+// the note, keeping the previous one among its revisions like Edit. Unlike
+// Edit, tags missing from text are removed, for editors that show the whole
+// note. It reports whether the note changed.
+func (n *Note) Rewrite(text string, now time.Time) bool {
+	rewritten := Note{Contents: text}
+	rewritten.ParseContent()
+	return n.revise(rewritten.Contents, rewritten.Tags, now)
+}
+
+// AddTags adds the tags the note does not have yet at now, keeping the
+// previous version among its revisions like Edit. It reports whether the note
+// changed.
+func (n *Note) AddTags(tags []string, now time.Time) bool {
+	added := append([]string(nil), n.Tags...)
+	for _, tag := range tags {
+		if !containsFold(added, tag) {
+			added = append(added, tag)
+		}
+	}
+	return n.revise(n.Contents, added, now)
+}
+
+// RemoveTags removes tags from the note at now, ignoring case, keeping the
+// previous version among its revisions like Edit. It reports whether the note
+// changed.
+func (n *Note) RemoveTags(tags []string, now time.Time) bool {
+	var kept []string
+	for _, tag := range n.Tags {
+		if !containsFold(tags, tag) {
+			kept = append(kept, tag)
+		}
+	}
+	return n.revise(n.Contents, kept, now)
+}
+
+// revise makes contents and tags the current version of the note, unless
+// they already are.
+func (n *Note) revise(contents string, tags []string, now time.Time) bool {
+	if contents == n.Contents && equalTags(tags, n.Tags) {
+		return false
+	}
+
+	n.Revisions = append(n.Revisions, NoteRevision{
+		Contents: n.Contents,
+		Tags:     n.Tags,
+		Time:     n.WrittenAt(),
+	})
+	n.Contents = contents
+	n.Tags = tags
+	n.UpdatedAt = now
+	return true
+}
+
+func equalTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// RemoveNote removes the note at index from the entry and returns it. The
+// index must be valid.
+func (j *JournalEntry) RemoveNote(index int) Note {
+	note := j.Notes[index]
+	j.Notes = append(j.Notes[:index:index], j.Notes[index+1:]...)
+	if len(j.Notes) == 0 {
+		j.Notes = nil
+	}
+	return note
+}
+
+// RenameTag replaces the tag old, ignoring case, with new on every note of
+// the entry and returns the positions of the notes it changed. A note that
+// already carries new simply loses old. Renaming is a change to the tags
+// rather than to the notes, so no revisions are kept.
+func (j *JournalEntry) RenameTag(old, new string) []int {
+	var renamed []int
+	for i := range j.Notes {
+		note := &j.Notes[i]
+		if !containsFold(note.Tags, old) {
+			continue
+		}
+		var tags []string
+		for _, tag := range note.Tags {
+			if strings.EqualFold(tag, old) {
+				tag = new
+			}
+			if !containsFold(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		note.Tags = tags
+		renamed = append(renamed, i)
+	}
+	return renamed
+}
+
+// TimelineItem is a break or a note of an entry, at the time it happened.
+// Exactly one of Break and Note is set.
+type TimelineItem struct {
+	Time  time.Time // start of the break or creation of the note, zero if unknown or on another day
+	Index int       // position of the break or note in the entry
+	Break *Break
+	Note  *Note
+}
+
+// Timeline returns the breaks and notes of the entry in the order they
+// happened. Notes are placed at the time they were created, and notes without
+// a time come last, in the order they were added. So do notes written on
+// another day and moved to the entry, which have no place in its day. A note
+// written at the start of a break comes after it.
+func (j *JournalEntry) Timeline() []TimelineItem {
+	// The calendar days of the entry, up to the end of an open one
+	first := time.Date(j.StartTime.Year(), j.StartTime.Month(), j.StartTime.Day(), 0, 0, 0, 0, j.StartTime.Location())
+	onDay := func(t time.Time) bool {
+		if t.Before(first) {
+			return false
+		}
+		if j.EndTime.IsZero() {
+			return true
+		}
+		last := time.Date(j.EndTime.Year(), j.EndTime.Month(), j.EndTime.Day()+1, 0, 0, 0, 0, j.EndTime.Location())
+		return t.Before(last)
+	}
+
+	var items []TimelineItem
+	for i := range j.Breaks {
+		items = append(items, TimelineItem{Time: j.Breaks[i].StartTime, Index: i, Break: &j.Breaks[i]})
+	}
+	for i := range j.Notes {
+		item := TimelineItem{Index: i, Note: &j.Notes[i]}
+		if onDay(j.Notes[i].CreatedAt) {
+			item.Time = j.Notes[i].CreatedAt
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(a, b int) bool {
+		ta, tb := items[a].Time, items[b].Time
+		if ta.IsZero() || tb.IsZero() {
+			return !ta.IsZero() && tb.IsZero()
+		}
+		if !ta.Equal(tb) {
+			return ta.Before(tb)
+		}
+		return items[a].Break != nil && items[b].Break == nil
+	})
+	return items
+}
@@ -0,0 +1,177 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNoteEdit(t *testing.T) {
+	created := time.Date(2026, 4, 7, 10, 0, 0, 0, time.UTC)
+	edited := created.Add(2 * time.Hour)
+
+	tests := []struct {
+		name     string
+		contents string
+		want     Note
+		changed  bool
+	}{
+		{
+			name:     "keeps the tags",
+			contents: "Reviewed the lexer",
+			want: Note{
+				Contents:  "Reviewed the lexer",
+				Tags:      []string{"review"},
+				CreatedAt: created,
+				UpdatedAt: edited,
+				Revisions: []NoteRevision{{Contents: "Reviewed the parser", Tags: []string{"review"}, Time: created}},
+			},
+			changed: true,
+		},
+		{
+			name:     "adds new tags",
+			contents: "Reviewed the parser #team",
+			want: Note{
+				Contents:  "Reviewed the parser",
+				Tags:      []string{"review", "team"},
+				CreatedAt: created,
+				UpdatedAt: edited,
+				Revisions: []NoteRevision{{Contents: "Reviewed the parser", Tags: []string{"review"}, Time: created}},
+			},
+			changed: true,
+		},
+		{
+			name:     "same text and tags",
+			contents: "Reviewed the parser #review",
+			want:     Note{Contents: "Reviewed the parser", Tags: []string{"review"}, CreatedAt: created},
+			changed:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note := NewNote("Reviewed the parser #review", created)
+			if changed := note.Edit(tt.contents, edited); changed != tt.changed {
+				t.Errorf("Edit() = %v, want %v", changed, tt.changed)
+			}
+			if diff := cmp.Diff(tt.want, note); diff != "" {
+				t.Errorf("Edit() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// A second edit keeps the first one as a revision written when it was made
+	note := NewNote("First", created)
+	note.Edit("Second", edited)
+	note.Edit("Third", edited.Add(time.Hour))
+	want := []NoteRevision{{Contents: "First", Time: created}, {Contents: "Second", Time: edited}}
+	if diff := cmp.Diff(want, note.Revisions); diff != "" {
+		t.Errorf("Revisions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 4, 7, h, m, 0, 0, time.UTC) }
+	entry := JournalEntry{
+		ID:        "20260407",
+		StartTime: at(9, 0),
+		Breaks: []Break{
+			{StartTime: at(12, 0), EndTime: at(12, 30), Reason: "lunch"},
+			{StartTime: at(15, 0), EndTime: at(15, 10), Reason: "coffee"},
+		},
+		Notes: []Note{
+			{Contents: "Written before notes had times"},
+			{Contents: "Afternoon", CreatedAt: at(14, 0)},
+			{Contents: "Morning", CreatedAt: at(10, 0)},
+			{Contents: "At lunch", CreatedAt: at(12, 0)},
+			{Contents: "Moved from the day before", CreatedAt: at(11, 0).AddDate(0, 0, -1)},
+			{Contents: "After the day ended", CreatedAt: at(19, 0)},
+		},
+		EndTime: at(17, 0),
+	}
+
+	var got []string
+	for _, item := range entry.Timeline() {
+		if item.Break != nil {
+			got = append(got, "break "+item.Break.Reason)
+		} else {
+			got = append(got, item.Note.Contents)
+		}
+	}
+	want := []string{"Morning", "break lunch", "At lunch", "Afternoon", "break coffee", "After the day ended", "Written before notes had times", "Moved from the day before"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Timeline() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNoteTags(t *testing.T) {
+	created := time.Date(2026, 4, 7, 10, 0, 0, 0, time.UTC)
+	edited := created.Add(time.Hour)
+
+	note := NewNote("Fixed the login bug #bug", created)
+	if note.AddTags([]string{"BUG"}, edited) {
+		t.Error("AddTags() of a tag the note has, in another case, changed it")
+	}
+	if !note.AddTags([]string{"progress", "team"}, edited) || !note.RemoveTags([]string{"Bug"}, edited) {
+		t.Fatal("AddTags() and RemoveTags() should change the note")
+	}
+	if note.RemoveTags([]string{"missing"}, edited) {
+		t.Error("RemoveTags() of a tag the note does not have changed it")
+	}
+
+	want := Note{
+		Contents:  "Fixed the login bug",
+		Tags:      []string{"progress", "team"},
+		CreatedAt: created,
+		UpdatedAt: edited,
+		Revisions: []NoteRevision{
+			{Contents: "Fixed the login bug", Tags: []string{"bug"}, Time: created},
+			{Contents: "Fixed the login bug", Tags: []string{"bug", "progress", "team"}, Time: edited},
+		},
+	}
+	if diff := cmp.Diff(want, note); diff != "" {
+		t.Errorf("note mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRemoveNote(t *testing.T) {
+	entry := JournalEntry{Notes: []Note{{Contents: "one"}, {Contents: "two"}, {Contents: "three"}}}
+	notes := entry.Notes
+
+	if removed := entry.RemoveNote(1); removed.Contents != "two" {
+		t.Errorf("RemoveNote() = %q, want two", removed.Contents)
+	}
+	if diff := cmp.Diff([]Note{{Contents: "one"}, {Contents: "three"}}, entry.Notes); diff != "" {
+		t.Errorf("notes mismatch (-want +got):\n%s", diff)
+	}
+	if notes[1].Contents != "two" {
+		t.Error("RemoveNote() changed the notes it was given")
+	}
+
+	entry.RemoveNote(0)
+	entry.RemoveNote(0)
+	if entry.Notes != nil {
+		t.Errorf("notes = %v, want nil", entry.Notes)
+	}
+}
+
+func TestRenameTag(t *testing.T) {
+	entry := JournalEntry{Notes: []Note{
+		{Contents: "Planned", Tags: []string{"Team", "planning"}},
+		{Contents: "Lunch"},
+		{Contents: "Retro", Tags: []string{"crew", "team"}},
+	}}
+
+	if diff := cmp.Diff([]int{0, 2}, entry.RenameTag("team", "crew")); diff != "" {
+		t.Errorf("RenameTag() mismatch (-want +got):\n%s", diff)
+	}
+	want := [][]string{{"crew", "planning"}, nil, {"crew"}}
+	var got [][]string
+	for _, note := range entry.Notes {
+		got = append(got, note.Tags)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("tags mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,381 @@
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Severity is how serious a rule violation is.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// ParseSeverity parses a severity, defaulting to SeverityWarning when s is
+// empty.
+func ParseSeverity(s string) (Severity, error) {
+	switch Severity(strings.ToLower(s)) {
+	case "", SeverityWarning:
+		return SeverityWarning, nil
+	case SeverityInfo:
+		return SeverityInfo, nil
+	case SeverityError:
+		return SeverityError, nil
+	default:
+		return "", ValidationError("severity", fmt.Sprintf("unknown severity %q, expected info, warning or error", s))
+	}
+}
+
+// Violation is a labor rule broken on one day.
+type Violation struct {
+	Day      string // ID of the entry that broke the rule
+	Rule     string // name of the rule, e.g. "daily_rest"
+	Severity Severity
+	Message  string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("[%s] %s: %s", v.Severity, v.Rule, v.Message)
+}
+
+// Rule is a labor rule that entries are checked against.
+type Rule interface {
+	// Name identifies the rule in violations.
+	Name() string
+
+	// Check checks entries[i]. The entries are sorted by start time, so rules
+	// that span several days can look at the entries before it.
+	Check(entries []JournalEntry, i int) []Violation
+}
+
+// RuleConfig is the configuration of one rule, as read from the rules section
+// of the config file:
+//
+//	rules:
+//	  - type: break_after
+//	    after: 6h
+//	    break: 30m
+//	  - type: daily_rest
+//	    min: 11h
+//	    severity: error
+//
+// Every rule takes a severity, which defaults to warning, and a name, which
+// defaults to the type; the other keys depend on the type.
+type RuleConfig map[string]interface{}
+
+// Duration returns the duration under key, or def when the key is not set.
+func (c RuleConfig) Duration(key string, def time.Duration) (time.Duration, error) {
+	value, ok := c[key]
+	if !ok {
+		return def, nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return 0, ValidationError(c.Type()+"."+key, fmt.Sprintf("expected a duration such as 6h, got %v", value))
+	}
+	return ValidateConfigDuration(s, c.Type()+"."+key)
+}
+
+// Strings returns the list of strings under key.
+func (c RuleConfig) Strings(key string) ([]string, error) {
+	list, ok := c[key].([]interface{})
+	if !ok {
+		return nil, ValidationError(c.Type()+"."+key, "expected a list")
+	}
+	values := make([]string, len(list))
+	for i, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, ValidationError(c.Type()+"."+key, fmt.Sprintf("expected text, got %v", item))
+		}
+		values[i] = s
+	}
+	return values, nil
+}
+
+// Type returns the rule type the config is for.
+func (c RuleConfig) Type() string {
+	s, _ := c["type"].(string)
+	return s
+}
+
+// RuleFactory builds a rule of one type from its config.
+type RuleFactory func(config RuleConfig, severity Severity) (Rule, error)
+
+// ruleTypes is the registry of rule types that can be configured.
+var ruleTypes = map[string]RuleFactory{
+	"break_after": newBreakAfterRule,
+	"daily_rest":  newDailyRestRule,
+	"max_weekly":  newMaxWeeklyRule,
+	"no_work_on":  newNoWorkOnRule,
+}
+
+// RegisterRuleType makes a rule type available to BuildRules. Registering a
+// name twice replaces the earlier factory.
+func RegisterRuleType(name string, factory RuleFactory) {
+	ruleTypes[name] = factory
+}
+
+// BuildRules builds the rules of configs, in order. Every rule needs its own
+// name, so that its violations can be told apart: a rule without a name is
+// named after its type, with a number for the second rule of a type onwards,
+// e.g. "break_after_2".
+func BuildRules(configs []RuleConfig) ([]Rule, error) {
+	rules := make([]Rule, 0, len(configs))
+	names := make(map[string]bool, len(configs))
+	types := make(map[string]int, len(configs))
+	for i, config := range configs {
+		factory, ok := ruleTypes[config.Type()]
+		if !ok {
+			names := make([]string, 0, len(ruleTypes))
+			for name := range ruleTypes {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, ValidationError("rules", fmt.Sprintf("rule %d has unknown type %q, expected one of %s", i+1, config.Type(), strings.Join(names, ", ")))
+		}
+
+		severityStr, _ := config["severity"].(string)
+		severity, err := ParseSeverity(severityStr)
+		if err != nil {
+			return nil, err
+		}
+		rule, err := factory(config, severity)
+		if err != nil {
+			return nil, err
+		}
+
+		types[config.Type()]++
+		name, _ := config["name"].(string)
+		if name == "" {
+			name = config.Type()
+			if n := types[config.Type()]; n > 1 {
+				name = fmt.Sprintf("%s_%d", name, n)
+			}
+		}
+		if names[name] {
+			return nil, ValidationError("rules", fmt.Sprintf("rule %d has the name %q of an earlier rule, set another name", i+1, name))
+		}
+		names[name] = true
+		if name != rule.Name() {
+			rule = namedRule{Rule: rule, name: name}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// namedRule is a rule under a name given in its config.
+type namedRule struct {
+	Rule
+	name string
+}
+
+func (r namedRule) Name() string { return r.name }
+
+func (r namedRule) Check(entries []JournalEntry, i int) []Violation {
+	violations := r.Rule.Check(entries, i)
+	for k := range violations {
+		violations[k].Rule = r.name
+	}
+	return violations
+}
+
+// CheckRules checks every ended entry that starts between the days of from
+// and to, inclusive, against rules, and returns the violations in day order.
+// entries may hold days outside the range, which rules use as context.
+func CheckRules(rules []Rule, entries []JournalEntry, from, to time.Time) []Violation {
+	sorted := make([]JournalEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].StartTime.Before(sorted[b].StartTime)
+	})
+
+	fromDay, toDay := from.Format("20060102"), to.Format("20060102")
+	var violations []Violation
+	for i, entry := range sorted {
+		day := entry.StartTime.Format("20060102")
+		if day < fromDay || day > toDay || entry.EndTime.IsZero() {
+			continue
+		}
+		for _, rule := range rules {
+			violations = append(violations, rule.Check(sorted, i)...)
+		}
+	}
+	return violations
+}
+
+// breakAfterRule requires breaks of at least minBreak in total on days with
+// more than after of work.
+type breakAfterRule struct {
+	severity Severity
+	after    time.Duration
+	minBreak time.Duration
+}
+
+func newBreakAfterRule(config RuleConfig, severity Severity) (Rule, error) {
+	after, err := config.Duration("after", 6*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	minBreak, err := config.Duration("break", 30*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	return &breakAfterRule{severity: severity, after: after, minBreak: minBreak}, nil
+}
+
+func (r *breakAfterRule) Name() string { return "break_after" }
+
+func (r *breakAfterRule) Check(entries []JournalEntry, i int) []Violation {
+	entry := &entries[i]
+	if entry.TotalWorkTime() <= r.after {
+		return nil
+	}
+
+	var breaks time.Duration
+	for _, br := range entry.Breaks {
+		breaks += br.Duration()
+	}
+	if breaks >= r.minBreak {
+		return nil
+	}
+	return []Violation{{
+		Day:      entry.ID,
+		Rule:     r.Name(),
+		Severity: r.severity,
+		Message: fmt.Sprintf("worked %s with %s of breaks, at least %s required after %s",
+			entry.TotalWorkTime(), breaks, r.minBreak, r.after),
+	}}
+}
+
+// dailyRestRule requires a rest of at least min between the end of one day
+// and the start of the next.
+type dailyRestRule struct {
+	severity Severity
+	min      time.Duration
+}
+
+func newDailyRestRule(config RuleConfig, severity Severity) (Rule, error) {
+	minRest, err := config.Duration("min", 11*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	return &dailyRestRule{severity: severity, min: minRest}, nil
+}
+
+func (r *dailyRestRule) Name() string { return "daily_rest" }
+
+func (r *dailyRestRule) Check(entries []JournalEntry, i int) []Violation {
+	sessions := entries[i].WorkSessions()
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	// The rest starts at the end of the last day that was worked
+	for j := i - 1; j >= 0; j-- {
+		previous := entries[j].WorkSessions()
+		if len(previous) == 0 {
+			continue
+		}
+		end := previous[len(previous)-1].EndTime
+		if end.IsZero() {
+			return nil
+		}
+		rest := sessions[0].StartTime.Sub(end)
+		if rest >= r.min {
+			return nil
+		}
+		return []Violation{{
+			Day:      entries[i].ID,
+			Rule:     r.Name(),
+			Severity: r.severity,
+			Message:  fmt.Sprintf("rested %s since %s, at least %s required", rest, end.Format("Jan 2 15:04"), r.min),
+		}}
+	}
+	return nil
+}
+
+// maxWeeklyRule limits the work of a week, Monday to Sunday. The violation is
+// reported on the day the limit is crossed.
+type maxWeeklyRule struct {
+	severity Severity
+	max      time.Duration
+}
+
+func newMaxWeeklyRule(config RuleConfig, severity Severity) (Rule, error) {
+	maxWork, err := config.Duration("max", 48*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	return &maxWeeklyRule{severity: severity, max: maxWork}, nil
+}
+
+func (r *maxWeeklyRule) Name() string { return "max_weekly" }
+
+func (r *maxWeeklyRule) Check(entries []JournalEntry, i int) []Violation {
+	monday, _ := WeekBounds(entries[i].StartTime)
+	weekStart := monday.Format("20060102")
+
+	var before time.Duration
+	for j := i - 1; j >= 0 && entries[j].StartTime.Format("20060102") >= weekStart; j-- {
+		before += entries[j].TotalWorkTime()
+	}
+	total := before + entries[i].TotalWorkTime()
+	if before > r.max || total <= r.max {
+		return nil
+	}
+	return []Violation{{
+		Day:      entries[i].ID,
+		Rule:     r.Name(),
+		Severity: r.severity,
+		Message:  fmt.Sprintf("worked %s this week, more than the maximum of %s", total, r.max),
+	}}
+}
+
+// noWorkOnRule flags work on the given weekdays, e.g. Sundays.
+type noWorkOnRule struct {
+	severity Severity
+	days     map[time.Weekday]bool
+}
+
+func newNoWorkOnRule(config RuleConfig, severity Severity) (Rule, error) {
+	names, err := config.Strings("days")
+	if err != nil {
+		return nil, err
+	}
+
+	days := make(map[time.Weekday]bool)
+	for _, name := range names {
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(name, day.String()) {
+				days[day], found = true, true
+			}
+		}
+		if !found {
+			return nil, ValidationError("no_work_on.days", fmt.Sprintf("unknown weekday %q", name))
+		}
+	}
+	return &noWorkOnRule{severity: severity, days: days}, nil
+}
+
+func (r *noWorkOnRule) Name() string { return "no_work_on" }
+
+func (r *noWorkOnRule) Check(entries []JournalEntry, i int) []Violation {
+	entry := &entries[i]
+	if !r.days[entry.StartTime.Weekday()] || entry.TotalWorkTime() == 0 {
+		return nil
+	}
+	return []Violation{{
+		Day:      entry.ID,
+		Rule:     r.Name(),
+		Severity: r.severity,
+		Message:  fmt.Sprintf("worked %s on a %s", entry.TotalWorkTime(), entry.StartTime.Weekday()),
+	}}
+}
@@ -0,0 +1,168 @@
+package journal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// workedDay builds an ended entry on April day of 2026 worked from start to
+// end, with the given breaks as [start, end] hour pairs.
+func workedDay(day int, start, end float64, breaks ...[2]float64) JournalEntry {
+	at := func(hour float64) time.Time {
+		return time.Date(2026, 4, day, 0, 0, 0, 0, time.UTC).Add(time.Duration(hour * float64(time.Hour)))
+	}
+	entry := JournalEntry{
+		ID:        at(0).Format("20060102"),
+		StartTime: at(start),
+		EndTime:   at(end),
+		Sessions:  []Session{{StartTime: at(start), EndTime: at(end)}},
+	}
+	for _, br := range breaks {
+		entry.Breaks = append(entry.Breaks, Break{StartTime: at(br[0]), EndTime: at(br[1]), Reason: "break"})
+	}
+	return entry
+}
+
+func TestBuildRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []RuleConfig
+		wantErr bool
+	}{
+		{name: "no rules"},
+		{name: "defaults", configs: []RuleConfig{{"type": "break_after"}, {"type": "daily_rest"}, {"type": "max_weekly"}}},
+		{name: "settings", configs: []RuleConfig{{"type": "break_after", "after": "9h", "break": "45m", "severity": "error"}}},
+		{name: "weekdays", configs: []RuleConfig{{"type": "no_work_on", "days": []interface{}{"Sunday", "saturday"}}}},
+		{name: "unknown type", configs: []RuleConfig{{"type": "four_day_week"}}, wantErr: true},
+		{name: "unknown severity", configs: []RuleConfig{{"type": "daily_rest", "severity": "fatal"}}, wantErr: true},
+		{name: "invalid duration", configs: []RuleConfig{{"type": "daily_rest", "min": "eleven hours"}}, wantErr: true},
+		{name: "duration is not text", configs: []RuleConfig{{"type": "max_weekly", "max": 48}}, wantErr: true},
+		{name: "unknown weekday", configs: []RuleConfig{{"type": "no_work_on", "days": []interface{}{"Caturday"}}}, wantErr: true},
+		{name: "days missing", configs: []RuleConfig{{"type": "no_work_on"}}, wantErr: true},
+		{name: "two rules of a type", configs: []RuleConfig{{"type": "break_after"}, {"type": "break_after", "after": "9h"}}},
+		{name: "same name twice", configs: []RuleConfig{{"type": "break_after", "name": "breaks"}, {"type": "daily_rest", "name": "breaks"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := BuildRules(tt.configs)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("expected ErrValidation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildRules() error = %v", err)
+			}
+			if len(rules) != len(tt.configs) {
+				t.Errorf("got %d rules, want %d", len(rules), len(tt.configs))
+			}
+		})
+	}
+}
+
+func TestCheckRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   RuleConfig
+		entries  []JournalEntry
+		wantDays []string
+	}{
+		{
+			name:     "break after 6h",
+			config:   RuleConfig{"type": "break_after"},
+			entries:  []JournalEntry{workedDay(6, 8, 15), workedDay(7, 8, 15, [2]float64{12, 12.5}), workedDay(8, 8, 14)},
+			wantDays: []string{"20260406"},
+		},
+		{
+			name:     "11h of rest",
+			config:   RuleConfig{"type": "daily_rest"},
+			entries:  []JournalEntry{workedDay(6, 12, 23), workedDay(7, 8, 16), workedDay(8, 9, 17)},
+			wantDays: []string{"20260407"},
+		},
+		{
+			name:   "rest is measured from the last day worked",
+			config: RuleConfig{"type": "daily_rest", "min": "11h"},
+			entries: func() []JournalEntry {
+				off, _ := NewDayOffEntry(time.Date(2026, 4, 7, 0, 0, 0, 0, time.UTC), DaySick, "")
+				return []JournalEntry{workedDay(6, 14, 22), *off, workedDay(8, 6, 14)}
+			}(),
+		},
+		{
+			// Sunday the 5th belongs to the week before
+			name:     "weekly maximum is reported on the day it is crossed",
+			config:   RuleConfig{"type": "max_weekly", "max": "20h"},
+			entries:  []JournalEntry{workedDay(5, 8, 18), workedDay(6, 8, 18), workedDay(7, 8, 18), workedDay(8, 8, 18)},
+			wantDays: []string{"20260408"},
+		},
+		{
+			name:     "no work on Sundays",
+			config:   RuleConfig{"type": "no_work_on", "days": []interface{}{"sunday"}},
+			entries:  []JournalEntry{workedDay(5, 10, 12), workedDay(6, 8, 16)},
+			wantDays: []string{"20260405"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := BuildRules([]RuleConfig{tt.config})
+			if err != nil {
+				t.Fatal(err)
+			}
+			from := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+			to := time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)
+			violations := CheckRules(rules, tt.entries, from, to)
+
+			if len(violations) != len(tt.wantDays) {
+				t.Fatalf("got violations %v, want them on %v", violations, tt.wantDays)
+			}
+			for i, v := range violations {
+				if v.Day != tt.wantDays[i] || v.Rule != tt.config.Type() || v.Severity != SeverityWarning {
+					t.Errorf("violation %d = %+v, want a warning from %s on %s", i, v, tt.config.Type(), tt.wantDays[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckRulesNamesEveryRule(t *testing.T) {
+	rules, err := BuildRules([]RuleConfig{
+		{"type": "break_after"},
+		{"type": "break_after", "after": "9h", "break": "45m"},
+		{"type": "break_after", "after": "8h", "break": "1h", "name": "long_days"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 10h of work with a 15m break breaks all three rules
+	day := time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC)
+	violations := CheckRules(rules, []JournalEntry{workedDay(6, 8, 18.25, [2]float64{12, 12.25})}, day, day)
+
+	var names []string
+	for _, v := range violations {
+		names = append(names, v.Rule)
+	}
+	if diff := cmp.Diff([]string{"break_after", "break_after_2", "long_days"}, names); diff != "" {
+		t.Errorf("violation rules mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCheckRulesOnlyChecksTheRange(t *testing.T) {
+	rules, err := BuildRules([]RuleConfig{{"type": "daily_rest"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// April 6 and 7 both follow a short rest, but only the 7th is checked;
+	// the 6th is context for it
+	entries := []JournalEntry{workedDay(7, 6, 14), workedDay(5, 12, 22), workedDay(6, 6, 20)}
+	day := time.Date(2026, 4, 7, 0, 0, 0, 0, time.UTC)
+
+	violations := CheckRules(rules, entries, day, day)
+	if len(violations) != 1 || violations[0].Day != "20260407" {
+		t.Errorf("expected one violation on April 7, got %v", violations)
+	}
+}
@@ -0,0 +1,492 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// searchIndexVersion is the format of the search index file. An index in
+// another format is rebuilt instead of read.
+const searchIndexVersion = 1
+
+// SearchIndex is an inverted index of the notes of the journal: for every
+// word it lists the notes containing it and where, so notes can be searched
+// without reading the journal. It also keeps a copy of the notes, which is
+// what searches return. Tags are indexed as words starting with '#'.
+type SearchIndex struct {
+	Version  int                     `json:"version"`
+	Entries  map[string]indexedEntry `json:"entries"`  // by entry ID
+	Postings map[string][]Posting    `json:"postings"` // by word
+}
+
+// indexedEntry is what the index knows about an entry with notes.
+type indexedEntry struct {
+	Date        time.Time `json:"date"`
+	Fingerprint string    `json:"fingerprint"` // of the date and the notes, to skip unchanged entries
+	Notes       []Note    `json:"notes"`
+}
+
+// Posting is one note containing a word, with the positions of the word
+// among the words of the note. Tags have no positions.
+type Posting struct {
+	EntryID   string `json:"entry"`
+	Note      int    `json:"note"`
+	Positions []int  `json:"positions,omitempty"`
+}
+
+// SearchIndexPath returns where the search index of the journal at location
+// is kept, next to the journal.
+func SearchIndexPath(location string) string {
+	return location + ".index"
+}
+
+// NewSearchIndex returns an empty index.
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		Version:  searchIndexVersion,
+		Entries:  make(map[string]indexedEntry),
+		Postings: make(map[string][]Posting),
+	}
+}
+
+// BuildSearchIndex indexes the notes of entries from scratch.
+func BuildSearchIndex(entries []JournalEntry) *SearchIndex {
+	index := NewSearchIndex()
+	for _, entry := range entries {
+		index.Update(entry)
+	}
+	return index
+}
+
+// LoadSearchIndex reads the index at path. A missing file gives an error
+// wrapping os.ErrNotExist; an index in another format is an error too, and is
+// meant to be rebuilt.
+func LoadSearchIndex(path string) (*SearchIndex, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, JournalIOError("read the search index of the", err)
+	}
+	var index SearchIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, JournalIOError("decode the search index of the", err)
+	}
+	if index.Version != searchIndexVersion {
+		return nil, JournalIOError("read the search index of the", fmt.Errorf("format version %d, expected %d", index.Version, searchIndexVersion))
+	}
+	if index.Entries == nil {
+		index.Entries = make(map[string]indexedEntry)
+	}
+	if index.Postings == nil {
+		index.Postings = make(map[string][]Posting)
+	}
+	return &index, nil
+}
+
+// Save writes the index to path atomically, like SaveEntries.
+func (x *SearchIndex) Save(path string) error {
+	data, err := json.Marshal(x)
+	if err != nil {
+		return JournalIOError("encode the search index of the", err)
+	}
+	return writeFileAtomic(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// RemoveSearchIndex deletes the search index of the journal at location, so
+// the next search rebuilds it. Commands that replace the journal without
+// going through a Store, such as restoring a backup, call it. A missing index
+// is not an error.
+func RemoveSearchIndex(location string) error {
+	if err := os.Remove(SearchIndexPath(location)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return JournalIOError("remove the search index of the", err)
+	}
+	return nil
+}
+
+// OpenSearchIndex returns the search index of store. It is built from every
+// entry of store, and saved, when it does not exist yet, cannot be read, or
+// rebuild is set.
+func OpenSearchIndex(store Store, rebuild bool) (*SearchIndex, error) {
+	path := SearchIndexPath(store.Location())
+	if !rebuild {
+		if index, err := LoadSearchIndex(path); err == nil {
+			return index, nil
+		}
+	}
+
+	entries, err := store.All()
+	if err != nil {
+		return nil, err
+	}
+	index := BuildSearchIndex(entries)
+	if err := index.Save(path); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// NoteCount returns how many notes are indexed.
+func (x *SearchIndex) NoteCount() int {
+	count := 0
+	for _, entry := range x.Entries {
+		count += len(entry.Notes)
+	}
+	return count
+}
+
+// Update indexes the notes of entry, replacing what was indexed for it
+// before, and reports whether the index changed.
+func (x *SearchIndex) Update(entry JournalEntry) bool {
+	old, indexed := x.Entries[entry.ID]
+	if len(entry.Notes) == 0 {
+		return x.Remove(entry.ID)
+	}
+	fingerprint := entryFingerprint(entry)
+	if indexed && old.Fingerprint == fingerprint {
+		return false
+	}
+
+	x.Remove(entry.ID)
+	notes := append([]Note(nil), entry.Notes...)
+	for i := range notes {
+		// Searches only show the current version
+		notes[i].Revisions = nil
+	}
+	x.Entries[entry.ID] = indexedEntry{Date: entry.StartTime, Fingerprint: fingerprint, Notes: notes}
+	for i, note := range notes {
+		positions := make(map[string][]int)
+		for pos, word := range tokenize(note.Contents) {
+			positions[word] = append(positions[word], pos)
+		}
+		for _, tag := range uniqueTags(note.Tags) {
+			if _, ok := positions[tagTerm(tag)]; !ok {
+				positions[tagTerm(tag)] = nil
+			}
+		}
+		for term, pos := range positions {
+			x.Postings[term] = append(x.Postings[term], Posting{EntryID: entry.ID, Note: i, Positions: pos})
+		}
+	}
+	return true
+}
+
+// Remove drops the notes of the entry with the given ID from the index and
+// reports whether there were any.
+func (x *SearchIndex) Remove(id string) bool {
+	old, ok := x.Entries[id]
+	if !ok {
+		return false
+	}
+	for _, note := range old.Notes {
+		terms := tokenize(note.Contents)
+		for _, tag := range note.Tags {
+			terms = append(terms, tagTerm(tag))
+		}
+		for _, term := range terms {
+			postings, kept := x.Postings[term], x.Postings[term][:0]
+			for _, posting := range postings {
+				if posting.EntryID != id {
+					kept = append(kept, posting)
+				}
+			}
+			if len(kept) == 0 {
+				delete(x.Postings, term)
+			} else {
+				x.Postings[term] = kept
+			}
+		}
+	}
+	delete(x.Entries, id)
+	return true
+}
+
+// IndexQuery is a search of the index. Words of Text must all be in a note,
+// and words in double quotes must be there in that order, as a phrase. The
+// tags are matched like those of a NoteQuery.
+type IndexQuery struct {
+	Text     string
+	Tags     []string
+	AnyTag   bool
+	From, To time.Time // days the notes must be written on, zero for an open end
+}
+
+// noteKey identifies a note in the index.
+type noteKey struct {
+	EntryID string
+	Note    int
+}
+
+// Search returns the notes selected by query, best matches first. Notes are
+// ranked with BM25 on the words of the query, so rare words and short notes
+// weigh more; notes that rank the same, such as every note of a search by
+// tags only, come most recent first.
+func (x *SearchIndex) Search(query IndexQuery) []NoteMatch {
+	words, phrases := parseSearchText(query.Text)
+
+	// Notes carrying every word, with where each word is
+	var candidates map[noteKey]map[string][]int
+	for _, word := range words {
+		found := make(map[noteKey]map[string][]int)
+		for _, posting := range x.Postings[word] {
+			key := noteKey{posting.EntryID, posting.Note}
+			if candidates != nil && candidates[key] == nil {
+				continue
+			}
+			positions := candidates[key]
+			if positions == nil {
+				positions = make(map[string][]int)
+			}
+			positions[word] = posting.Positions
+			found[key] = positions
+		}
+		candidates = found
+	}
+	if candidates == nil {
+		candidates = make(map[noteKey]map[string][]int)
+		for id, entry := range x.Entries {
+			for i := range entry.Notes {
+				candidates[noteKey{id, i}] = nil
+			}
+		}
+	}
+
+	fromKey, toKey := "", "99999999"
+	if !query.From.IsZero() {
+		fromKey = dayKey(query.From)
+	}
+	if !query.To.IsZero() {
+		toKey = dayKey(query.To)
+	}
+	tagQuery := NoteQuery{Tags: query.Tags, AnyTag: query.AnyTag}
+	stats := x.corpusStats()
+
+	var matches []NoteMatch
+	for key, positions := range candidates {
+		if key.EntryID < fromKey || key.EntryID > toKey {
+			continue
+		}
+		entry := x.Entries[key.EntryID]
+		note := entry.Notes[key.Note]
+		if !tagQuery.Matches(note) || !containsPhrases(positions, phrases) {
+			continue
+		}
+		matches = append(matches, NoteMatch{
+			EntryID: key.EntryID,
+			Date:    entry.Date,
+			Index:   key.Note,
+			Note:    note,
+			Score:   x.score(note, positions, stats),
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.EntryID != b.EntryID {
+			return a.EntryID > b.EntryID
+		}
+		return a.Index < b.Index
+	})
+	return matches
+}
+
+// BM25 parameters, the usual ones
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// corpus is the number of notes in the index and their average length in
+// words, which BM25 compares each note to.
+type corpus struct {
+	notes         int
+	averageLength float64
+}
+
+func (x *SearchIndex) corpusStats() corpus {
+	stats := corpus{notes: x.NoteCount()}
+	words := 0
+	for _, entry := range x.Entries {
+		for _, note := range entry.Notes {
+			words += len(tokenize(note.Contents))
+		}
+	}
+	if stats.notes > 0 {
+		stats.averageLength = float64(words) / float64(stats.notes)
+	}
+	return stats
+}
+
+// score ranks note by how often it contains the words found in positions,
+// with BM25.
+func (x *SearchIndex) score(note Note, positions map[string][]int, stats corpus) float64 {
+	if len(positions) == 0 || stats.averageLength == 0 {
+		return 0
+	}
+
+	length := float64(len(tokenize(note.Contents)))
+	var score float64
+	for word, pos := range positions {
+		df := float64(len(x.Postings[word]))
+		idf := math.Log(1 + (float64(stats.notes)-df+0.5)/(df+0.5))
+		tf := float64(len(pos))
+		score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*length/stats.averageLength))
+	}
+	return score
+}
+
+// containsPhrases reports whether the words of every phrase follow each other
+// in a note, given the positions of the words in the note.
+func containsPhrases(positions map[string][]int, phrases [][]string) bool {
+	for _, phrase := range phrases {
+		found := false
+		for _, start := range positions[phrase[0]] {
+			found = true
+			for i, word := range phrase[1:] {
+				if !containsInt(positions[word], start+i+1) {
+					found = false
+					break
+				}
+			}
+			if found {
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSearchText splits the text of a search into its words and its
+// phrases, the runs of more than one word in double quotes. The words of
+// phrases are among the words too. A missing closing quote ends the phrase
+// at the end of the text.
+func parseSearchText(text string) ([]string, [][]string) {
+	var words []string
+	var phrases [][]string
+	for i, part := range strings.Split(text, `"`) {
+		tokens := tokenize(part)
+		for _, token := range tokens {
+			if !containsFold(words, token) {
+				words = append(words, token)
+			}
+		}
+		// Odd parts are between quotes
+		if i%2 == 1 && len(tokens) > 1 {
+			phrases = append(phrases, tokens)
+		}
+	}
+	return words, phrases
+}
+
+// tokenize splits text into lower case words of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func tagTerm(tag string) string {
+	return "#" + strings.ToLower(tag)
+}
+
+// entryFingerprint hashes what the index keeps of entry.
+func entryFingerprint(entry JournalEntry) string {
+	data, _ := json.Marshal(struct {
+		Date  time.Time
+		Notes []Note
+	}{entry.StartTime, entry.Notes})
+	hash := fnv.New64a()
+	hash.Write(data)
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// IndexedStore is a Store that keeps the search index of its journal up to
+// date: every entry it saves or deletes is updated in the index, so the index
+// is never rebuilt from scratch unless asked to. Until the first search has
+// built the index there is nothing to update.
+type IndexedStore struct {
+	Store
+}
+
+// NewIndexedStore wraps store so its changes update its search index.
+func NewIndexedStore(store Store) *IndexedStore {
+	return &IndexedStore{Store: store}
+}
+
+// Upsert saves entry and updates its notes in the search index.
+func (s *IndexedStore) Upsert(entry JournalEntry) error {
+	if err := s.Store.Upsert(entry); err != nil {
+		return err
+	}
+	return s.updateIndex(func(index *SearchIndex) bool { return index.Update(entry) })
+}
+
+// Delete removes the entry with the given ID and its notes from the search
+// index.
+func (s *IndexedStore) Delete(id string) error {
+	if err := s.Store.Delete(id); err != nil {
+		return err
+	}
+	return s.updateIndex(func(index *SearchIndex) bool { return index.Remove(id) })
+}
+
+// Write applies changes and updates the search index for each of them.
+func (s *IndexedStore) Write(changes []Change) error {
+	if err := s.Store.Write(changes); err != nil {
+		return err
+	}
+	return s.updateIndex(func(index *SearchIndex) bool {
+		changed := false
+		for _, change := range changes {
+			if change.Delete {
+				changed = index.Remove(change.Entry.ID) || changed
+			} else {
+				changed = index.Update(change.Entry) || changed
+			}
+		}
+		return changed
+	})
+}
+
+// updateIndex applies change to the search index and saves it when it
+// changed. An index that cannot be read is removed, to be rebuilt by the
+// next search, rather than failing the change to the journal.
+func (s *IndexedStore) updateIndex(change func(index *SearchIndex) bool) error {
+	path := SearchIndexPath(s.Location())
+	index, err := LoadSearchIndex(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return RemoveSearchIndex(s.Location())
+	}
+	if !change(index) {
+		return nil
+	}
+	return index.Save(path)
+}
@@ -0,0 +1,193 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func searchContents(matches []NoteMatch) []string {
+	var contents []string
+	for _, match := range matches {
+		contents = append(contents, match.Note.Contents)
+	}
+	return contents
+}
+
+func TestSearchIndexSearch(t *testing.T) {
+	index := BuildSearchIndex(append(tagEntries(), JournalEntry{
+		ID: "20260409", StartTime: time.Date(2026, 4, 9, 9, 0, 0, 0, time.UTC), Notes: []Note{
+			{Contents: "Reviewed the parser and the parser tests"},
+			{Contents: "Team lunch"},
+		},
+	}))
+
+	tests := []struct {
+		name  string
+		query IndexQuery
+		want  []string
+	}{
+		{name: "word ignores case", query: IndexQuery{Text: "PARSER"}, want: []string{"Reviewed the parser and the parser tests", "Paired on the parser"}},
+		{name: "all words", query: IndexQuery{Text: "team lunch"}, want: []string{"Team lunch", "Lunch with the team"}},
+		{name: "phrase", query: IndexQuery{Text: `"the team"`}, want: []string{"Lunch with the team"}},
+		{name: "phrase out of order", query: IndexQuery{Text: `"team the"`}, want: nil},
+		{name: "unknown word", query: IndexQuery{Text: "parser deploy"}, want: nil},
+		{name: "tags only, most recent first", query: IndexQuery{Tags: []string{"progress"}}, want: []string{"Paired on the parser", "Shipped the release", "Fixed the login bug"}},
+		{name: "word and tag", query: IndexQuery{Text: "the", Tags: []string{"team"}}, want: []string{"Shipped the release", "Planned the sprint"}},
+		{name: "any tag", query: IndexQuery{Tags: []string{"planning", "team"}, AnyTag: true}, want: []string{"Shipped the release", "Planned the sprint"}},
+		{
+			name:  "dates",
+			query: IndexQuery{Text: "the", From: time.Date(2026, 4, 7, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 4, 8, 0, 0, 0, 0, time.UTC)},
+			want:  []string{"Shipped the release", "Paired on the parser", "Lunch with the team"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, searchContents(index.Search(tt.query))); diff != "" {
+				t.Errorf("Search() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSearchIndexUpdate(t *testing.T) {
+	entries := tagEntries()
+	index := BuildSearchIndex(entries)
+
+	if index.Update(entries[0]) {
+		t.Error("Update() of an unchanged entry reported a change")
+	}
+
+	entries[0].Notes = []Note{{Contents: "Planned the roadmap", Tags: []string{"planning"}}}
+	if !index.Update(entries[0]) {
+		t.Error("Update() of a changed entry reported no change")
+	}
+	if got := index.Search(IndexQuery{Text: "sprint"}); len(got) != 0 {
+		t.Errorf("the old note is still found: %v", searchContents(got))
+	}
+	if got := index.Search(IndexQuery{Tags: []string{"team"}}); len(got) != 1 {
+		t.Errorf("found %d notes tagged #team, want 1", len(got))
+	}
+
+	if !index.Remove(entries[1].ID) || index.Remove(entries[1].ID) {
+		t.Error("Remove() should report a change only the first time")
+	}
+	entries[2].Notes = nil
+	if !index.Update(entries[2]) {
+		t.Error("Update() of an entry without notes should remove it")
+	}
+
+	// The same as indexing what is left from scratch
+	want := BuildSearchIndex([]JournalEntry{entries[0]})
+	if diff := cmp.Diff(want, index); diff != "" {
+		t.Errorf("index mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadSearchIndex(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := LoadSearchIndex(filepath.Join(dir, "missing.index")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadSearchIndex() of a missing file error = %v, want os.ErrNotExist", err)
+	}
+
+	path := filepath.Join(dir, "journal.json.index")
+	index := BuildSearchIndex(tagEntries())
+	if err := index.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadSearchIndex(path)
+	if err != nil {
+		t.Fatalf("LoadSearchIndex() error = %v", err)
+	}
+	if diff := cmp.Diff(searchContents(index.Search(IndexQuery{Text: "the"})), searchContents(loaded.Search(IndexQuery{Text: "the"}))); diff != "" {
+		t.Errorf("loaded index mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version":99}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSearchIndex(path); err == nil {
+		t.Error("expected an error for another format version")
+	}
+}
+
+func TestIndexedStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	store := NewIndexedStore(NewJSONStore(path, BackupPolicy{}))
+	entries := tagEntries()
+
+	// Without an index there is nothing to update
+	if err := store.Upsert(entries[0]); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if _, err := os.Stat(SearchIndexPath(path)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Upsert() created the index, stat error = %v", err)
+	}
+
+	index, err := OpenSearchIndex(store, false)
+	if err != nil {
+		t.Fatalf("OpenSearchIndex() error = %v", err)
+	}
+	if index.NoteCount() != 2 {
+		t.Errorf("built an index of %d notes, want 2", index.NoteCount())
+	}
+
+	if err := store.Upsert(entries[2]); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if err := store.Delete(entries[0].ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	index, err = LoadSearchIndex(SearchIndexPath(path))
+	if err != nil {
+		t.Fatalf("LoadSearchIndex() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"Paired on the parser"}, searchContents(index.Search(IndexQuery{Text: "the"}))); diff != "" {
+		t.Errorf("index after the changes mismatch (-want +got):\n%s", diff)
+	}
+
+	// A broken index is removed and rebuilt by the next search
+	if err := os.WriteFile(SearchIndexPath(path), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Upsert(entries[1]); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if _, err := os.Stat(SearchIndexPath(path)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("the broken index was kept, stat error = %v", err)
+	}
+	index, err = OpenSearchIndex(store, true)
+	if err != nil {
+		t.Fatalf("OpenSearchIndex() error = %v", err)
+	}
+	if index.NoteCount() != 3 {
+		t.Errorf("rebuilt an index of %d notes, want 3", index.NoteCount())
+	}
+
+	// A write updates the index for each of its changes
+	if err := store.Write([]Change{{Entry: entries[0]}, {Entry: entries[1], Delete: true}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	index, err = LoadSearchIndex(SearchIndexPath(path))
+	if err != nil {
+		t.Fatalf("LoadSearchIndex() error = %v", err)
+	}
+	if fresh := BuildSearchIndex(mustAll(t, store)); index.NoteCount() != fresh.NoteCount() {
+		t.Errorf("index after the write has %d notes, want %d", index.NoteCount(), fresh.NoteCount())
+	}
+}
+
+func mustAll(t *testing.T, store Store) []JournalEntry {
+	t.Helper()
+	entries, err := store.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
@@ -0,0 +1,153 @@
+package journal
+
+import (
+	"sort"
+	"time"
+)
+
+// Store persists journal entries.
+//
+// Entries are identified by their ID (YYYYMMDD). Implementations return
+// entries sorted by ID, so the last entry of All is always the most recent
+// day. A Store is not safe for concurrent read-modify-write cycles on its own:
+// callers serialise them by holding the lock for Location (see WithLock).
+type Store interface {
+	// Location identifies where the store keeps its data, e.g. a file path.
+	Location() string
+
+	// All returns every entry in the store.
+	All() ([]JournalEntry, error)
+
+	// Get returns the entry with the given ID, or a JournalError wrapping
+	// ErrEntryNotFound when there is none.
+	Get(id string) (*JournalEntry, error)
+
+	// Range returns the entries whose day falls between from and to,
+	// inclusive. An empty range is not an error.
+	Range(from, to time.Time) ([]JournalEntry, error)
+
+	// Upsert inserts entry, or replaces the stored entry with the same ID.
+	Upsert(entry JournalEntry) error
+
+	// Delete removes the entry with the given ID, or returns a JournalError
+	// wrapping ErrEntryNotFound when there is none.
+	Delete(id string) error
+
+	// Write applies changes in order as a single write, for commands that
+	// change several entries: the JSON backend loads, backs up and saves its
+	// file once. When a change cannot be applied, such as the delete of an
+	// entry that is not there, nothing is written.
+	Write(changes []Change) error
+
+	// Close releases any resources held by the store.
+	Close() error
+}
+
+// Storage backends accepted by OpenStore.
+const (
+	BackendJSON    = "json"
+	BackendSQLite  = "sqlite"
+	BackendMonthly = "monthly"
+)
+
+// OpenStore opens the store for the named backend at location. The backup
+// policy only applies to the JSON backend, which rewrites its whole file on
+// every change.
+func OpenStore(backend string, location string, backup BackupPolicy) (Store, error) {
+	switch backend {
+	case BackendJSON, "":
+		return NewJSONStore(location, backup), nil
+	case BackendSQLite:
+		return OpenSQLiteStore(location)
+	case BackendMonthly:
+		return NewMonthlyStore(location), nil
+	default:
+		return nil, ValidationError("storage.backend", "unknown backend '"+backend+"', use json, sqlite or monthly")
+	}
+}
+
+// Change is one change written by Store.Write: Entry is inserted, or
+// replaces the stored entry with the same ID, or with Delete set the entry
+// with the ID of Entry is removed.
+type Change struct {
+	Entry  JournalEntry
+	Delete bool
+}
+
+// applyChanges applies changes in order to entries, which are changed in
+// place where possible, and returns the result.
+func applyChanges(entries []JournalEntry, changes []Change) ([]JournalEntry, error) {
+	for _, change := range changes {
+		_, idx := FetchEntryByID(change.Entry.ID, entries)
+		switch {
+		case change.Delete && idx == -1:
+			return nil, EntryNotFoundError(change.Entry.ID)
+		case change.Delete:
+			entries = append(entries[:idx], entries[idx+1:]...)
+		case idx != -1:
+			entries[idx] = change.Entry
+		default:
+			entries = append(entries, change.Entry)
+		}
+	}
+	return entries, nil
+}
+
+// CopyEntries upserts every entry of src into dst, in a single write, and
+// returns how many entries were copied. Entries already in dst with other IDs
+// are kept.
+func CopyEntries(src, dst Store) (int, error) {
+	entries, err := src.All()
+	if err != nil {
+		return 0, err
+	}
+	changes := make([]Change, 0, len(entries))
+	for _, entry := range entries {
+		changes = append(changes, Change{Entry: entry})
+	}
+	if err := dst.Write(changes); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// CurrentEntry returns the entry that work done at now belongs to: the entry
+// whose day covers now, with the day boundaries taken in the zone the entry
+// was recorded in (see JournalEntry.CoversTime), or, for a shift that runs
+// past midnight, the previous day's entry while it has not ended. When there
+// is neither it returns a JournalError wrapping ErrEntryNotFound for the day
+// of now.
+func CurrentEntry(store Store, now time.Time) (*JournalEntry, error) {
+	// An entry recorded in another zone can be dated a day before or after
+	// now is in its own
+	candidates, err := store.Range(now.AddDate(0, 0, -2), now.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		if candidates[i].CoversTime(now) {
+			return &candidates[i], nil
+		}
+	}
+	for i := len(candidates) - 1; i >= 0; i-- {
+		entry := &candidates[i]
+		loc := entry.Location()
+		if entry.EndTime.IsZero() && entry.ID == DayID(now.In(loc).AddDate(0, 0, -1), loc) {
+			return entry, nil
+		}
+	}
+	return nil, EntryNotFoundError(dayKey(now))
+}
+
+// sortEntriesByID orders entries by day, keeping the relative order of
+// entries that share an ID.
+func sortEntriesByID(entries []JournalEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+}
+
+// dayKey returns the entry ID for the day of t.
+func dayKey(t time.Time) string {
+	return t.Format("20060102")
+}
@@ -0,0 +1,115 @@
+package journal
+
+import "time"
+
+// JSONStore keeps the journal in a single JSON file, the format read by
+// LoadEntries and written by SaveEntries. Every change rewrites the whole
+// file, after taking a backup of it according to the backup policy.
+type JSONStore struct {
+	path   string
+	backup BackupPolicy
+}
+
+// NewJSONStore returns a store for the JSON journal at path. The file is
+// created on first use if it does not exist.
+func NewJSONStore(path string, backup BackupPolicy) *JSONStore {
+	return &JSONStore{path: path, backup: backup}
+}
+
+// Location returns the path of the journal file.
+func (s *JSONStore) Location() string {
+	return s.path
+}
+
+// All returns every entry in the journal file, sorted by ID.
+func (s *JSONStore) All() ([]JournalEntry, error) {
+	entries, err := LoadEntries(s.path)
+	if err != nil {
+		return nil, err
+	}
+	sortEntriesByID(entries)
+	return entries, nil
+}
+
+// Get returns the entry with the given ID.
+func (s *JSONStore) Get(id string) (*JournalEntry, error) {
+	entries, err := LoadEntries(s.path)
+	if err != nil {
+		return nil, err
+	}
+	entry, idx := FetchEntryByID(id, entries)
+	if idx == -1 {
+		return nil, EntryNotFoundError(id)
+	}
+	return entry, nil
+}
+
+// Range returns the entries between from and to, inclusive, sorted by ID.
+func (s *JSONStore) Range(from, to time.Time) ([]JournalEntry, error) {
+	entries, err := s.All()
+	if err != nil {
+		return nil, err
+	}
+
+	fromKey, toKey := dayKey(from), dayKey(to)
+	inRange := []JournalEntry{}
+	for _, entry := range entries {
+		if entry.ID >= fromKey && entry.ID <= toKey {
+			inRange = append(inRange, entry)
+		}
+	}
+	return inRange, nil
+}
+
+// Upsert replaces the entry with the same ID in place, or appends entry to
+// the journal.
+func (s *JSONStore) Upsert(entry JournalEntry) error {
+	entries, err := LoadEntries(s.path)
+	if err != nil {
+		return err
+	}
+
+	if _, idx := FetchEntryByID(entry.ID, entries); idx != -1 {
+		entries[idx] = entry
+	} else {
+		entries = append(entries, entry)
+	}
+	return SaveEntriesWithBackup(entries, s.path, s.backup)
+}
+
+// Delete removes the entry with the given ID from the journal.
+func (s *JSONStore) Delete(id string) error {
+	entries, err := LoadEntries(s.path)
+	if err != nil {
+		return err
+	}
+
+	_, idx := FetchEntryByID(id, entries)
+	if idx == -1 {
+		return EntryNotFoundError(id)
+	}
+	entries = append(entries[:idx], entries[idx+1:]...)
+	return SaveEntriesWithBackup(entries, s.path, s.backup)
+}
+
+// Write applies changes to the journal with one load, one backup and one
+// save, however many entries they touch.
+func (s *JSONStore) Write(changes []Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	entries, err := LoadEntries(s.path)
+	if err != nil {
+		return err
+	}
+	entries, err = applyChanges(entries, changes)
+	if err != nil {
+		return err
+	}
+	return SaveEntriesWithBackup(entries, s.path, s.backup)
+}
+
+// Close is a no-op; the journal file is only open while it is read or written.
+func (s *JSONStore) Close() error {
+	return nil
+}
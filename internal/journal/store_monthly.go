@@ -0,0 +1,227 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// MonthlyStore keeps the journal in one JSON file per month, laid out as
+// YYYY/MM.json under a journal directory. Each file has the same format as a
+// single-file journal. Reads and writes only touch the months they need, so
+// the cost of a command does not grow with the size of the history.
+type MonthlyStore struct {
+	dir string
+}
+
+// NewMonthlyStore returns a store for the per-month journal under dir. The
+// directory and month files are created as entries are written.
+func NewMonthlyStore(dir string) *MonthlyStore {
+	return &MonthlyStore{dir: dir}
+}
+
+// Location returns the journal directory.
+func (s *MonthlyStore) Location() string {
+	return s.dir
+}
+
+// MonthFilename returns the path of the file holding the entries of the
+// month of t.
+func (s *MonthlyStore) MonthFilename(t time.Time) string {
+	return filepath.Join(s.dir, t.Format("2006"), t.Format("01")+".json")
+}
+
+// MonthFiles returns the paths of the existing month files, oldest first.
+func (s *MonthlyStore) MonthFiles() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "[0-9][0-9][0-9][0-9]", "[0-9][0-9].json"))
+	if err != nil {
+		return nil, JournalIOError("list month files of", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// All returns every entry of every month file, sorted by ID.
+func (s *MonthlyStore) All() ([]JournalEntry, error) {
+	files, err := s.MonthFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []JournalEntry{}
+	for _, file := range files {
+		month, err := s.loadMonthFile(file)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, month...)
+	}
+	return entries, nil
+}
+
+// Get returns the entry with the given ID, reading only its month file.
+func (s *MonthlyStore) Get(id string) (*JournalEntry, error) {
+	day, err := time.Parse("20060102", id)
+	if err != nil {
+		return nil, EntryNotFoundError(id)
+	}
+
+	entries, err := s.loadMonthFile(s.MonthFilename(day))
+	if err != nil {
+		return nil, err
+	}
+	entry, idx := FetchEntryByID(id, entries)
+	if idx == -1 {
+		return nil, EntryNotFoundError(id)
+	}
+	return entry, nil
+}
+
+// Range returns the entries between from and to, inclusive, reading only the
+// month files the range covers.
+func (s *MonthlyStore) Range(from, to time.Time) ([]JournalEntry, error) {
+	fromKey, toKey := dayKey(from), dayKey(to)
+	inRange := []JournalEntry{}
+
+	month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for ; !month.After(last); month = month.AddDate(0, 1, 0) {
+		entries, err := s.loadMonthFile(s.MonthFilename(month))
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.ID >= fromKey && entry.ID <= toKey {
+				inRange = append(inRange, entry)
+			}
+		}
+	}
+	return inRange, nil
+}
+
+// Upsert replaces or appends entry in its month file.
+func (s *MonthlyStore) Upsert(entry JournalEntry) error {
+	day, err := time.Parse("20060102", entry.ID)
+	if err != nil {
+		return InvalidEntryError(entry.ID, "id must be a YYYYMMDD date")
+	}
+
+	filename := s.MonthFilename(day)
+	entries, err := s.loadMonthFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if _, idx := FetchEntryByID(entry.ID, entries); idx != -1 {
+		entries[idx] = entry
+	} else {
+		entries = append(entries, entry)
+		sortEntriesByID(entries)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return JournalIOError("create month directory for", err)
+	}
+	return SaveEntries(entries, filename)
+}
+
+// Delete removes the entry with the given ID from its month file. A month
+// file left without entries is removed.
+func (s *MonthlyStore) Delete(id string) error {
+	day, err := time.Parse("20060102", id)
+	if err != nil {
+		return EntryNotFoundError(id)
+	}
+
+	filename := s.MonthFilename(day)
+	entries, err := s.loadMonthFile(filename)
+	if err != nil {
+		return err
+	}
+
+	_, idx := FetchEntryByID(id, entries)
+	if idx == -1 {
+		return EntryNotFoundError(id)
+	}
+	entries = append(entries[:idx], entries[idx+1:]...)
+
+	if len(entries) == 0 {
+		if err := os.Remove(filename); err != nil {
+			return JournalIOError("remove empty month of", err)
+		}
+		return nil
+	}
+	return SaveEntries(entries, filename)
+}
+
+// Write applies changes to the month files they touch, saving each of them
+// once. Every change is applied in memory before any file is written, so a
+// change that cannot be applied leaves all of them as they were.
+func (s *MonthlyStore) Write(changes []Change) error {
+	months := make(map[string][]JournalEntry)
+	var order []string
+	for _, change := range changes {
+		day, err := time.Parse("20060102", change.Entry.ID)
+		if err != nil {
+			if change.Delete {
+				return EntryNotFoundError(change.Entry.ID)
+			}
+			return InvalidEntryError(change.Entry.ID, "id must be a YYYYMMDD date")
+		}
+
+		filename := s.MonthFilename(day)
+		entries, loaded := months[filename]
+		if !loaded {
+			if entries, err = s.loadMonthFile(filename); err != nil {
+				return err
+			}
+			order = append(order, filename)
+		}
+		if months[filename], err = applyChanges(entries, []Change{change}); err != nil {
+			return err
+		}
+	}
+
+	for _, filename := range order {
+		entries := months[filename]
+		if len(entries) == 0 {
+			if err := os.Remove(filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return JournalIOError("remove empty month of", err)
+			}
+			continue
+		}
+		sortEntriesByID(entries)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return JournalIOError("create month directory for", err)
+		}
+		if err := SaveEntries(entries, filename); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close is a no-op; month files are only open while they are read or written.
+func (s *MonthlyStore) Close() error {
+	return nil
+}
+
+// loadMonthFile reads the entries of a month file, sorted by ID. Unlike
+// LoadEntries, a missing file is not created: it simply has no entries.
+func (s *MonthlyStore) loadMonthFile(filename string) ([]JournalEntry, error) {
+	if _, err := os.Stat(filename); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []JournalEntry{}, nil
+		}
+		return nil, JournalIOError("read", err)
+	}
+
+	entries, err := loadJournalFile(filename, s.dir)
+	if err != nil {
+		return nil, err
+	}
+	sortEntriesByID(entries)
+	return entries, nil
+}
@@ -0,0 +1,63 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMonthlyStoreLayout(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "journal")
+	store := NewMonthlyStore(dir)
+
+	for _, id := range []string{"20251231", "20260105", "20260201"} {
+		if err := store.Upsert(JournalEntry{ID: id, Notes: []Note{{Contents: id}}}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	t.Run("entries are split into YYYY/MM.json files", func(t *testing.T) {
+		for _, name := range []string{"2025/12.json", "2026/01.json", "2026/02.json"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+				t.Errorf("Expected month file %s, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("reads only touch the months they need", func(t *testing.T) {
+		// A broken month file only matters to reads that cover that month.
+		if err := os.WriteFile(filepath.Join(dir, "2025", "12.json"), []byte("not json"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		entry, err := store.Get("20260105")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if entry.Notes[0].Contents != "20260105" {
+			t.Errorf("Unexpected entry %+v", entry)
+		}
+
+		entries, err := store.Range(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(entries) != 2 {
+			t.Errorf("Expected 2 entries, got %d", len(entries))
+		}
+
+		if _, err := store.All(); err == nil {
+			t.Error("Expected All to fail on the broken month file")
+		}
+	})
+
+	t.Run("deleting the last entry of a month removes its file", func(t *testing.T) {
+		if err := store.Delete("20260201"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "2026", "02.json")); !os.IsNotExist(err) {
+			t.Errorf("Expected 2026/02.json to be removed, got %v", err)
+		}
+	})
+}
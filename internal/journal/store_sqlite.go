@@ -0,0 +1,335 @@
+package journal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	// Pure Go SQLite driver, registered as "sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema creates the tables of a SQLite journal. Each entry is kept as
+// its JSON encoding keyed by ID, so new entry fields need no schema change.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS entries (
+	id   TEXT PRIMARY KEY,
+	data TEXT NOT NULL
+);
+`
+
+// SQLiteStore keeps the journal in a SQLite database, one row per entry, so
+// changing an entry does not rewrite the others.
+type SQLiteStore struct {
+	path string
+	db   *sql.DB
+}
+
+// OpenSQLiteStore opens the SQLite journal at path, creating the database and
+// its tables if needed. A database written at an older schema version is
+// migrated first, see SQLiteStore.migrate, while holding the journal lock
+// unless this process already holds it.
+func OpenSQLiteStore(path string) (*SQLiteStore, error) {
+	store, err := openSQLiteDatabase(path)
+	if err != nil {
+		return nil, err
+	}
+	version, err := store.schemaVersion()
+	if err == nil && version < SchemaVersion {
+		var report *MigrationReport
+		err = withMigrationLock(path, func() error {
+			var err error
+			report, err = store.migrate(false)
+			return err
+		})
+		if err == nil && report.Migrated() && OnMigrated != nil {
+			OnMigrated(path, report)
+		}
+	}
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// MigrateSQLite brings the SQLite journal at path up to SchemaVersion, see
+// SQLiteStore.migrate. With dryRun set nothing is written, and the report
+// only describes what would change.
+func MigrateSQLite(path string, dryRun bool) (*MigrationReport, error) {
+	store, err := openSQLiteDatabase(path)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+	return store.migrate(dryRun)
+}
+
+// openSQLiteDatabase opens the database at path and creates its tables if
+// needed, whatever its schema version.
+func openSQLiteDatabase(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, JournalIOError("open", err)
+	}
+	// A single connection keeps every statement on the same database handle,
+	// which is all a CLI invocation needs.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, JournalIOError("initialise", err)
+	}
+	_, err = db.Exec(`INSERT OR IGNORE INTO meta (key, value) VALUES ('schema_version', ?)`, SchemaVersion)
+	if err != nil {
+		db.Close()
+		return nil, JournalIOError("initialise", err)
+	}
+
+	return &SQLiteStore{path: path, db: db}, nil
+}
+
+// schemaVersion returns the schema version the database was written at. A
+// database written by a newer version of workday is an error.
+func (s *SQLiteStore) schemaVersion() (int, error) {
+	var value string
+	err := s.db.QueryRow(`SELECT value FROM meta WHERE key = 'schema_version'`).Scan(&value)
+	if err != nil {
+		return 0, JournalIOError("read schema version of", err)
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, MigrationError(0, fmt.Sprintf("invalid schema version '%s'", value), err)
+	}
+	if version > SchemaVersion {
+		return 0, MigrationError(version, fmt.Sprintf("the database was written by a newer version of workday (this one supports up to %d)", SchemaVersion), nil)
+	}
+	return version, nil
+}
+
+// migrate brings a database written by an older version of workday up to
+// SchemaVersion. Its rows go through the same migrations as a JSON journal,
+// as the entries of a single document, and the database file is copied to
+// MigrationBackupFilename before they are rewritten. With dryRun set nothing
+// is written.
+func (s *SQLiteStore) migrate(dryRun bool) (*MigrationReport, error) {
+	version, err := s.schemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	report := &MigrationReport{From: version, To: version}
+	if version == SchemaVersion {
+		return report, nil
+	}
+
+	rows, err := s.db.Query(`SELECT data FROM entries ORDER BY id`)
+	if err != nil {
+		return nil, JournalIOError("read", err)
+	}
+	entries := []interface{}{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			rows.Close()
+			return nil, JournalIOError("read", err)
+		}
+		decoder := json.NewDecoder(strings.NewReader(data))
+		decoder.UseNumber()
+		var entry interface{}
+		if err := decoder.Decode(&entry); err != nil {
+			rows.Close()
+			return nil, JournalIOError("decode entry from", err)
+		}
+		entries = append(entries, entry)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, JournalIOError("read", err)
+	}
+
+	doc := map[string]interface{}{"version": version, "entries": entries}
+	steps, err := runMigrations(doc, version, SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	report.To = SchemaVersion
+	report.Steps = steps
+	migrated, err := documentEntries(doc)
+	if err != nil {
+		return nil, MigrationError(version, "migrations left invalid entries", err)
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	original, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, JournalIOError("read", err)
+	}
+	report.Backup = MigrationBackupFilename(s.path, version)
+	if err := writeMigrationBackup(report.Backup, original); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, JournalIOError("migrate", err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`DELETE FROM entries`); err != nil {
+		return nil, JournalIOError("migrate", err)
+	}
+	for _, entry := range migrated {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return nil, JournalIOError("marshal", err)
+		}
+		id, _ := entry["id"].(string)
+		if _, err := tx.Exec(`INSERT INTO entries (id, data) VALUES (?, ?)`, id, string(data)); err != nil {
+			return nil, JournalIOError("migrate", err)
+		}
+	}
+	if _, err := tx.Exec(`UPDATE meta SET value = ? WHERE key = 'schema_version'`, SchemaVersion); err != nil {
+		return nil, JournalIOError("migrate", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, JournalIOError("migrate", err)
+	}
+	return report, nil
+}
+
+// Location returns the path of the database file.
+func (s *SQLiteStore) Location() string {
+	return s.path
+}
+
+// All returns every entry in the database, sorted by ID.
+func (s *SQLiteStore) All() ([]JournalEntry, error) {
+	return s.query(`SELECT data FROM entries ORDER BY id`)
+}
+
+// Get returns the entry with the given ID.
+func (s *SQLiteStore) Get(id string) (*JournalEntry, error) {
+	var data string
+	err := s.db.QueryRow(`SELECT data FROM entries WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, EntryNotFoundError(id)
+	}
+	if err != nil {
+		return nil, JournalIOError("read", err)
+	}
+
+	var entry JournalEntry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return nil, JournalIOError("decode entry from", err)
+	}
+	return &entry, nil
+}
+
+// Range returns the entries between from and to, inclusive, sorted by ID.
+func (s *SQLiteStore) Range(from, to time.Time) ([]JournalEntry, error) {
+	return s.query(`SELECT data FROM entries WHERE id BETWEEN ? AND ? ORDER BY id`, dayKey(from), dayKey(to))
+}
+
+// Upsert inserts entry or replaces the row with the same ID.
+func (s *SQLiteStore) Upsert(entry JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return JournalIOError("marshal", err)
+	}
+
+	_, err = s.db.Exec(`INSERT INTO entries (id, data) VALUES (?, ?)
+		ON CONFLICT (id) DO UPDATE SET data = excluded.data`, entry.ID, string(data))
+	if err != nil {
+		return JournalIOError("write", err)
+	}
+	return nil
+}
+
+// Delete removes the entry with the given ID.
+func (s *SQLiteStore) Delete(id string) error {
+	result, err := s.db.Exec(`DELETE FROM entries WHERE id = ?`, id)
+	if err != nil {
+		return JournalIOError("write", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return EntryNotFoundError(id)
+	}
+	return nil
+}
+
+// Write applies changes in a single transaction.
+func (s *SQLiteStore) Write(changes []Change) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return JournalIOError("write", err)
+	}
+	defer tx.Rollback()
+
+	for _, change := range changes {
+		if change.Delete {
+			result, err := tx.Exec(`DELETE FROM entries WHERE id = ?`, change.Entry.ID)
+			if err != nil {
+				return JournalIOError("write", err)
+			}
+			if n, err := result.RowsAffected(); err == nil && n == 0 {
+				return EntryNotFoundError(change.Entry.ID)
+			}
+			continue
+		}
+
+		data, err := json.Marshal(change.Entry)
+		if err != nil {
+			return JournalIOError("marshal", err)
+		}
+		_, err = tx.Exec(`INSERT INTO entries (id, data) VALUES (?, ?)
+			ON CONFLICT (id) DO UPDATE SET data = excluded.data`, change.Entry.ID, string(data))
+		if err != nil {
+			return JournalIOError("write", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return JournalIOError("write", err)
+	}
+	return nil
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) query(query string, args ...interface{}) ([]JournalEntry, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, JournalIOError("read", err)
+	}
+	defer rows.Close()
+
+	entries := []JournalEntry{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, JournalIOError("read", err)
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, JournalIOError("decode entry from", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, JournalIOError("read", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,318 @@
+package journal
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// storeBackends builds a fresh, empty store of every backend for contract tests.
+var storeBackends = []struct {
+	name string
+	open func(t *testing.T) Store
+}{
+	{
+		name: "json",
+		open: func(t *testing.T) Store {
+			return NewJSONStore(filepath.Join(t.TempDir(), "journal.json"), BackupPolicy{})
+		},
+	},
+	{
+		name: "sqlite",
+		open: func(t *testing.T) Store {
+			store, err := OpenSQLiteStore(filepath.Join(t.TempDir(), "journal.db"))
+			if err != nil {
+				t.Fatalf("Expected no error opening store, got %v", err)
+			}
+			return store
+		},
+	},
+	{
+		name: "monthly",
+		open: func(t *testing.T) Store {
+			return NewMonthlyStore(filepath.Join(t.TempDir(), "journal"))
+		},
+	},
+}
+
+func storeTestEntry(day int, note string) JournalEntry {
+	start := time.Date(2026, 4, day, 9, 0, 0, 0, time.UTC)
+	return JournalEntry{
+		ID:        start.Format("20060102"),
+		StartTime: start,
+		EndTime:   start.Add(8 * time.Hour),
+		Notes:     []Note{{Contents: note}},
+	}
+}
+
+func TestStoreContract(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			store := backend.open(t)
+			defer store.Close()
+
+			// Inserted out of order on purpose
+			for _, day := range []int{3, 1, 2} {
+				if err := store.Upsert(storeTestEntry(day, "first")); err != nil {
+					t.Fatalf("Expected no error on upsert, got %v", err)
+				}
+			}
+
+			t.Run("All returns every entry sorted by ID", func(t *testing.T) {
+				entries, err := store.All()
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				var ids []string
+				for _, entry := range entries {
+					ids = append(ids, entry.ID)
+				}
+				if diff := cmp.Diff([]string{"20260401", "20260402", "20260403"}, ids); diff != "" {
+					t.Errorf("IDs mismatch (-want +got):\n%s", diff)
+				}
+			})
+
+			t.Run("Upsert replaces an entry with the same ID", func(t *testing.T) {
+				if err := store.Upsert(storeTestEntry(2, "second")); err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				entry, err := store.Get("20260402")
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if diff := cmp.Diff(storeTestEntry(2, "second"), *entry); diff != "" {
+					t.Errorf("Entry mismatch (-want +got):\n%s", diff)
+				}
+				entries, _ := store.All()
+				if len(entries) != 3 {
+					t.Errorf("Expected 3 entries after replacing one, got %d", len(entries))
+				}
+			})
+
+			t.Run("Range is inclusive on both days", func(t *testing.T) {
+				entries, err := store.Range(time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC))
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if len(entries) != 2 || entries[0].ID != "20260402" || entries[1].ID != "20260403" {
+					t.Errorf("Expected entries 20260402 and 20260403, got %+v", entries)
+				}
+
+				empty, err := store.Range(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC))
+				if err != nil {
+					t.Fatalf("Expected no error for an empty range, got %v", err)
+				}
+				if len(empty) != 0 {
+					t.Errorf("Expected no entries, got %d", len(empty))
+				}
+			})
+
+			t.Run("Delete removes the entry", func(t *testing.T) {
+				if err := store.Delete("20260401"); err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if _, err := store.Get("20260401"); !errors.Is(err, ErrEntryNotFound) {
+					t.Errorf("Expected ErrEntryNotFound after delete, got %v", err)
+				}
+				if err := store.Delete("20260401"); !errors.Is(err, ErrEntryNotFound) {
+					t.Errorf("Expected ErrEntryNotFound deleting twice, got %v", err)
+				}
+			})
+		})
+	}
+}
+
+func TestStoreWrite(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			store := backend.open(t)
+			defer store.Close()
+			for _, day := range []int{1, 2} {
+				if err := store.Upsert(storeTestEntry(day, "first")); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := store.Write([]Change{
+				{Entry: storeTestEntry(2, "second")},
+				{Entry: storeTestEntry(3, "first")},
+				{Entry: JournalEntry{ID: "20260401"}, Delete: true},
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			want := []JournalEntry{storeTestEntry(2, "second"), storeTestEntry(3, "first")}
+			got, _ := store.All()
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Entries mismatch (-want +got):\n%s", diff)
+			}
+
+			// A change that cannot be applied leaves the others unwritten
+			err = store.Write([]Change{
+				{Entry: storeTestEntry(4, "first")},
+				{Entry: JournalEntry{ID: "20260401"}, Delete: true},
+			})
+			if !errors.Is(err, ErrEntryNotFound) {
+				t.Errorf("Expected ErrEntryNotFound, got %v", err)
+			}
+			got, _ = store.All()
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Entries after a failed write mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJSONStoreWriteTakesOneBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	policy := BackupPolicy{Count: 3}
+	store := NewJSONStore(path, policy)
+	if err := store.Upsert(storeTestEntry(1, "before")); err != nil {
+		t.Fatal(err)
+	}
+
+	var changes []Change
+	for day := 1; day <= 10; day++ {
+		changes = append(changes, Change{Entry: storeTestEntry(day, "after")})
+	}
+	if err := store.Write(changes); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	backups, err := ListBackups(path, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The first upsert backed up the empty journal, the write the one before it
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups, got %d", len(backups))
+	}
+	entries, err := LoadEntries(backups[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]JournalEntry{storeTestEntry(1, "before")}, entries); diff != "" {
+		t.Errorf("Backup mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCopyEntries(t *testing.T) {
+	dir := t.TempDir()
+	src := NewJSONStore(filepath.Join(dir, "journal.json"), BackupPolicy{})
+	for _, day := range []int{1, 2} {
+		if err := src.Upsert(storeTestEntry(day, "note")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dst, err := OpenSQLiteStore(filepath.Join(dir, "journal.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	copied, err := CopyEntries(src, dst)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if copied != 2 {
+		t.Errorf("Expected 2 entries copied, got %d", copied)
+	}
+
+	want, _ := src.All()
+	got, err := dst.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Entries mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOpenStoreUnknownBackend(t *testing.T) {
+	if _, err := OpenStore("csv", "journal.csv", BackupPolicy{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation, got %v", err)
+	}
+}
+
+func TestCurrentEntry(t *testing.T) {
+	store := NewJSONStore(filepath.Join(t.TempDir(), "journal.json"), BackupPolicy{})
+	night := JournalEntry{
+		ID:        "20260331",
+		StartTime: time.Date(2026, 3, 31, 22, 0, 0, 0, time.UTC),
+	}
+	if err := store.Upsert(night); err != nil {
+		t.Fatal(err)
+	}
+	afterMidnight := time.Date(2026, 4, 1, 2, 0, 0, 0, time.UTC)
+
+	entry, err := CurrentEntry(store, afterMidnight)
+	if err != nil {
+		t.Fatalf("Expected the open night shift, got error %v", err)
+	}
+	if entry.ID != night.ID {
+		t.Errorf("Expected entry %s, got %s", night.ID, entry.ID)
+	}
+
+	night.EndTime = afterMidnight
+	if err := store.Upsert(night); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CurrentEntry(store, afterMidnight); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("Expected ErrEntryNotFound once the shift ended, got %v", err)
+	}
+
+	today := storeTestEntry(1, "day shift")
+	if err := store.Upsert(today); err != nil {
+		t.Fatal(err)
+	}
+	entry, err = CurrentEntry(store, afterMidnight)
+	if err != nil || entry.ID != today.ID {
+		t.Errorf("Expected today's entry, got %+v, %v", entry, err)
+	}
+}
+
+func TestCurrentEntryUsesRecordedZone(t *testing.T) {
+	store := NewJSONStore(filepath.Join(t.TempDir(), "journal.json"), BackupPolicy{})
+	entries := []JournalEntry{
+		{ID: "20260331", Timezone: "America/New_York", StartTime: time.Date(2026, 3, 31, 13, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 3, 31, 21, 0, 0, 0, time.UTC)},
+		{ID: "20260406", Timezone: "Asia/Tokyo", StartTime: time.Date(2026, 4, 5, 23, 0, 0, 0, time.UTC)},
+	}
+	for _, entry := range entries {
+		if err := store.Upsert(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		wantID string
+	}{
+		// 02:00 UTC on April 1 is still March 31 in New York
+		{name: "day of the New York entry", now: time.Date(2026, 4, 1, 2, 0, 0, 0, time.UTC), wantID: "20260331"},
+		// 20:00 UTC on April 5 is already April 6 in Tokyo
+		{name: "day of the Tokyo entry", now: time.Date(2026, 4, 5, 20, 0, 0, 0, time.UTC), wantID: "20260406"},
+		// 16:00 UTC on April 6 is April 7 in Tokyo, where the entry is still open
+		{name: "open Tokyo entry past midnight", now: time.Date(2026, 4, 6, 16, 0, 0, 0, time.UTC), wantID: "20260406"},
+		{name: "no entry", now: time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := CurrentEntry(store, tt.now)
+			if tt.wantID == "" {
+				if !errors.Is(err, ErrEntryNotFound) {
+					t.Errorf("CurrentEntry() = %+v, %v, want ErrEntryNotFound", entry, err)
+				}
+				return
+			}
+			if err != nil || entry.ID != tt.wantID {
+				t.Errorf("CurrentEntry() = %+v, %v, want entry %s", entry, err, tt.wantID)
+			}
+		})
+	}
+}
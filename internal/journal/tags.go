@@ -0,0 +1,135 @@
+package journal
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TagStat is how a tag has been used in the notes of a set of entries. Tags
+// are compared ignoring case; Tag keeps the spelling of its first use.
+type TagStat struct {
+	Tag   string
+	Count int       // notes carrying the tag
+	First time.Time // start of the first entry using the tag
+	Last  time.Time // start of the last entry using the tag
+	// Weekly counts the notes per ISO week, keyed by FormatISOWeek
+	Weekly map[string]int
+}
+
+// Trend returns the weekly counts of the tag for the count weeks ending with
+// the week of until, oldest first.
+func (s TagStat) Trend(until time.Time, count int) []int {
+	trend := make([]int, count)
+	for i := range trend {
+		trend[i] = s.Weekly[FormatISOWeek(until.AddDate(0, 0, -7*(count-1-i)))]
+	}
+	return trend
+}
+
+// SummarizeTags counts the tags of the notes of the given entries, most used
+// first and by name for tags used equally often.
+func SummarizeTags(entries []JournalEntry) []TagStat {
+	byTag := make(map[string]*TagStat)
+	for _, entry := range entries {
+		week := FormatISOWeek(entry.StartTime)
+		for _, note := range entry.Notes {
+			for _, tag := range uniqueTags(note.Tags) {
+				key := strings.ToLower(tag)
+				stat, ok := byTag[key]
+				if !ok {
+					stat = &TagStat{Tag: tag, First: entry.StartTime, Weekly: make(map[string]int)}
+					byTag[key] = stat
+				}
+				stat.Count++
+				stat.Weekly[week]++
+				if entry.StartTime.Before(stat.First) {
+					stat.First = entry.StartTime
+				}
+				if entry.StartTime.After(stat.Last) {
+					stat.Last = entry.StartTime
+				}
+			}
+		}
+	}
+
+	stats := make([]TagStat, 0, len(byTag))
+	for _, stat := range byTag {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return strings.ToLower(stats[i].Tag) < strings.ToLower(stats[j].Tag)
+	})
+	return stats
+}
+
+// uniqueTags drops the repeated tags of a note, ignoring case, so a note
+// counts once for each of its tags.
+func uniqueTags(tags []string) []string {
+	var unique []string
+	for _, tag := range tags {
+		if tag != "" && !containsFold(unique, tag) {
+			unique = append(unique, tag)
+		}
+	}
+	return unique
+}
+
+// NoteQuery selects notes by their contents and tags. Zero fields match
+// every note.
+type NoteQuery struct {
+	Text    string         // contained in the contents, ignoring case
+	Pattern *regexp.Regexp // matching the contents
+	Tags    []string       // tags the note must carry, ignoring case
+	AnyTag  bool           // one of Tags is enough, instead of all of them
+}
+
+// Matches reports whether note is selected by the query.
+func (q NoteQuery) Matches(note Note) bool {
+	if q.Text != "" && !strings.Contains(strings.ToLower(note.Contents), strings.ToLower(q.Text)) {
+		return false
+	}
+	if q.Pattern != nil && !q.Pattern.MatchString(note.Contents) {
+		return false
+	}
+	if len(q.Tags) == 0 {
+		return true
+	}
+	for _, tag := range q.Tags {
+		has := containsFold(note.Tags, tag)
+		if has && q.AnyTag {
+			return true
+		}
+		if !has && !q.AnyTag {
+			return false
+		}
+	}
+	return !q.AnyTag
+}
+
+// NoteMatch is a note found by SearchNotes, with the entry it belongs to.
+type NoteMatch struct {
+	EntryID string
+	Date    time.Time // start of the entry
+	Index   int       // position of the note in the entry
+	Note    Note
+	Score   float64 // relevance in a SearchIndex search, 0 otherwise
+}
+
+// SearchNotes returns the notes of the given entries selected by query, in
+// the order of the entries.
+func SearchNotes(entries []JournalEntry, query NoteQuery) []NoteMatch {
+	var matches []NoteMatch
+	for _, entry := range entries {
+		for i, note := range entry.Notes {
+			if query.Matches(note) {
+				matches = append(matches, NoteMatch{EntryID: entry.ID, Date: entry.StartTime, Index: i, Note: note})
+			}
+		}
+	}
+	return matches
+}
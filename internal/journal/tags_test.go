@@ -0,0 +1,83 @@
+package journal
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func tagEntries() []JournalEntry {
+	day := func(d int) time.Time { return time.Date(2026, 4, d, 9, 0, 0, 0, time.UTC) }
+	return []JournalEntry{
+		{ID: "20260401", StartTime: day(1), Notes: []Note{
+			{Contents: "Planned the sprint", Tags: []string{"team", "planning"}},
+			{Contents: "Fixed the login bug", Tags: []string{"progress", "Progress"}},
+		}},
+		{ID: "20260407", StartTime: day(7), Notes: []Note{
+			{Contents: "Shipped the release", Tags: []string{"progress", "team"}},
+			{Contents: "Lunch with the team"},
+		}},
+		{ID: "20260408", StartTime: day(8), Notes: []Note{
+			{Contents: "Paired on the parser", Tags: []string{"PROGRESS"}},
+		}},
+	}
+}
+
+func TestSummarizeTags(t *testing.T) {
+	stats := SummarizeTags(tagEntries())
+
+	type row struct {
+		Tag         string
+		Count       int
+		First, Last string
+		Weekly      map[string]int
+	}
+	var got []row
+	for _, stat := range stats {
+		got = append(got, row{stat.Tag, stat.Count, stat.First.Format("01-02"), stat.Last.Format("01-02"), stat.Weekly})
+	}
+	want := []row{
+		{"progress", 3, "04-01", "04-08", map[string]int{"2026-W14": 1, "2026-W15": 2}},
+		{"team", 2, "04-01", "04-07", map[string]int{"2026-W14": 1, "2026-W15": 1}},
+		{"planning", 1, "04-01", "04-01", map[string]int{"2026-W14": 1}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SummarizeTags() mismatch (-want +got):\n%s", diff)
+	}
+
+	trend := stats[0].Trend(time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC), 4)
+	if diff := cmp.Diff([]int{0, 1, 2, 0}, trend); diff != "" {
+		t.Errorf("Trend() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchNotes(t *testing.T) {
+	tests := []struct {
+		name  string
+		query NoteQuery
+		want  []string
+	}{
+		{name: "everything", query: NoteQuery{}, want: []string{"Planned the sprint", "Fixed the login bug", "Shipped the release", "Lunch with the team", "Paired on the parser"}},
+		{name: "text ignores case", query: NoteQuery{Text: "THE TEAM"}, want: []string{"Lunch with the team"}},
+		{name: "all tags", query: NoteQuery{Tags: []string{"team", "progress"}}, want: []string{"Shipped the release"}},
+		{name: "any tag", query: NoteQuery{Tags: []string{"planning", "progress"}, AnyTag: true}, want: []string{"Planned the sprint", "Fixed the login bug", "Shipped the release", "Paired on the parser"}},
+		{name: "tags ignore case", query: NoteQuery{Tags: []string{"Progress"}}, want: []string{"Fixed the login bug", "Shipped the release", "Paired on the parser"}},
+		{name: "pattern", query: NoteQuery{Pattern: regexp.MustCompile(`^(Fixed|Paired)\b`)}, want: []string{"Fixed the login bug", "Paired on the parser"}},
+		{name: "pattern and tag", query: NoteQuery{Pattern: regexp.MustCompile(`release|parser`), Tags: []string{"team"}}, want: []string{"Shipped the release"}},
+		{name: "no match", query: NoteQuery{Tags: []string{"missing"}}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, match := range SearchNotes(tagEntries(), tt.query) {
+				got = append(got, match.Note.Contents)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SearchNotes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -260,6 +260,36 @@ func TestJournalEntryStopTimeSegment(t *testing.T) {
 	})
 }
 
+func TestJournalEntryStopTimeSegmentAt(t *testing.T) {
+	startTime := time.Date(2025, 10, 27, 9, 0, 0, 0, time.UTC)
+
+	t.Run("stops segment at the given time", func(t *testing.T) {
+		entry := NewJournalEntry()
+		entry.AddTimeSegment(TimeSegment{ID: "1", StartTime: startTime, Project: "project1", Task: "task1"})
+
+		stopTime := startTime.Add(90 * time.Minute)
+		if err := entry.StopTimeSegmentAt("1", stopTime); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !entry.TimeSegments[0].EndTime.Equal(stopTime) {
+			t.Errorf("Expected EndTime %v, got %v", stopTime, entry.TimeSegments[0].EndTime)
+		}
+	})
+
+	t.Run("rejects end time before start time", func(t *testing.T) {
+		entry := NewJournalEntry()
+		entry.AddTimeSegment(TimeSegment{ID: "1", StartTime: startTime, Project: "project1", Task: "task1"})
+
+		err := entry.StopTimeSegmentAt("1", startTime.Add(-time.Minute))
+		if err == nil {
+			t.Fatal("Expected error when stopping before start time")
+		}
+		if !entry.TimeSegments[0].IsActive() {
+			t.Error("Expected segment to remain active after rejected stop")
+		}
+	})
+}
+
 func TestJournalEntryGetTimeSegmentsByProject(t *testing.T) {
 	entry := NewJournalEntry()
 	startTime := time.Date(2025, 10, 27, 9, 0, 0, 0, time.UTC)
@@ -0,0 +1,142 @@
+package journal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// LoadTimezone returns the location for an IANA zone name such as
+// "Europe/Berlin". An empty name is the local zone of the process.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ValidationError("timezone", "unknown timezone "+name+": "+err.Error())
+	}
+	return loc, nil
+}
+
+// LocationName returns the IANA name of loc. For the local zone, whose Go name
+// is just "Local", the name is taken from $TZ or the /etc/localtime link; it
+// is empty when neither names a zone that matches the local one.
+func LocationName(loc *time.Location) string {
+	if loc != time.Local {
+		return loc.String()
+	}
+
+	name := strings.TrimPrefix(os.Getenv("TZ"), ":")
+	if name == "" {
+		target, err := filepath.EvalSymlinks("/etc/localtime")
+		if err != nil {
+			return ""
+		}
+		i := strings.Index(target, "zoneinfo/")
+		if i == -1 {
+			return ""
+		}
+		name = target[i+len("zoneinfo/"):]
+	}
+
+	named, err := time.LoadLocation(name)
+	if err != nil || !sameOffsets(named, loc) {
+		return ""
+	}
+	return name
+}
+
+// sameOffsets reports whether a and b have the same UTC offset in both
+// winter and summer of the current year.
+func sameOffsets(a, b *time.Location) bool {
+	year := time.Now().Year()
+	for _, month := range []time.Month{time.January, time.July} {
+		at := time.Date(year, month, 1, 12, 0, 0, 0, time.UTC)
+		_, offsetA := at.In(a).Zone()
+		_, offsetB := at.In(b).Zone()
+		if offsetA != offsetB {
+			return false
+		}
+	}
+	return true
+}
+
+// Location returns the zone the entry was recorded in. Entries from before
+// zones were recorded, or with a zone this system does not know, use the
+// local zone.
+func (j *JournalEntry) Location() *time.Location {
+	if j.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(j.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// DayID returns the entry ID of the day t falls on in loc.
+func DayID(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("20060102")
+}
+
+// CoversTime reports whether t falls on the entry's day, with the day
+// boundaries taken in the zone the entry was recorded in.
+func (j *JournalEntry) CoversTime(t time.Time) bool {
+	return j.ID == DayID(t, j.Location())
+}
+
+// UnmarshalJSON decodes an entry and moves its times into the zone it was
+// recorded in, so they print as the wall clock times of that zone wherever the
+// journal is read.
+func (j *JournalEntry) UnmarshalJSON(data []byte) error {
+	type plain JournalEntry
+	if err := json.Unmarshal(data, (*plain)(j)); err != nil {
+		return err
+	}
+	if j.Timezone != "" {
+		j.localize(j.Location())
+	}
+	return nil
+}
+
+// localize moves every time of the entry into loc. The instants themselves
+// do not change.
+func (j *JournalEntry) localize(loc *time.Location) {
+	localizeValue(reflect.ValueOf(j).Elem(), loc)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// localizeValue moves every time.Time in v, walking its exported fields,
+// slices and pointers, into loc, so times added to an entry later are not
+// missed.
+func localizeValue(v reflect.Value, loc *time.Location) {
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == timeType {
+			// A zero time would no longer encode as the zero time
+			if t := v.Interface().(time.Time); !t.IsZero() {
+				v.Set(reflect.ValueOf(t.In(loc)))
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				localizeValue(v.Field(i), loc)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			localizeValue(v.Index(i), loc)
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			localizeValue(v.Elem(), loc)
+		}
+	}
+}
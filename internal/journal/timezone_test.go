@@ -0,0 +1,170 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	if loc, err := LoadTimezone(""); err != nil || loc != time.Local {
+		t.Errorf("LoadTimezone(\"\") = %v, %v, want the local zone", loc, err)
+	}
+	if loc, err := LoadTimezone("Asia/Tokyo"); err != nil || loc.String() != "Asia/Tokyo" {
+		t.Errorf("LoadTimezone(Asia/Tokyo) = %v, %v", loc, err)
+	}
+	if _, err := LoadTimezone("Mars/Olympus_Mons"); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation for an unknown zone, got %v", err)
+	}
+}
+
+func TestUnmarshalEntryUsesRecordedZone(t *testing.T) {
+	data := []byte(`{"id":"20260406","timezone":"Asia/Tokyo","start_time":"2026-04-06T00:30:00Z","end_time":"0001-01-01T00:00:00Z",` +
+		`"sessions":[{"start_time":"2026-04-06T00:30:00Z","end_time":"0001-01-01T00:00:00Z"}],` +
+		`"notes":[{"Contents":"Standup","created_at":"2026-04-06T01:00:00Z","updated_at":"0001-01-01T00:00:00Z"}]}`)
+
+	var entry JournalEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := entry.StartTime.Format("15:04"); got != "09:30" {
+		t.Errorf("StartTime = %s, want 09:30 Tokyo time", got)
+	}
+	if !entry.EndTime.IsZero() || entry.OpenSession() == nil {
+		t.Errorf("expected the entry to stay open, got %+v", entry)
+	}
+	if note := entry.Notes[0]; note.CreatedAt.Format("15:04") != "10:00" || !note.UpdatedAt.IsZero() {
+		t.Errorf("note times = %v, %v, want 10:00 Tokyo time and zero", note.CreatedAt, note.UpdatedAt)
+	}
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again JournalEntry
+	if err := json.Unmarshal(encoded, &again); err != nil || !again.EndTime.IsZero() || !again.StartTime.Equal(entry.StartTime) {
+		t.Errorf("entry did not survive a round trip: %s", encoded)
+	}
+}
+
+// setEveryTime sets every time.Time reachable from v to at, growing empty
+// slices to one element so the times inside them are set too, and returns
+// the paths of the times it set.
+func setEveryTime(v reflect.Value, at time.Time, path string) []string {
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == timeType {
+			v.Set(reflect.ValueOf(at))
+			return []string{path}
+		}
+		var paths []string
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Type().Field(i); field.IsExported() {
+				paths = append(paths, setEveryTime(v.Field(i), at, path+"."+field.Name)...)
+			}
+		}
+		return paths
+	case reflect.Slice:
+		if v.Len() == 0 {
+			v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		}
+		return setEveryTime(v.Index(0), at, path+"[0]")
+	}
+	return nil
+}
+
+// everyTime returns every time.Time reachable from v by its path.
+func everyTime(v reflect.Value, path string, times map[string]time.Time) {
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == timeType {
+			times[path] = v.Interface().(time.Time)
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Type().Field(i); field.IsExported() {
+				everyTime(v.Field(i), path+"."+field.Name, times)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			everyTime(v.Index(i), fmt.Sprintf("%s[%d]", path, i), times)
+		}
+	}
+}
+
+// TestUnmarshalEntryLocalizesEveryTime fails when a time field is added to an
+// entry that is not moved into its zone when it is read.
+func TestUnmarshalEntryLocalizesEveryTime(t *testing.T) {
+	entry := JournalEntry{ID: "20260406", Timezone: "Asia/Tokyo"}
+	at := time.Date(2026, 4, 6, 0, 30, 0, 0, time.UTC)
+	paths := setEveryTime(reflect.ValueOf(&entry).Elem(), at, "entry")
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded JournalEntry
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	times := make(map[string]time.Time)
+	everyTime(reflect.ValueOf(decoded), "entry", times)
+	for _, path := range paths {
+		got, ok := times[path]
+		switch {
+		case !ok:
+			t.Errorf("%s did not survive a round trip", path)
+		case !got.Equal(at):
+			t.Errorf("%s = %v, want %v", path, got, at)
+		case got.Location().String() != "Asia/Tokyo":
+			t.Errorf("%s is in %s, want Asia/Tokyo", path, got.Location())
+		}
+	}
+}
+
+func TestFetchEntryForTime(t *testing.T) {
+	entries := []JournalEntry{
+		{ID: "20260405", Timezone: "Asia/Tokyo"},
+		{ID: "20260406", Timezone: "America/New_York"},
+	}
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		wantIdx int
+	}{
+		// 20:00 UTC on April 4 is already April 5 in Tokyo, and 02:00 UTC on
+		// April 7 is still April 6 in New York
+		{name: "day boundary of the Tokyo entry", at: time.Date(2026, 4, 4, 20, 0, 0, 0, time.UTC), wantIdx: 0},
+		{name: "day boundary of the New York entry", at: time.Date(2026, 4, 7, 2, 0, 0, 0, time.UTC), wantIdx: 1},
+		{name: "no entry", at: time.Date(2026, 4, 5, 20, 0, 0, 0, time.UTC), wantIdx: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, idx := FetchEntryForTime(tt.at, entries); idx != tt.wantIdx {
+				t.Errorf("FetchEntryForTime() index = %d, want %d", idx, tt.wantIdx)
+			}
+		})
+	}
+}
+
+func TestNewJournalEntryIn(t *testing.T) {
+	loc, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Skip("zone database not available")
+	}
+
+	entry := NewJournalEntryIn(loc)
+	if entry.Timezone != "Pacific/Auckland" {
+		t.Errorf("Timezone = %q, want Pacific/Auckland", entry.Timezone)
+	}
+	if want := DayID(entry.StartTime, loc); entry.ID != want {
+		t.Errorf("ID = %s, want the day in Auckland %s", entry.ID, want)
+	}
+}
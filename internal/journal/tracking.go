@@ -0,0 +1,115 @@
+package journal
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeAllocation is the tracked time spent on a client, project or task.
+// Fields that are not part of the grouping level are left empty, so a
+// client-level allocation only carries Client and Duration.
+type TimeAllocation struct {
+	Client   string
+	Project  string
+	Task     string
+	Duration time.Duration
+}
+
+// TrackedTimeSummary aggregates the time segments of a set of entries.
+//
+// Tasks holds one allocation per client/project/task combination, sorted by
+// client, project and task. WorkTime is the sum of TotalWorkTime for every
+// entry, and Untracked is the part of that work time not covered by any
+// completed segment, summed per entry and never negative.
+type TrackedTimeSummary struct {
+	Tasks     []TimeAllocation
+	Tracked   time.Duration
+	WorkTime  time.Duration
+	Untracked time.Duration
+}
+
+// SummarizeTimeSegments aggregates the completed time segments of the given
+// entries by client, project and task. Active segments have no duration yet
+// and are ignored.
+func SummarizeTimeSegments(entries []JournalEntry) TrackedTimeSummary {
+	var summary TrackedTimeSummary
+	byTask := make(map[TimeAllocation]time.Duration)
+
+	for _, entry := range entries {
+		var entryTracked time.Duration
+		for _, segment := range entry.TimeSegments {
+			if segment.IsActive() {
+				continue
+			}
+			key := TimeAllocation{Client: segment.GetClient(), Project: segment.Project, Task: segment.Task}
+			byTask[key] += segment.Duration()
+			entryTracked += segment.Duration()
+		}
+		summary.Tracked += entryTracked
+
+		workTime := entry.TotalWorkTime()
+		summary.WorkTime += workTime
+		if workTime > entryTracked {
+			summary.Untracked += workTime - entryTracked
+		}
+	}
+
+	for key, duration := range byTask {
+		key.Duration = duration
+		summary.Tasks = append(summary.Tasks, key)
+	}
+	sortAllocations(summary.Tasks)
+
+	return summary
+}
+
+// ByClient rolls the task allocations up to one allocation per client.
+func (s TrackedTimeSummary) ByClient() []TimeAllocation {
+	return s.rollUp(func(a TimeAllocation) TimeAllocation {
+		return TimeAllocation{Client: a.Client}
+	})
+}
+
+// ByProject rolls the task allocations up to one allocation per client and project.
+func (s TrackedTimeSummary) ByProject() []TimeAllocation {
+	return s.rollUp(func(a TimeAllocation) TimeAllocation {
+		return TimeAllocation{Client: a.Client, Project: a.Project}
+	})
+}
+
+// Share returns d as a percentage of the total tracked time, or 0 when nothing
+// was tracked.
+func (s TrackedTimeSummary) Share(d time.Duration) float64 {
+	if s.Tracked == 0 {
+		return 0
+	}
+	return float64(d) / float64(s.Tracked) * 100
+}
+
+func (s TrackedTimeSummary) rollUp(keyFn func(TimeAllocation) TimeAllocation) []TimeAllocation {
+	totals := make(map[TimeAllocation]time.Duration)
+	for _, allocation := range s.Tasks {
+		totals[keyFn(allocation)] += allocation.Duration
+	}
+
+	allocations := make([]TimeAllocation, 0, len(totals))
+	for key, duration := range totals {
+		key.Duration = duration
+		allocations = append(allocations, key)
+	}
+	sortAllocations(allocations)
+	return allocations
+}
+
+func sortAllocations(allocations []TimeAllocation) {
+	sort.Slice(allocations, func(i, j int) bool {
+		a, b := allocations[i], allocations[j]
+		if a.Client != b.Client {
+			return a.Client < b.Client
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return a.Task < b.Task
+	})
+}
@@ -0,0 +1,97 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummarizeTimeSegments(t *testing.T) {
+	day := func(d, h, m int) time.Time {
+		return time.Date(2026, 3, d, h, m, 0, 0, time.UTC)
+	}
+
+	entries := []JournalEntry{
+		{
+			ID:        "20260302",
+			StartTime: day(2, 9, 0),
+			EndTime:   day(2, 18, 0),
+			Breaks:    []Break{{StartTime: day(2, 12, 0), EndTime: day(2, 13, 0), Reason: "lunch"}},
+			TimeSegments: []TimeSegment{
+				{ID: "1", StartTime: day(2, 9, 0), EndTime: day(2, 12, 0), Client: "acme", Project: "website", Task: "landing"},
+				{ID: "2", StartTime: day(2, 13, 0), EndTime: day(2, 15, 0), Project: "internal", Task: "planning"},
+			},
+		},
+		{
+			ID:        "20260303",
+			StartTime: day(3, 9, 0),
+			EndTime:   day(3, 11, 0),
+			TimeSegments: []TimeSegment{
+				{ID: "1", StartTime: day(3, 9, 0), EndTime: day(3, 10, 0), Client: "acme", Project: "website", Task: "landing"},
+				{ID: "2", StartTime: day(3, 10, 0), EndTime: day(3, 11, 0), Client: "acme", Project: "website", Task: "review"},
+			},
+		},
+		{
+			// Ongoing day with an active segment: neither contributes.
+			ID:        "20260304",
+			StartTime: day(4, 9, 0),
+			TimeSegments: []TimeSegment{
+				{ID: "1", StartTime: day(4, 9, 0), Client: "acme", Project: "website", Task: "landing"},
+			},
+		},
+	}
+
+	summary := SummarizeTimeSegments(entries)
+
+	t.Run("tasks are aggregated and sorted", func(t *testing.T) {
+		expected := []TimeAllocation{
+			{Client: "acme", Project: "website", Task: "landing", Duration: 4 * time.Hour},
+			{Client: "acme", Project: "website", Task: "review", Duration: time.Hour},
+			{Client: "general", Project: "internal", Task: "planning", Duration: 2 * time.Hour},
+		}
+		if diff := cmp.Diff(expected, summary.Tasks); diff != "" {
+			t.Errorf("Tasks mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("totals account for untracked work time", func(t *testing.T) {
+		if summary.Tracked != 7*time.Hour {
+			t.Errorf("Expected tracked 7h, got %v", summary.Tracked)
+		}
+		if summary.WorkTime != 10*time.Hour {
+			t.Errorf("Expected work time 10h, got %v", summary.WorkTime)
+		}
+		// Only the first day has a gap: 8h worked, 5h tracked.
+		if summary.Untracked != 3*time.Hour {
+			t.Errorf("Expected untracked 3h, got %v", summary.Untracked)
+		}
+	})
+
+	t.Run("roll ups by client and project", func(t *testing.T) {
+		expectedClients := []TimeAllocation{
+			{Client: "acme", Duration: 5 * time.Hour},
+			{Client: "general", Duration: 2 * time.Hour},
+		}
+		if diff := cmp.Diff(expectedClients, summary.ByClient()); diff != "" {
+			t.Errorf("ByClient mismatch (-want +got):\n%s", diff)
+		}
+
+		expectedProjects := []TimeAllocation{
+			{Client: "acme", Project: "website", Duration: 5 * time.Hour},
+			{Client: "general", Project: "internal", Duration: 2 * time.Hour},
+		}
+		if diff := cmp.Diff(expectedProjects, summary.ByProject()); diff != "" {
+			t.Errorf("ByProject mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("share is a percentage of tracked time", func(t *testing.T) {
+		if got := summary.Share(summary.Tracked); got != 100 {
+			t.Errorf("Expected share 100, got %v", got)
+		}
+		if got := (TrackedTimeSummary{}).Share(time.Hour); got != 0 {
+			t.Errorf("Expected share 0 when nothing tracked, got %v", got)
+		}
+	})
+}
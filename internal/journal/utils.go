@@ -1,6 +1,7 @@
 package journal
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -18,6 +19,19 @@ func FetchEntryByID(id string, entries []JournalEntry) (*JournalEntry, int) {
 	return nil, -1
 }
 
+// FetchEntryForTime searches for the JournalEntry whose day contains t. The
+// day boundaries are those of the zone each entry was recorded in, so an
+// entry started in Berlin is still found when t is read in another zone.
+// It returns nil and -1 if no entry covers t.
+func FetchEntryForTime(t time.Time, entries []JournalEntry) (*JournalEntry, int) {
+	for i := range entries {
+		if entries[i].CoversTime(t) {
+			return &entries[i], i
+		}
+	}
+	return nil, -1
+}
+
 // FetchEntriesByWeekDate filters a slice of JournalEntry objects and returns a new slice
 // containing only the entries from the given date's week. The function uses the ISO week date
 // system, where weeks start on a Monday and the first week of the year is the one that
@@ -107,6 +121,91 @@ func FetchEntriesByMonthDate(journalEntries []JournalEntry, filterDate time.Time
 	return currentMonthEntries, nil
 }
 
+// WeekBounds returns the Monday and the Sunday of the ISO week containing t,
+// keeping the time of day and location of t.
+func WeekBounds(t time.Time) (time.Time, time.Time) {
+	offset := (int(t.Weekday()) + 6) % 7
+	monday := t.AddDate(0, 0, -offset)
+	return monday, monday.AddDate(0, 0, 6)
+}
+
+// MonthBounds returns midnight of the first and of the last day of the month
+// containing t, in the location of t.
+func MonthBounds(t time.Time) (time.Time, time.Time) {
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return first, first.AddDate(0, 1, -1)
+}
+
+// ParseISOWeek parses an ISO 8601 week such as "2026-W14" and returns midnight
+// of its Monday in loc. Weeks start on Monday and the first week of a year is
+// the one containing its first Thursday, so week 1 may start in December.
+func ParseISOWeek(s string, loc *time.Location) (time.Time, error) {
+	var year, week int
+	if _, err := fmt.Sscanf(strings.ToUpper(s), "%4d-W%2d", &year, &week); err != nil || len(s) != len("2006-W01") {
+		return time.Time{}, ValidationError("week", fmt.Sprintf("invalid week %q, expected YYYY-Www such as 2026-W14", s))
+	}
+
+	// January 4th is always in the first week of its year
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	monday, _ := WeekBounds(jan4)
+	monday = monday.AddDate(0, 0, 7*(week-1))
+	if y, w := monday.ISOWeek(); week < 1 || y != year || w != week {
+		return time.Time{}, ValidationError("week", fmt.Sprintf("%d has no week %d", year, week))
+	}
+	return monday, nil
+}
+
+// FormatISOWeek formats the ISO 8601 week of t, e.g. "2026-W14", the form
+// ParseISOWeek reads.
+func FormatISOWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// FetchEntriesByRange filters a slice of JournalEntry objects and returns a new slice
+// containing only the entries whose start date falls between from and to, both days
+// inclusive. Only the calendar day of from and to is considered, so the time of day
+// they carry does not matter.
+//
+// If no entries are passed, or no entries fall inside the range, the function returns
+// an error along with an empty slice. It also returns an error when from is after to.
+//
+// Example:
+//
+//	entries := []JournalEntry{...}
+//	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+//	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local)
+//	quarterEntries, err := FetchEntriesByRange(entries, from, to)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func FetchEntriesByRange(journalEntries []JournalEntry, from, to time.Time) ([]JournalEntry, error) {
+	if len(journalEntries) == 0 {
+		return nil, NoEntriesError("range report")
+	}
+
+	fromDay := from.Format("20060102")
+	toDay := to.Format("20060102")
+	if fromDay > toDay {
+		return nil, ValidationError("range", fmt.Sprintf("start date %s is after end date %s",
+			from.Format("2006-01-02"), to.Format("2006-01-02")))
+	}
+
+	var rangeEntries []JournalEntry
+	for _, entry := range journalEntries {
+		// YYYYMMDD strings sort chronologically, so a plain comparison is enough.
+		entryDay := entry.StartTime.Format("20060102")
+		if entryDay >= fromDay && entryDay <= toDay {
+			rangeEntries = append(rangeEntries, entry)
+		}
+	}
+	if len(rangeEntries) == 0 {
+		return nil, NoEntriesError(fmt.Sprintf("%s to %s", from.Format("2006-01-02"), to.Format("2006-01-02")))
+	}
+
+	return rangeEntries, nil
+}
+
 // CalculateTotalTime calculates the total time duration for a slice of JournalEntry.
 // It iterates over each entry in the slice and checks if the end time of the entry is after the start time.
 // If the end time is not after the start time, it returns an error indicating that the entry is invalid.
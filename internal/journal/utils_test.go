@@ -2,7 +2,9 @@ package journal
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -323,3 +325,131 @@ func TestFormatNoteWithTags(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchEntriesByRange(t *testing.T) {
+	entries := []JournalEntry{
+		{ID: "20260101", StartTime: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: "20260215", StartTime: time.Date(2026, 2, 15, 9, 0, 0, 0, time.UTC)},
+		{ID: "20260331", StartTime: time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC)},
+		{ID: "20260401", StartTime: time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)},
+	}
+
+	testCases := []struct {
+		name        string
+		entries     []JournalEntry
+		from        time.Time
+		to          time.Time
+		expectedIDs []string
+		expectErr   bool
+	}{
+		{
+			name:        "range boundaries are inclusive regardless of time of day",
+			entries:     entries,
+			from:        time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC),
+			to:          time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			expectedIDs: []string{"20260101", "20260215", "20260331"},
+		},
+		{
+			name:        "single day range",
+			entries:     entries,
+			from:        time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			to:          time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			expectedIDs: []string{"20260401"},
+		},
+		{
+			name:      "no entries in range returns an error",
+			entries:   entries,
+			from:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+			expectErr: true,
+		},
+		{
+			name:      "from after to returns an error",
+			entries:   entries,
+			from:      time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectErr: true,
+		},
+		{
+			name:      "empty slice returns an error",
+			entries:   []JournalEntry{},
+			from:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := FetchEntriesByRange(tc.entries, tc.from, tc.to)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			var ids []string
+			for _, entry := range result {
+				ids = append(ids, entry.ID)
+			}
+			if !reflect.DeepEqual(ids, tc.expectedIDs) {
+				t.Errorf("Expected entries %v, got %v", tc.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestWeekAndMonthBounds(t *testing.T) {
+	// Wednesday, 2026-04-01
+	date := time.Date(2026, 4, 1, 15, 30, 0, 0, time.UTC)
+
+	from, to := WeekBounds(date)
+	if from.Format("2006-01-02") != "2026-03-30" || to.Format("2006-01-02") != "2026-04-05" {
+		t.Errorf("WeekBounds = %s - %s, want 2026-03-30 - 2026-04-05", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	from, to = MonthBounds(date)
+	if from.Format("2006-01-02") != "2026-04-01" || to.Format("2006-01-02") != "2026-04-30" {
+		t.Errorf("MonthBounds = %s - %s, want 2026-04-01 - 2026-04-30", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+}
+
+func TestParseISOWeek(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "2026-W14", want: "2026-03-30"},
+		{input: "2026-w01", want: "2025-12-29"},
+		{input: "2026-W53", want: "2026-12-28"},
+		{input: "2025-W53", wantErr: true},
+		{input: "2026-W00", wantErr: true},
+		{input: "2026-W1", wantErr: true},
+		{input: "2026-14", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseISOWeek(tt.input, time.UTC)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", got.Format("2006-01-02"))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Format("2006-01-02") != tt.want || got.Weekday() != time.Monday {
+				t.Errorf("ParseISOWeek(%q) = %s, want Monday %s", tt.input, got.Format("2006-01-02"), tt.want)
+			}
+			if back := FormatISOWeek(got.AddDate(0, 0, 6)); !strings.EqualFold(back, tt.input) {
+				t.Errorf("FormatISOWeek() of its Sunday = %q, want %q", back, tt.input)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package journal
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -32,6 +33,22 @@ func ValidateNote(note Note) ValidationResult {
 	return ValidationResult{IsValid: true, Error: nil}
 }
 
+// tagPattern matches a whole tag, with the characters ParseNoteTags accepts.
+var tagPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ValidateTag validates a tag given on its own rather than as a hashtag of
+// a note, such as one added with the note tag command. It must be a word that
+// would be parsed as a hashtag, e.g. "team-sync".
+func ValidateTag(tag string) ValidationResult {
+	if !tagPattern.MatchString(tag) {
+		return ValidationResult{
+			IsValid: false,
+			Error:   ValidationError("tag", fmt.Sprintf("'%s' is not a valid tag, use letters, digits, '-' and '_'", tag)),
+		}
+	}
+	return ValidationResult{IsValid: true, Error: nil}
+}
+
 // ValidateEntry validates a journal entry
 func ValidateEntry(entry *JournalEntry) ValidationResult {
 	if entry == nil {
@@ -55,14 +72,38 @@ func ValidateEntry(entry *JournalEntry) ValidationResult {
 		}
 	}
 
-	// If end time is set, validate it's after start time
-	if !entry.EndTime.IsZero() && !entry.EndTime.After(entry.StartTime) {
+	// If end time is set, validate it's after start time. A day without work,
+	// such as a day off, starts and ends at midnight.
+	empty := len(entry.Sessions) == 0 && entry.EndTime.Equal(entry.StartTime)
+	if !entry.EndTime.IsZero() && !entry.EndTime.After(entry.StartTime) && !empty {
 		return ValidationResult{
 			IsValid: false,
 			Error:   InvalidEntryError(entry.ID, "end time must be after start time"),
 		}
 	}
 
+	// Sessions must each end after they start, in order and without overlapping
+	for i, session := range entry.Sessions {
+		if result := ValidateSession(session); !result.IsValid {
+			return ValidationResult{
+				IsValid: false,
+				Error:   ValidationError("sessions", fmt.Sprintf("session %d is invalid: %v", i+1, result.Error)),
+			}
+		}
+		if i > 0 && session.StartTime.Before(entry.Sessions[i-1].EndTime) {
+			return ValidationResult{
+				IsValid: false,
+				Error:   InvalidEntryError(entry.ID, fmt.Sprintf("session %d starts before session %d ends", i+1, i)),
+			}
+		}
+		if session.IsOpen() && i < len(entry.Sessions)-1 {
+			return ValidationResult{
+				IsValid: false,
+				Error:   InvalidEntryError(entry.ID, fmt.Sprintf("session %d is open but is not the last one", i+1)),
+			}
+		}
+	}
+
 	// Validate all breaks
 	for i, br := range entry.Breaks {
 		if result := ValidateBreak(br); !result.IsValid {
@@ -76,6 +117,25 @@ func ValidateEntry(entry *JournalEntry) ValidationResult {
 	return ValidationResult{IsValid: true, Error: nil}
 }
 
+// ValidateSession validates a work session
+func ValidateSession(session Session) ValidationResult {
+	if session.StartTime.IsZero() {
+		return ValidationResult{
+			IsValid: false,
+			Error:   ValidationError("session_start_time", "session start time cannot be zero"),
+		}
+	}
+
+	if !session.IsOpen() && !session.EndTime.After(session.StartTime) {
+		return ValidationResult{
+			IsValid: false,
+			Error:   ValidationError("session_end_time", "session end time must be after start time"),
+		}
+	}
+
+	return ValidationResult{IsValid: true, Error: nil}
+}
+
 // ValidateBreak validates a break entry
 func ValidateBreak(br Break) ValidationResult {
 	if br.StartTime.IsZero() {
@@ -102,16 +162,17 @@ func ValidateBreak(br Break) ValidationResult {
 	return ValidationResult{IsValid: true, Error: nil}
 }
 
-// FindCurrentDayEntry finds the entry for the current day
+// FindCurrentDayEntry finds the entry for the current day, judging each
+// entry by the day boundaries of the zone it was recorded in
 func FindCurrentDayEntry(entries []JournalEntry) (*JournalEntry, int, error) {
 	if len(entries) == 0 {
 		return nil, -1, NoEntriesError("current day lookup")
 	}
 
-	currentDayId := time.Now().Format("20060102")
-	entry, idx := FetchEntryByID(currentDayId, entries)
+	now := time.Now()
+	entry, idx := FetchEntryForTime(now, entries)
 	if idx == -1 {
-		return nil, -1, EntryNotFoundError(currentDayId)
+		return nil, -1, EntryNotFoundError(now.Format("20060102"))
 	}
 
 	return entry, idx, nil
@@ -118,6 +118,44 @@ func TestValidateEntry(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "valid entry with two sessions",
+			entry: &JournalEntry{
+				ID:        "20240101",
+				StartTime: now,
+				EndTime:   later.Add(2 * time.Hour),
+				Sessions: []Session{
+					{StartTime: now, EndTime: later},
+					{StartTime: later.Add(time.Hour), EndTime: later.Add(2 * time.Hour)},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "invalid entry with overlapping sessions",
+			entry: &JournalEntry{
+				ID:        "20240101",
+				StartTime: now,
+				EndTime:   later,
+				Sessions: []Session{
+					{StartTime: now, EndTime: later},
+					{StartTime: now.Add(30 * time.Minute), EndTime: later},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "invalid entry with an open session before the last one",
+			entry: &JournalEntry{
+				ID:        "20240101",
+				StartTime: now,
+				Sessions: []Session{
+					{StartTime: now},
+					{StartTime: later},
+				},
+			},
+			expected: false,
+		},
 		{
 			name: "invalid entry with invalid break",
 			entry: &JournalEntry{
@@ -548,4 +586,24 @@ func TestValidateConfigDuration(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestValidateTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{tag: "team", want: true},
+		{tag: "team-sync_2", want: true},
+		{tag: "", want: false},
+		{tag: "#team", want: false},
+		{tag: "two words", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := ValidateTag(tt.tag); got.IsValid != tt.want {
+				t.Errorf("ValidateTag(%q) = %v, want %v", tt.tag, got.IsValid, tt.want)
+			}
+		})
+	}
+}